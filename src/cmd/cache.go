@@ -0,0 +1,88 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023-Present The UDS Authors
+
+// Package cmd contains the CLI commands for UDS.
+package cmd
+
+import (
+	"time"
+
+	"github.com/defenseunicorns/zarf/src/pkg/message"
+	zarfUtils "github.com/defenseunicorns/zarf/src/pkg/utils"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	"github.com/defenseunicorns/uds-cli/src/config"
+	"github.com/defenseunicorns/uds-cli/src/config/lang"
+	"github.com/defenseunicorns/uds-cli/src/pkg/cache"
+)
+
+var pruneOlderThanDays int
+
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: lang.CmdCacheShort,
+}
+
+var cacheListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   lang.CmdCacheListShort,
+	Args:    cobra.NoArgs,
+	Run: func(_ *cobra.Command, _ []string) {
+		entries, err := cache.List()
+		if err != nil {
+			message.Fatalf(err, lang.CmdCacheErr, err)
+		}
+		if len(entries) == 0 {
+			message.Infof("The cache at %s is empty", config.CommonOptions.CachePath)
+			return
+		}
+		data := pterm.TableData{{"Type", "Digest", "Size", "Last Used"}}
+		for _, entry := range entries {
+			data = append(data, []string{entry.Kind, entry.Digest, zarfUtils.ByteFormat(float64(entry.Size), 2),
+				entry.LastUsed.Format(time.DateTime)})
+		}
+		if err := pterm.DefaultTable.WithHasHeader().WithData(data).Render(); err != nil {
+			message.Fatalf(err, lang.CmdCacheErr, err)
+		}
+		message.Infof("%d cached entries using %s in %s", len(entries), zarfUtils.ByteFormat(float64(cacheSize(entries)), 2),
+			config.CommonOptions.CachePath)
+	},
+}
+
+var cachePruneCmd = &cobra.Command{
+	Use:     "prune",
+	Aliases: []string{"clear"},
+	Short:   lang.CmdCachePruneShort,
+	Args:    cobra.NoArgs,
+	Run: func(_ *cobra.Command, _ []string) {
+		if pruneOlderThanDays < 0 {
+			message.Fatalf(nil, lang.CmdCachePruneErrOlderThan, pruneOlderThanDays)
+		}
+		pruned, err := cache.Prune(time.Duration(pruneOlderThanDays) * 24 * time.Hour)
+		if err != nil {
+			message.Fatalf(err, lang.CmdCacheErr, err)
+		}
+		for _, entry := range pruned {
+			message.Debugf("Removed cached %s %s", entry.Kind, entry.Digest)
+		}
+		message.Successf("Removed %d cached entries, freeing %s", len(pruned), zarfUtils.ByteFormat(float64(cacheSize(pruned)), 2))
+	},
+}
+
+// cacheSize returns the total size of cache entries
+func cacheSize(entries []cache.Entry) int64 {
+	var size int64
+	for _, entry := range entries {
+		size += entry.Size
+	}
+	return size
+}
+
+func init() {
+	rootCmd.AddCommand(cacheCmd)
+	cacheCmd.AddCommand(cacheListCmd)
+	cacheCmd.AddCommand(cachePruneCmd)
+	cachePruneCmd.Flags().IntVar(&pruneOlderThanDays, "older-than", 0, lang.CmdCachePruneFlagOlderThan)
+}
@@ -0,0 +1,100 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023-Present The UDS Authors
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/defenseunicorns/zarf/src/pkg/message"
+	"github.com/defenseunicorns/zarf/src/pkg/utils"
+	"github.com/spf13/cobra"
+
+	"github.com/defenseunicorns/uds-cli/src/config"
+	"github.com/defenseunicorns/uds-cli/src/config/lang"
+	"github.com/defenseunicorns/uds-cli/src/pkg/runner"
+	"github.com/defenseunicorns/uds-cli/src/types"
+)
+
+var completionCmd = &cobra.Command{
+	Use:                   "completion [bash|zsh|fish|powershell]",
+	Short:                 lang.CmdCompletionShort,
+	Long:                  lang.CmdCompletionLong,
+	DisableFlagsInUseLine: true,
+	ValidArgs:             []string{"bash", "zsh", "fish", "powershell"},
+	Args:                  cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+	// the script is written to stdout, so skip the CLI setup (logo, log file, etc.)
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {},
+	Run: func(cmd *cobra.Command, args []string) {
+		var err error
+		switch args[0] {
+		case "bash":
+			err = rootCmd.GenBashCompletionV2(os.Stdout, true)
+		case "zsh":
+			err = rootCmd.GenZshCompletion(os.Stdout)
+		case "fish":
+			err = rootCmd.GenFishCompletion(os.Stdout, true)
+		case "powershell":
+			err = rootCmd.GenPowerShellCompletionWithDesc(os.Stdout)
+		}
+		if err != nil {
+			message.Fatalf(err, "Unable to generate %s completion: %s", args[0], err.Error())
+		}
+	},
+}
+
+// completeTaskNames completes the name of a task in the tasks file given to `uds run` (or tasks.yaml by default)
+func completeTaskNames(cmd *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	tasksFileLocation, err := cmd.Flags().GetString("file")
+	if err != nil || tasksFileLocation == "" {
+		tasksFileLocation = config.TasksYAML
+	}
+	var tasksFile types.TasksFile
+	if err := utils.ReadYaml(tasksFileLocation, &tasksFile); err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	var names []string
+	for _, task := range runner.ListTasks(tasksFile, false) {
+		if task.Description != "" {
+			names = append(names, fmt.Sprintf("%s\t%s", task.Name, task.Description))
+			continue
+		}
+		names = append(names, task.Name)
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeBundleTarball completes the path to a local bundle tarball as a command's first arg
+func completeBundleTarball(_ *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return []string{"zst"}, cobra.ShellCompDirectiveFilterFileExt
+}
+
+// completeBundleDir completes the directory containing a uds-bundle.yaml as a command's first arg
+func completeBundleDir(_ *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return nil, cobra.ShellCompDirectiveFilterDirs
+}
+
+func init() {
+	// replace cobra's default completion cmd so that its output isn't mixed with the CLI setup
+	rootCmd.CompletionOptions.DisableDefaultCmd = true
+	rootCmd.AddCommand(completionCmd)
+
+	runCmd.ValidArgsFunction = completeTaskNames
+	createCmd.ValidArgsFunction = completeBundleDir
+	for _, cmd := range []*cobra.Command{deployCmd, inspectCmd, removeCmd, publishCmd} {
+		cmd.ValidArgsFunction = completeBundleTarball
+	}
+	pullCmd.ValidArgsFunction = cobra.NoFileCompletions
+}
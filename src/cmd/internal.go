@@ -13,6 +13,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/defenseunicorns/uds-cli/src/config/lang"
+	"github.com/defenseunicorns/uds-cli/src/pkg/runner"
 	"github.com/defenseunicorns/uds-cli/src/types"
 )
 
@@ -42,8 +43,7 @@ var configTasksSchemaCmd = &cobra.Command{
 	Aliases: []string{"c"},
 	Short:   lang.CmdInternalConfigSchemaShort,
 	Run: func(cmd *cobra.Command, args []string) {
-		schema := jsonschema.Reflect(&types.TasksFile{})
-		output, err := json.MarshalIndent(schema, "", "  ")
+		output, err := json.MarshalIndent(runner.TasksSchema(), "", "  ")
 		if err != nil {
 			message.Fatal(err, lang.CmdInternalConfigSchemaErr)
 		}
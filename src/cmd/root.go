@@ -13,8 +13,10 @@ import (
 	zarfConfig "github.com/defenseunicorns/zarf/src/config"
 	"github.com/defenseunicorns/zarf/src/pkg/message"
 	"github.com/defenseunicorns/zarf/src/pkg/utils/exec"
+	"github.com/pterm/pterm"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"golang.org/x/term"
 
 	"github.com/defenseunicorns/uds-cli/src/config"
 	"github.com/defenseunicorns/uds-cli/src/config/lang"
@@ -43,13 +45,18 @@ var rootCmd = &cobra.Command{
 			return
 		}
 
+		// Skip for shell completion requests, which run on every <TAB>
+		if cmd.Name() == cobra.ShellCompRequestCmd || cmd.Name() == cobra.ShellCompNoDescRequestCmd {
+			return
+		}
+
 		exec.ExitOnInterrupt()
 
 		// Don't add the logo to the help command
 		if cmd.Parent() == nil {
 			config.SkipLogFile = true
 		}
-		cliSetup()
+		cliSetup(cmd)
 	},
 	Short: lang.RootCmdShort,
 	Run: func(cmd *cobra.Command, args []string) {
@@ -83,8 +90,14 @@ func init() {
 	v.SetDefault(V_ARCHITECTURE, "")
 	v.SetDefault(V_NO_LOG_FILE, false)
 	v.SetDefault(V_NO_PROGRESS, false)
+	v.SetDefault(V_NO_COLOR, false)
 	v.SetDefault(V_INSECURE, false)
 	v.SetDefault(V_TMP_DIR, "")
+	v.SetDefault(V_PROXY, "")
+	v.SetDefault(V_OCI_RETRIES, config.DefaultOCIRetries)
+	v.SetDefault(V_REGISTRY_USERNAME, "")
+	v.SetDefault(V_REGISTRY_PASSWORD, "")
+	v.SetDefault(V_REGISTRY_TOKEN, "")
 
 	homeDir, _ := os.UserHomeDir()
 	v.SetDefault(V_UDS_CACHE, filepath.Join(homeDir, config.UDSCache))
@@ -93,15 +106,23 @@ func init() {
 	rootCmd.PersistentFlags().StringVarP(&config.CLIArch, "architecture", "a", v.GetString(common.VArchitecture), lang.RootCmdFlagArch)
 	rootCmd.PersistentFlags().BoolVar(&config.SkipLogFile, "no-log-file", v.GetBool(V_NO_LOG_FILE), lang.RootCmdFlagSkipLogFile)
 	rootCmd.PersistentFlags().BoolVar(&message.NoProgress, "no-progress", v.GetBool(V_NO_PROGRESS), lang.RootCmdFlagNoProgress)
+	rootCmd.PersistentFlags().BoolVar(&config.Plain, "plain", v.GetBool(V_PLAIN), lang.RootCmdFlagPlain)
+	rootCmd.PersistentFlags().BoolVar(&config.NoColor, "no-color", v.GetBool(V_NO_COLOR), lang.RootCmdFlagNoColor)
 	rootCmd.PersistentFlags().StringVar(&config.CommonOptions.CachePath, "uds-cache", v.GetString(V_UDS_CACHE), lang.RootCmdFlagCachePath)
 	rootCmd.PersistentFlags().StringVar(&config.CommonOptions.TempDirectory, "tmpdir", v.GetString(V_TMP_DIR), lang.RootCmdFlagTempDir)
 	rootCmd.PersistentFlags().BoolVar(&config.CommonOptions.Insecure, "insecure", v.GetBool(V_INSECURE), lang.RootCmdFlagInsecure)
+	rootCmd.PersistentFlags().StringVar(&config.CommonOptions.Proxy, "proxy", v.GetString(V_PROXY), lang.RootCmdFlagProxy)
+	rootCmd.PersistentFlags().StringVar(&config.CommonOptions.CAFile, "ca-file", v.GetString(V_CA_FILE), lang.RootCmdFlagCAFile)
+	rootCmd.PersistentFlags().IntVar(&config.CommonOptions.OCIRetries, "oci-retries", v.GetInt(V_OCI_RETRIES), lang.RootCmdFlagOCIRetries)
+	rootCmd.PersistentFlags().StringVar(&config.CommonOptions.RegistryUsername, "registry-username", v.GetString(V_REGISTRY_USERNAME), lang.RootCmdFlagRegistryUsername)
+	rootCmd.PersistentFlags().StringVar(&config.CommonOptions.RegistryPassword, "registry-password", v.GetString(V_REGISTRY_PASSWORD), lang.RootCmdFlagRegistryPassword)
+	rootCmd.PersistentFlags().StringVar(&config.CommonOptions.RegistryToken, "registry-token", v.GetString(V_REGISTRY_TOKEN), lang.RootCmdFlagRegistryToken)
 
 	// use system Zarf because of internal commands being using during zarf init (such as creating gitea users)
 	zarfConfig.ActionsUseSystemZarf = true
 }
 
-func cliSetup() {
+func cliSetup(cmd *cobra.Command) {
 	match := map[string]message.LogLevel{
 		"warn":  message.WarnLevel,
 		"info":  message.InfoLevel,
@@ -121,12 +142,44 @@ func cliSetup() {
 		}
 	}
 
+	if config.CommonOptions.OCIConcurrency < 1 {
+		message.Fatalf(nil, lang.RootCmdErrInvalidConcurrency, config.CommonOptions.OCIConcurrency)
+	}
+
 	// Disable progress bars for CI envs
 	if os.Getenv("CI") == "true" {
 		message.Debug("CI environment detected, disabling progress bars")
 		message.NoProgress = true
 	}
 
+	// NO_COLOR disables colors like --no-color, unless --no-color was given
+	noColorEnv, forceColor := utils.ColorEnv()
+	if noColorEnv && !cmd.Flags().Changed("no-color") && !v.IsSet(V_NO_COLOR) {
+		message.Debug("NO_COLOR is set, disabling colors")
+		config.NoColor = true
+	}
+
+	// Default to plain output when stdout isn't a terminal (e.g. it's piped to a file or a log aggregator)
+	plainByDefault := false
+	if !cmd.Flags().Changed("plain") && !v.IsSet(V_PLAIN) && !term.IsTerminal(int(os.Stdout.Fd())) {
+		message.Debug("stdout is not a terminal, using plain output")
+		config.Plain = true
+		plainByDefault = true
+	}
+	if config.Plain {
+		message.NoProgress = true
+	}
+	switch {
+	case config.NoColor:
+		message.DisableColor()
+	case forceColor && (!config.Plain || plainByDefault):
+		// CLICOLOR_FORCE keeps colors when stdout isn't a terminal, but not when --plain was given
+		message.Debug("CLICOLOR_FORCE is set, forcing colors")
+		pterm.EnableColor()
+	case config.Plain:
+		message.DisableColor()
+	}
+
 	if !config.SkipLogFile {
 		utils.UseLogFile()
 	}
@@ -4,8 +4,12 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"os"
+	"os/exec"
 	"strings"
+	"time"
 
 	"github.com/defenseunicorns/zarf/src/pkg/message"
 	"github.com/defenseunicorns/zarf/src/pkg/utils"
@@ -24,8 +28,29 @@ import (
 var runCmd = &cobra.Command{
 	Use:   "run [ TASK NAME ]",
 	Short: "run a task",
-	Long:  `run a task from an tasks file`,
-	Args:  cobra.ExactArgs(1),
+	Long: "run a task from an tasks file, or its default task when no task name is given\n\n" +
+		"with --env, run the command given after -- with the variables, env and working directory of a task instead",
+	Args: func(cmd *cobra.Command, args []string) error {
+		if config.ListTasks || config.ListAllTasks || config.CheckTasks || config.ValidateTasksSchema ||
+			len(config.TaskSelectors) > 0 {
+			return cobra.NoArgs(cmd, args)
+		}
+		if cmd.Flags().Changed("env") {
+			return cobra.MinimumNArgs(1)(cmd, args)
+		}
+		return cobra.MaximumNArgs(1)(cmd, args)
+	},
+	PreRun: func(cmd *cobra.Command, args []string) {
+		if config.LogFormat != runner.LogFormatText && config.LogFormat != runner.LogFormatJSON {
+			message.Fatalf(nil, "Invalid --log-format %q, must be %s or %s", config.LogFormat, runner.LogFormatText, runner.LogFormatJSON)
+		}
+		if config.RunDir != "" && !utils.IsDir(config.RunDir) {
+			message.Fatalf(nil, "Invalid --dir %q, must be an existing directory", config.RunDir)
+		}
+		if config.Resume && config.StateFile == "" {
+			message.Fatalf(nil, lang.CmdRunResumeErr)
+		}
+	},
 	Run: func(cmd *cobra.Command, args []string) {
 		var tasksFile types.TasksFile
 
@@ -33,27 +58,169 @@ var runCmd = &cobra.Command{
 			message.Fatalf(err, "%s not found", config.TaskFileLocation)
 		}
 
-		// Ensure uppercase keys from viper
-		v := common.GetViper()
-		config.SetVariables = helpers.TransformAndMergeMap(
-			v.GetStringMapString(common.VPkgCreateSet), config.SetVariables, strings.ToUpper)
+		if config.ValidateTasksSchema {
+			if err := runner.ValidateSchema(config.TaskFileLocation); err != nil {
+				var schemaErr *runner.SchemaError
+				if !errors.As(err, &schemaErr) {
+					message.Fatalf(err, "Unable to validate %s: %s", config.TaskFileLocation, err)
+				}
+				for _, problem := range schemaErr.Problems {
+					message.Warn(problem)
+				}
+				message.Fatalf(err, "Found %d schema error(s) in %s", len(schemaErr.Problems), config.TaskFileLocation)
+			}
+			message.Successf("%s matches the tasks schema", config.TaskFileLocation)
+			return
+		}
 
-		err := utils.ReadYaml(config.TaskFileLocation, &tasksFile)
+		fileVariables, err := runner.ReadVarsFiles(config.VarsFiles)
+		if err != nil {
+			message.Fatalf(err, "Unable to read variables: %s", err)
+		}
+
+		// Ensure uppercase keys from the environment, viper, variables files and CLI --set, with --set overriding the files
+		variables := helpers.TransformAndMergeMap(runner.EnvVariables(config.EnvPrefix), configVariables(), strings.ToUpper)
+		variables = helpers.TransformAndMergeMap(variables, fileVariables, strings.ToUpper)
+		if cmd.Flags().Changed("set") {
+			variables = helpers.TransformAndMergeMap(variables, config.SetVariables, strings.ToUpper)
+		}
+		config.SetVariables = variables
+
+		err = utils.ReadYaml(config.TaskFileLocation, &tasksFile)
 		if err != nil {
 			message.Fatalf(err, "Cannot unmarshal %s", config.TaskFileLocation)
 		}
 
-		taskName := args[0]
-		if err := runner.Run(tasksFile, taskName, config.SetVariables); err != nil {
+		if config.ListTasks || config.ListAllTasks {
+			if err := runner.PrintTasks(tasksFile, config.ListAllTasks, config.ListOutputFormat); err != nil {
+				message.Fatalf(err, "%s: %s", lang.CmdRunListErr, err)
+			}
+			return
+		}
+
+		if config.CheckTasks {
+			if err := runner.Check(tasksFile, config.SetVariables); err != nil {
+				var checkErr *runner.CheckError
+				if !errors.As(err, &checkErr) {
+					message.Fatalf(err, "Unable to check %s: %s", config.TaskFileLocation, err)
+				}
+				for _, problem := range checkErr.Problems {
+					message.Warn(problem)
+				}
+				message.Fatalf(err, "Found %d problem(s) in %s", len(checkErr.Problems), config.TaskFileLocation)
+			}
+			message.Successf("%s is valid", config.TaskFileLocation)
+			return
+		}
+
+		if cmd.Flags().Changed("env") {
+			runCommand(tasksFile, args)
+			return
+		}
+
+		var taskName string
+		if len(args) > 0 {
+			taskName = args[0]
+		}
+		flushTraces := startTracing()
+		if len(config.TaskSelectors) > 0 {
+			var taskNames []string
+			if taskNames, err = runner.SelectTasks(tasksFile, config.TaskSelectors); err == nil {
+				err = runner.RunTasks(tasksFile, taskNames, config.SetVariables)
+			}
+		} else if len(config.WatchPaths) > 0 {
+			err = runner.Watch(taskName, config.SetVariables, config.WatchPaths)
+		} else {
+			err = runner.Run(tasksFile, taskName, config.SetVariables)
+		}
+		flushTraces()
+		if err != nil {
+			if errors.Is(err, runner.ErrNoDefaultTask) {
+				message.Warn(lang.CmdRunNoDefaultTask)
+				if err := runner.PrintTasks(tasksFile, false, "table"); err != nil {
+					message.Fatalf(err, "%s: %s", lang.CmdRunListErr, err)
+				}
+				os.Exit(1)
+			}
 			message.Fatalf(err, "Failed to run action: %s", err)
 		}
 	},
 }
 
+// traceFlushTimeout is how long to wait for the spans of a run to be exported once it completes
+const traceFlushTimeout = 5 * time.Second
+
+// startTracing exports the spans of the run when --trace is given, returning a func that flushes them
+func startTracing() func() {
+	if !config.Trace {
+		return func() {}
+	}
+	shutdown, err := runner.StartTracing(context.Background())
+	if err != nil {
+		message.Fatalf(err, "Unable to start tracing: %s", err)
+	}
+	return func() {
+		ctx, cancel := context.WithTimeout(context.Background(), traceFlushTimeout)
+		defer cancel()
+		if err := shutdown(ctx); err != nil {
+			message.Warnf("Unable to export the traces of the run: %s", err)
+		}
+	}
+}
+
+// configVariables returns the runner variables set in the config file under run.set, along with the ones under the
+// deprecated package.create.set key that runner variables used to be read from (run.set wins)
+func configVariables() map[string]string {
+	variables := v.GetStringMapString(V_RUN_SET)
+	deprecated := v.GetStringMapString(common.VPkgCreateSet)
+	if len(deprecated) == 0 {
+		return variables
+	}
+	message.Warnf(lang.CmdRunDeprecatedSetKey, common.VPkgCreateSet, V_RUN_SET)
+	return helpers.MergeMap(deprecated, variables)
+}
+
+// runCommand runs an ad-hoc command with the environment of the --env task, exiting with the command's exit code
+func runCommand(tasksFile types.TasksFile, command []string) {
+	err := runner.RunCommand(tasksFile, config.CommandTask, config.SetVariables, command)
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil:
+	case errors.As(err, &exitErr) && exitErr.ExitCode() > 0:
+		os.Exit(exitErr.ExitCode())
+	default:
+		message.Fatalf(err, "Failed to run command: %s", err)
+	}
+}
+
 func init() {
 	initViper()
 	rootCmd.AddCommand(runCmd)
 	runFlags := runCmd.Flags()
 	runFlags.StringVarP(&config.TaskFileLocation, "file", "f", config.TasksYAML, lang.CmdRunFlag)
-	runFlags.StringToStringVar(&config.SetVariables, "set", v.GetStringMapString(common.VPkgCreateSet), lang.CmdRunSetVarFlag)
+	runFlags.StringToStringVar(&config.SetVariables, "set", v.GetStringMapString(V_RUN_SET), lang.CmdRunSetVarFlag)
+	runFlags.StringSliceVar(&config.VarsFiles, "vars-file", v.GetStringSlice(V_RUN_VARS_FILES), lang.CmdRunVarsFileFlag)
+	runFlags.StringVar(&config.EnvPrefix, "env-prefix", v.GetString(V_RUN_ENV_PREFIX), lang.CmdRunEnvPrefixFlag)
+	runFlags.BoolVar(&config.DryRun, "dry-run", false, lang.CmdRunDryRunFlag)
+	runFlags.BoolVar(&config.ListTasks, "list", false, lang.CmdRunListFlag)
+	runFlags.BoolVar(&config.ListAllTasks, "list-all", false, lang.CmdRunListAllFlag)
+	runFlags.StringVarP(&config.ListOutputFormat, "output", "o", "table", lang.CmdRunOutputFlag)
+	runFlags.BoolVar(&config.CheckTasks, "check", false, lang.CmdRunCheckFlag)
+	runFlags.BoolVar(&config.ValidateTasksSchema, "validate-schema", false, lang.CmdRunValidateSchemaFlag)
+	runFlags.StringVar(&config.CommandTask, "env", "", lang.CmdRunEnvFlag)
+	runFlags.BoolVar(&config.Strict, "strict", false, lang.CmdRunStrictFlag)
+	runFlags.BoolVarP(&config.CommonOptions.Confirm, "confirm", "c", false, lang.CmdRunConfirmFlag)
+	runFlags.StringVar(&config.StateFile, "state-file", v.GetString(V_RUN_STATE_FILE), lang.CmdRunStateFileFlag)
+	runFlags.BoolVar(&config.Resume, "resume", false, lang.CmdRunResumeFlag)
+	runFlags.DurationVar(&config.RunTimeout, "timeout", 0, lang.CmdRunTimeoutFlag)
+	runFlags.StringVar(&config.RunDir, "dir", "", lang.CmdRunDirFlag)
+	runFlags.StringVar(&config.LogFormat, "log-format", runner.LogFormatText, lang.CmdRunLogFormatFlag)
+	runFlags.StringSliceVar(&config.TaskSelectors, "selector", nil, lang.CmdRunSelectorFlag)
+	runFlags.StringSliceVar(&config.WatchPaths, "watch", nil, lang.CmdRunWatchFlag)
+	runFlags.BoolVar(&config.Trace, "trace", false, lang.CmdRunTraceFlag)
+	runFlags.IntVar(&config.DownloadRetries, "download-retries", config.DefaultDownloadRetries, lang.CmdRunDownloadRetriesFlag)
+	runCmd.MarkFlagsMutuallyExclusive("selector", "watch")
+	runCmd.MarkFlagsMutuallyExclusive("selector", "env")
+	runCmd.MarkFlagsMutuallyExclusive("resume", "watch")
+	runCmd.MarkFlagsMutuallyExclusive("resume", "dry-run")
 }
@@ -5,10 +5,15 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
+	"os/signal"
 	"path/filepath"
 	"strings"
+	"syscall"
+	"time"
 
 	"github.com/AlecAivazis/survey/v2"
 	zarfConfig "github.com/defenseunicorns/zarf/src/config"
@@ -52,12 +57,17 @@ var createCmd = &cobra.Command{
 
 		bundleCfg.CreateOpts.SetVariables = utils.MergeVariables(v.GetStringMapString(V_BNDL_CREATE_SET), bundleCfg.CreateOpts.SetVariables)
 
+		// fall back to cosign's password env var so the signing key password doesn't need to be passed as a flag
+		if bundleCfg.CreateOpts.SigningKeyPassword == "" {
+			bundleCfg.CreateOpts.SigningKeyPassword = os.Getenv("COSIGN_PASSWORD")
+		}
+
 		bndlClient := bundle.NewOrDie(&bundleCfg)
 		defer bndlClient.ClearPaths()
 
 		if err := bndlClient.Create(); err != nil {
 			bndlClient.ClearPaths()
-			message.Fatalf(err, "Failed to create bundle: %s", err.Error())
+			message.Fatalf(err, "Failed to create bundle: %s", utils.WithAuthHint(err))
 		}
 	},
 }
@@ -88,13 +98,50 @@ var deployCmd = &cobra.Command{
 		bndlClient := bundle.NewOrDie(&bundleCfg)
 		defer bndlClient.ClearPaths()
 
-		if err := bndlClient.Deploy(); err != nil {
+		ctx, cancel := deployContext(deployTimeout)
+		defer cancel()
+		if err := bndlClient.Deploy(ctx); err != nil {
+			// say why the deploy was canceled instead of only reporting the canceled request
+			if cause := context.Cause(ctx); cause != nil && !errors.Is(err, cause) {
+				err = fmt.Errorf("%w: %w", cause, err)
+			}
 			bndlClient.ClearPaths()
-			message.Fatalf(err, "Failed to deploy bundle: %s", err.Error())
+			message.Fatalf(err, "Failed to deploy bundle: %s", utils.WithAuthHint(err))
 		}
 	},
 }
 
+// deployTimeout bounds how long a deploy can take, zero means no limit
+var deployTimeout time.Duration
+
+// deployContext returns the context of a deploy, which is canceled once its timeout (if any) is exceeded or when the
+// CLI is interrupted (ie. Ctrl-C). A second interrupt is no longer caught, so it stops the CLI right away
+func deployContext(timeout time.Duration) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancelCause(context.Background())
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		select {
+		case sig := <-signals:
+			signal.Stop(signals)
+			cancel(fmt.Errorf("deploy interrupted by %s", sig))
+		case <-ctx.Done():
+		}
+	}()
+	stop := func() {
+		signal.Stop(signals)
+		cancel(nil)
+	}
+	if timeout <= 0 {
+		return ctx, stop
+	}
+	ctx, cancelTimeout := context.WithTimeoutCause(ctx, timeout, fmt.Errorf("deploy timed out after %s", timeout))
+	return ctx, func() {
+		cancelTimeout()
+		stop()
+	}
+}
+
 var inspectCmd = &cobra.Command{
 	Use:     "inspect [BUNDLE_TARBALL|OCI_REF]",
 	Aliases: []string{"i"},
@@ -105,6 +152,9 @@ var inspectCmd = &cobra.Command{
 		if cmd.Flag("extract").Value.String() == "true" && cmd.Flag("sbom").Value.String() == "false" {
 			message.Fatal(nil, "cannot use 'extract' flag without 'sbom' flag")
 		}
+		if output := cmd.Flag("output").Value.String(); output != "yaml" && output != "json" {
+			message.Fatalf(nil, "invalid output format %q, must be one of: yaml, json", output)
+		}
 	},
 	Run: func(cmd *cobra.Command, args []string) {
 		bundleCfg.InspectOpts.Source = choosePackage(args)
@@ -115,7 +165,33 @@ var inspectCmd = &cobra.Command{
 
 		if err := bndlClient.Inspect(); err != nil {
 			bndlClient.ClearPaths()
-			message.Fatalf(err, "Failed to inspect bundle: %s", err.Error())
+			message.Fatalf(err, "Failed to inspect bundle: %s", utils.WithAuthHint(err))
+		}
+	},
+}
+
+var diffCmd = &cobra.Command{
+	Use:   "diff [BUNDLE_TARBALL|OCI_REF] [BUNDLE_TARBALL|OCI_REF]",
+	Short: lang.CmdBundleDiffShort,
+	Args:  cobra.ExactArgs(2),
+	PreRun: func(cmd *cobra.Command, args []string) {
+		firstArgIsEitherOCIorTarball(nil, args[:1])
+		firstArgIsEitherOCIorTarball(nil, args[1:])
+		if output := cmd.Flag("output").Value.String(); output != "text" && output != "json" {
+			message.Fatalf(nil, "invalid output format %q, must be one of: text, json", output)
+		}
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		bundleCfg.DiffOpts.Source = args[0]
+		bundleCfg.DiffOpts.Target = args[1]
+		configureZarf()
+
+		bndlClient := bundle.NewOrDie(&bundleCfg)
+		defer bndlClient.ClearPaths()
+
+		if err := bndlClient.Diff(); err != nil {
+			bndlClient.ClearPaths()
+			message.Fatalf(err, "Failed to diff bundles: %s", utils.WithAuthHint(err))
 		}
 	},
 }
@@ -135,7 +211,7 @@ var removeCmd = &cobra.Command{
 
 		if err := bndlClient.Remove(); err != nil {
 			bndlClient.ClearPaths()
-			message.Fatalf(err, "Failed to remove bundle: %s", err.Error())
+			message.Fatalf(err, "Failed to remove bundle: %s", utils.WithAuthHint(err))
 		}
 	},
 }
@@ -163,7 +239,29 @@ var publishCmd = &cobra.Command{
 
 		if err := bndlClient.Publish(); err != nil {
 			bndlClient.ClearPaths()
-			message.Fatalf(err, "Failed to publish bundle: %s", err.Error())
+			message.Fatalf(err, "Failed to publish bundle: %s", utils.WithAuthHint(err))
+		}
+	},
+}
+
+var verifyCmd = &cobra.Command{
+	Use:   "verify [OCI_REF]",
+	Short: lang.CmdBundleVerifyShort,
+	Args:  cobra.ExactArgs(1),
+	PreRun: func(cmd *cobra.Command, args []string) {
+		if err := oci.ValidateReference(args[0]); err != nil {
+			message.Fatalf(err, "First argument (%q) must be a valid OCI URL: %s", args[0], err.Error())
+		}
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		bundleCfg.VerifyOpts.Source = args[0]
+		configureZarf()
+		bndlClient := bundle.NewOrDie(&bundleCfg)
+		defer bndlClient.ClearPaths()
+
+		if err := bndlClient.Verify(); err != nil {
+			bndlClient.ClearPaths()
+			message.Fatalf(err, "Failed to verify bundle: %s", utils.WithAuthHint(err))
 		}
 	},
 }
@@ -186,7 +284,7 @@ var pullCmd = &cobra.Command{
 
 		if err := bndlClient.Pull(); err != nil {
 			bndlClient.ClearPaths()
-			message.Fatalf(err, "Failed to pull bundle: %s", err.Error())
+			message.Fatalf(err, "Failed to pull bundle: %s", utils.WithAuthHint(err))
 		}
 	},
 }
@@ -222,16 +320,36 @@ func init() {
 	createCmd.Flags().StringVarP(&bundleCfg.CreateOpts.Output, "output", "o", v.GetString(V_BNDL_CREATE_OUTPUT), lang.CmdBundleCreateFlagOutput)
 	createCmd.Flags().StringVarP(&bundleCfg.CreateOpts.SigningKeyPath, "signing-key", "k", v.GetString(V_BNDL_CREATE_SIGNING_KEY), lang.CmdBundleCreateFlagSigningKey)
 	createCmd.Flags().StringVarP(&bundleCfg.CreateOpts.SigningKeyPassword, "signing-key-password", "p", v.GetString(V_BNDL_CREATE_SIGNING_KEY_PASSWORD), lang.CmdBundleCreateFlagSigningKeyPassword)
+	createCmd.Flags().BoolVar(&bundleCfg.CreateOpts.RequireDigests, "require-digests", v.GetBool(V_BNDL_CREATE_REQUIRE_DIGESTS), lang.CmdBundleCreateFlagRequireDigests)
+	createCmd.Flags().BoolVar(&bundleCfg.CreateOpts.AllowArchMismatch, "allow-arch-mismatch", v.GetBool(V_BNDL_CREATE_ALLOW_ARCH_MISMATCH), lang.CmdBundleCreateFlagAllowArchMismatch)
 
 	// deploy cmd flags
 	rootCmd.AddCommand(deployCmd)
 	deployCmd.Flags().BoolVarP(&config.CommonOptions.Confirm, "confirm", "c", false, lang.CmdBundleDeployFlagConfirm)
+	deployCmd.Flags().StringVarP(&bundleCfg.DeployOpts.PublicKeyPath, "key", "k", v.GetString(V_BNDL_DEPLOY_KEY), lang.CmdBundleDeployFlagKey)
+	deployCmd.Flags().BoolVar(&bundleCfg.DeployOpts.SkipSignatureValidation, "skip-signature-validation", v.GetBool(V_BNDL_DEPLOY_SKIP_SIGNATURE_VALIDATION), lang.CmdBundleFlagSkipSignatureValidation)
+	deployCmd.Flags().BoolVar(&bundleCfg.DeployOpts.StrictSignatureValidation, "strict-signature-validation", v.GetBool(V_BNDL_DEPLOY_STRICT_SIGNATURE_VALIDATION), lang.CmdBundleFlagStrictSignatureValidation)
+	deployCmd.MarkFlagsMutuallyExclusive("key", "skip-signature-validation")
+	deployCmd.MarkFlagsMutuallyExclusive("strict-signature-validation", "skip-signature-validation")
+	deployCmd.Flags().StringSliceVar(&bundleCfg.DeployOpts.Packages, "packages", v.GetStringSlice(V_BNDL_DEPLOY_PACKAGES), lang.CmdBundleDeployFlagPackages)
+	deployCmd.Flags().StringToStringVar(&bundleCfg.DeployOpts.PackageRefs, "ref", v.GetStringMapString(V_BNDL_DEPLOY_REFS), lang.CmdBundleDeployFlagRef)
+	deployCmd.Flags().DurationVar(&deployTimeout, "timeout", v.GetDuration(V_BNDL_DEPLOY_TIMEOUT), lang.CmdBundleDeployFlagTimeout)
 
 	// inspect cmd flags
 	rootCmd.AddCommand(inspectCmd)
 	inspectCmd.Flags().BoolVarP(&bundleCfg.InspectOpts.IncludeSBOM, "sbom", "s", false, lang.CmdPackageInspectFlagSBOM)
 	inspectCmd.Flags().BoolVarP(&bundleCfg.InspectOpts.ExtractSBOM, "extract", "e", false, lang.CmdPackageInspectFlagExtractSBOM)
 	inspectCmd.Flags().StringVarP(&bundleCfg.InspectOpts.PublicKeyPath, "key", "k", v.GetString(V_BNDL_INSPECT_KEY), lang.CmdBundleInspectFlagKey)
+	inspectCmd.Flags().BoolVar(&bundleCfg.InspectOpts.SkipSignatureValidation, "skip-signature-validation", v.GetBool(V_BNDL_INSPECT_SKIP_SIGNATURE_VALIDATION), lang.CmdBundleFlagSkipSignatureValidation)
+	inspectCmd.Flags().BoolVar(&bundleCfg.InspectOpts.StrictSignatureValidation, "strict-signature-validation", v.GetBool(V_BNDL_INSPECT_STRICT_SIGNATURE_VALIDATION), lang.CmdBundleFlagStrictSignatureValidation)
+	inspectCmd.MarkFlagsMutuallyExclusive("key", "skip-signature-validation")
+	inspectCmd.MarkFlagsMutuallyExclusive("strict-signature-validation", "skip-signature-validation")
+	inspectCmd.Flags().StringVarP(&bundleCfg.InspectOpts.Output, "output", "o", "yaml", lang.CmdBundleInspectFlagOutput)
+	inspectCmd.Flags().BoolVar(&bundleCfg.InspectOpts.NoBuildData, "no-build-data", false, lang.CmdBundleInspectFlagNoBuildData)
+
+	// diff cmd flags
+	rootCmd.AddCommand(diffCmd)
+	diffCmd.Flags().StringVarP(&bundleCfg.DiffOpts.Output, "output", "o", "text", lang.CmdBundleDiffFlagOutput)
 
 	// remove cmd flags
 	rootCmd.AddCommand(removeCmd)
@@ -241,11 +359,27 @@ func init() {
 
 	// publish cmd flags
 	rootCmd.AddCommand(publishCmd)
+	publishCmd.Flags().BoolVar(&bundleCfg.PublishOpts.SizeOnly, "size-only", false, lang.CmdPublishFlagSizeOnly)
 
 	// pull cmd flags
 	rootCmd.AddCommand(pullCmd)
 	pullCmd.Flags().StringVarP(&bundleCfg.PullOpts.OutputDirectory, "output", "o", v.GetString(V_BNDL_PULL_OUTPUT), lang.CmdBundlePullFlagOutput)
 	pullCmd.Flags().StringVarP(&bundleCfg.PullOpts.PublicKeyPath, "key", "k", v.GetString(V_BNDL_PULL_KEY), lang.CmdBundlePullFlagKey)
+	pullCmd.Flags().BoolVar(&bundleCfg.PullOpts.SkipSignatureValidation, "skip-signature-validation", v.GetBool(V_BNDL_PULL_SKIP_SIGNATURE_VALIDATION), lang.CmdBundleFlagSkipSignatureValidation)
+	pullCmd.Flags().BoolVar(&bundleCfg.PullOpts.StrictSignatureValidation, "strict-signature-validation", v.GetBool(V_BNDL_PULL_STRICT_SIGNATURE_VALIDATION), lang.CmdBundleFlagStrictSignatureValidation)
+	pullCmd.MarkFlagsMutuallyExclusive("key", "skip-signature-validation")
+	pullCmd.MarkFlagsMutuallyExclusive("strict-signature-validation", "skip-signature-validation")
+	pullCmd.Flags().StringVar(&bundleCfg.PullOpts.PackagesDirectory, "output-dir", v.GetString(V_BNDL_PULL_OUTPUT_DIR), lang.CmdBundlePullFlagOutputDir)
+	pullCmd.Flags().BoolVar(&bundleCfg.PullOpts.Force, "force", false, lang.CmdBundlePullFlagForce)
+	pullCmd.MarkFlagsMutuallyExclusive("output", "output-dir")
+
+	// verify cmd flags
+	rootCmd.AddCommand(verifyCmd)
+	verifyCmd.Flags().StringVarP(&bundleCfg.VerifyOpts.PublicKeyPath, "key", "k", v.GetString(V_BNDL_VERIFY_KEY), lang.CmdBundleVerifyFlagKey)
+	verifyCmd.Flags().BoolVar(&bundleCfg.VerifyOpts.SkipSignatureValidation, "skip-signature-validation", v.GetBool(V_BNDL_VERIFY_SKIP_SIGNATURE_VALIDATION), lang.CmdBundleFlagSkipSignatureValidation)
+	verifyCmd.Flags().BoolVar(&bundleCfg.VerifyOpts.StrictSignatureValidation, "strict-signature-validation", v.GetBool(V_BNDL_VERIFY_STRICT_SIGNATURE_VALIDATION), lang.CmdBundleFlagStrictSignatureValidation)
+	verifyCmd.MarkFlagsMutuallyExclusive("key", "skip-signature-validation")
+	verifyCmd.MarkFlagsMutuallyExclusive("strict-signature-validation", "skip-signature-validation")
 }
 
 // configureZarf copies configs from UDS-CLI to Zarf
@@ -5,28 +5,104 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
+	"runtime"
+	"runtime/debug"
 
+	"github.com/defenseunicorns/zarf/src/pkg/message"
 	"github.com/spf13/cobra"
 
 	"github.com/defenseunicorns/uds-cli/src/config"
 	"github.com/defenseunicorns/uds-cli/src/config/lang"
+	"github.com/defenseunicorns/uds-cli/src/pkg/utils"
 )
 
+var (
+	versionOutput      string
+	versionCheckUpdate bool
+)
+
+// versionInfo is the build metadata of the running binary
+type versionInfo struct {
+	Version     string `json:"version"`
+	Commit      string `json:"commit"`
+	BuildDate   string `json:"buildDate"`
+	GoVersion   string `json:"goVersion"`
+	ZarfVersion string `json:"zarfVersion"`
+	Platform    string `json:"platform"`
+}
+
 var versionCmd = &cobra.Command{
 	Use:     "version",
 	Aliases: []string{"v"},
 	PersistentPreRun: func(cmd *cobra.Command, args []string) {
 		config.SkipLogFile = true
-		cliSetup()
+		cliSetup(cmd)
 	},
 	Short: lang.CmdVersionShort,
 	Long:  lang.CmdVersionLong,
 	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println(config.CLIVersion)
+		switch versionOutput {
+		case "json":
+			output, err := json.MarshalIndent(getVersionInfo(), "", "  ")
+			if err != nil {
+				message.Fatalf(err, "Unable to marshal version info: %s", err.Error())
+			}
+			fmt.Println(string(output))
+		case "text", "":
+			fmt.Println(config.CLIVersion)
+		default:
+			message.Fatalf(nil, "invalid output format %q, must be one of: text, json", versionOutput)
+		}
+
+		if versionCheckUpdate {
+			if latest := utils.CheckForUpdate(cmd.Context(), config.CLIVersion); latest != "" {
+				message.Warnf("A newer version %s is available (currently %s), see https://github.com/defenseunicorns/uds-cli/releases", latest, config.CLIVersion)
+			}
+		}
 	},
 }
 
+// getVersionInfo gathers the build metadata of the running binary, using the Go build info for anything that wasn't
+// set with ldflags at build time
+func getVersionInfo() versionInfo {
+	info := versionInfo{
+		Version:   config.CLIVersion,
+		Commit:    config.CLICommit,
+		BuildDate: config.CLIBuildDate,
+		GoVersion: runtime.Version(),
+		Platform:  fmt.Sprintf("%s/%s", runtime.GOOS, runtime.GOARCH),
+	}
+
+	buildInfo, ok := debug.ReadBuildInfo()
+	if !ok {
+		return info
+	}
+	for _, setting := range buildInfo.Settings {
+		switch {
+		case setting.Key == "vcs.revision" && info.Commit == "":
+			info.Commit = setting.Value
+		case setting.Key == "vcs.time" && info.BuildDate == "":
+			info.BuildDate = setting.Value
+		}
+	}
+	for _, dep := range buildInfo.Deps {
+		if dep.Path == "github.com/defenseunicorns/zarf" {
+			info.ZarfVersion = dep.Version
+			if dep.Replace != nil {
+				info.ZarfVersion = dep.Replace.Version
+			}
+		}
+	}
+	return info
+}
+
 func init() {
+	initViper()
+	v.SetDefault(V_VERSION_CHECK_UPDATE, false)
+
 	rootCmd.AddCommand(versionCmd)
+	versionCmd.Flags().StringVarP(&versionOutput, "output", "o", "text", lang.CmdVersionFlagOutput)
+	versionCmd.Flags().BoolVar(&versionCheckUpdate, "check-update", v.GetBool(V_VERSION_CHECK_UPDATE), lang.CmdVersionFlagCheckUpdate)
 }
@@ -23,9 +23,22 @@ const (
 	V_ARCHITECTURE = "architecture"
 	V_NO_LOG_FILE  = "no_log_file"
 	V_NO_PROGRESS  = "no_progress"
+	V_PLAIN        = "plain"
+	V_NO_COLOR     = "no_color"
 	V_UDS_CACHE    = "uds_cache"
 	V_TMP_DIR      = "tmp_dir"
 	V_INSECURE     = "insecure"
+	V_PROXY        = "proxy"
+	V_CA_FILE      = "ca_file"
+	V_OCI_RETRIES  = "oci_retries"
+
+	// Registry auth config keys
+	V_REGISTRY_USERNAME = "registry_username"
+	V_REGISTRY_PASSWORD = "registry_password"
+	V_REGISTRY_TOKEN    = "registry_token"
+
+	// Version config keys
+	V_VERSION_CHECK_UPDATE = "version.check_update"
 
 	// Bundle config keys
 	V_BNDL_OCI_CONCURRENCY = "bundle.oci_concurrency"
@@ -35,19 +48,43 @@ const (
 	V_BNDL_CREATE_SIGNING_KEY          = "bundle.create.signing_key"
 	V_BNDL_CREATE_SIGNING_KEY_PASSWORD = "bundle.create.signing_key_password"
 	V_BNDL_CREATE_SET                  = "bundle.create.set"
+	V_BNDL_CREATE_REQUIRE_DIGESTS      = "bundle.create.require_digests"
+	V_BNDL_CREATE_ALLOW_ARCH_MISMATCH  = "bundle.create.allow_arch_mismatch"
 
 	// Bundle deploy config keys
-	V_BNDL_DEPLOY_ZARF_PACKAGES = "bundle.deploy.zarf-packages"
+	V_BNDL_DEPLOY_ZARF_PACKAGES               = "bundle.deploy.zarf-packages"
+	V_BNDL_DEPLOY_KEY                         = "bundle.deploy.key"
+	V_BNDL_DEPLOY_PACKAGES                    = "bundle.deploy.packages"
+	V_BNDL_DEPLOY_REFS                        = "bundle.deploy.refs"
+	V_BNDL_DEPLOY_SKIP_SIGNATURE_VALIDATION   = "bundle.deploy.skip_signature_validation"
+	V_BNDL_DEPLOY_STRICT_SIGNATURE_VALIDATION = "bundle.deploy.strict_signature_validation"
+	V_BNDL_DEPLOY_TIMEOUT                     = "bundle.deploy.timeout"
 
 	// Bundle inspect config keys
-	V_BNDL_INSPECT_KEY = "bundle.inspect.key"
+	V_BNDL_INSPECT_KEY                         = "bundle.inspect.key"
+	V_BNDL_INSPECT_SKIP_SIGNATURE_VALIDATION   = "bundle.inspect.skip_signature_validation"
+	V_BNDL_INSPECT_STRICT_SIGNATURE_VALIDATION = "bundle.inspect.strict_signature_validation"
 
 	// Bundle remove config keys
 	V_BNDL_REMOVE_PACKAGES = "bundle.remove.packages"
 
 	// Bundle pull config keys
-	V_BNDL_PULL_OUTPUT = "bundle.pull.output"
-	V_BNDL_PULL_KEY    = "bundle.pull.key"
+	V_BNDL_PULL_OUTPUT                      = "bundle.pull.output"
+	V_BNDL_PULL_KEY                         = "bundle.pull.key"
+	V_BNDL_PULL_OUTPUT_DIR                  = "bundle.pull.output_dir"
+	V_BNDL_PULL_SKIP_SIGNATURE_VALIDATION   = "bundle.pull.skip_signature_validation"
+	V_BNDL_PULL_STRICT_SIGNATURE_VALIDATION = "bundle.pull.strict_signature_validation"
+
+	// Bundle verify config keys
+	V_BNDL_VERIFY_KEY                         = "bundle.verify.key"
+	V_BNDL_VERIFY_SKIP_SIGNATURE_VALIDATION   = "bundle.verify.skip_signature_validation"
+	V_BNDL_VERIFY_STRICT_SIGNATURE_VALIDATION = "bundle.verify.strict_signature_validation"
+
+	// Run config keys
+	V_RUN_SET        = "run.set"
+	V_RUN_VARS_FILES = "run.vars_files"
+	V_RUN_ENV_PREFIX = "run.env_prefix"
+	V_RUN_STATE_FILE = "run.state_file"
 )
 
 func initViper() {
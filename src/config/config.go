@@ -6,6 +6,8 @@ package config
 
 import (
 	"runtime"
+	"strings"
+	"time"
 
 	zarfConfig "github.com/defenseunicorns/zarf/src/config"
 	zarfTypes "github.com/defenseunicorns/zarf/src/types"
@@ -47,31 +49,107 @@ const (
 	// UDSCache is the directory containing cached bundle layers
 	UDSCache = ".uds-cache"
 
+	// DefaultOCIRetries is the default number of times a registry operation that failed with a transient error is retried
+	DefaultOCIRetries = 3
+
+	// DefaultDownloadRetries is the default number of times a failed download of a task's file is retried
+	DefaultDownloadRetries = 3
+
 	// TasksYAML is the default name of the uds run cmd file
 	TasksYAML = "tasks.yaml"
 )
 
 var (
 	// CommonOptions tracks user-defined values that apply across commands.
-	CommonOptions types.BundlerCommonOptions
+	CommonOptions = types.BundlerCommonOptions{OCIRetries: DefaultOCIRetries}
 
 	// CLIVersion track the version of the CLI
 	CLIVersion = "unset"
 
+	// CLICommit tracks the git commit the CLI was built from (falls back to the Go build info when unset)
+	CLICommit = ""
+
+	// CLIBuildDate tracks when the CLI was built (falls back to the commit time from the Go build info when unset)
+	CLIBuildDate = ""
+
 	// CLIArch is the computer architecture of the device executing the CLI commands
 	CLIArch string
 
 	// SkipLogFile is a flag to skip logging to a file
 	SkipLogFile bool
 
+	// Plain is a flag to print plain output, without colors or animated spinners and progress bars
+	Plain bool
+
+	// NoColor is a flag to print output without colors
+	NoColor bool
+
 	// TaskFileLocation is the location of the tasks file to run
 	TaskFileLocation string
 
+	// RunDir is the base directory of a run's relative paths, defaulting to the directory of the tasks file
+	RunDir string
+
 	// SetVariables is a map of the run time variables defined using --set
 	SetVariables map[string]string
+
+	// VarsFiles are the YAML files to read runner variables from
+	VarsFiles []string
+
+	// EnvPrefix is the prefix of the environment variables that are imported as runner variables
+	EnvPrefix string
+
+	// DryRun is a flag to print the commands and file operations of a task instead of running them
+	DryRun bool
+
+	// CommandTask is the task whose environment an ad-hoc command (given after --) is run with
+	CommandTask string
+
+	// Strict is a flag to fail commands that reference variables that aren't set
+	Strict bool
+
+	// RunTimeout is the time budget of a whole run, zero means no limit
+	RunTimeout time.Duration
+
+	// StateFile is the file the completed actions of a run are recorded to, so that a failed run can be resumed
+	StateFile string
+
+	// Resume is a flag to skip the actions recorded as completed in the state file by the previous (failed) run
+	Resume bool
+
+	// TaskSelectors select the tasks to run by label (e.g. label=ci) instead of by name
+	TaskSelectors []string
+
+	// WatchPaths are the files and directories whose changes run the task again
+	WatchPaths []string
+
+	// LogFormat is the format (text or json) used to report the progress of a run
+	LogFormat string
+
+	// Trace is a flag to export OpenTelemetry spans of runs, tasks and actions to OTEL_EXPORTER_OTLP_ENDPOINT
+	Trace bool
+
+	// DownloadRetries is how many times a failed download of a task's file is retried, unless the file sets retries
+	DownloadRetries = DefaultDownloadRetries
+
+	// ListTasks is a flag to print the tasks in the tasks file instead of running one
+	ListTasks bool
+
+	// ListAllTasks is a flag to print all tasks in the tasks file, including internal ones
+	ListAllTasks bool
+
+	// ListOutputFormat is the format (table or json) to list tasks in
+	ListOutputFormat string
+
+	// CheckTasks is a flag to validate the tasks file instead of running a task
+	CheckTasks bool
+
+	// ValidateTasksSchema is a flag to validate the tasks file against the tasks schema instead of running a task
+	ValidateTasksSchema bool
 )
 
-// GetArch returns the arch based on a priority list with options for overriding.
+// GetArch returns the arch based on a priority list with options for overriding, falling back to the arch of the
+// host; aliases such as x86_64 and aarch64 are mapped to the arch names Zarf uses
 func GetArch(archs ...string) string {
 	// List of architecture overrides.
 	priority := append([]string{CLIArch}, archs...)
@@ -79,11 +157,23 @@ func GetArch(archs ...string) string {
 	// Find the first architecture that is specified.
 	for _, arch := range priority {
 		if arch != "" {
-			return arch
+			return zarfArch(arch)
 		}
 	}
 
-	return runtime.GOARCH
+	return zarfArch(runtime.GOARCH)
+}
+
+// zarfArch maps the common aliases of an arch to the name Zarf uses for it
+func zarfArch(arch string) string {
+	switch strings.ToLower(arch) {
+	case "x86_64", "x86-64", "x64", "amd64":
+		return "amd64"
+	case "aarch64", "arm64":
+		return "arm64"
+	default:
+		return arch
+	}
 }
 
 var (
@@ -6,48 +6,86 @@ package lang
 
 const (
 	// root UDS-CLI cmds
-	RootCmdShort              = "CLI for UDS Bundles"
-	RootCmdFlagSkipLogFile    = "Disable log file creation"
-	RootCmdFlagNoProgress     = "Disable fancy UI progress bars, spinners, logos, etc"
-	RootCmdFlagCachePath      = "Specify the location of the Zarf cache directory"
-	RootCmdFlagTempDir        = "Specify the temporary directory to use for intermediate files"
-	RootCmdFlagInsecure       = "Allow access to insecure registries and disable other recommended security enforcements such as package checksum and signature validation. This flag should only be used if you have a specific reason and accept the reduced security posture."
-	RootCmdFlagLogLevel       = "Log level when running UDS-CLI. Valid options are: warn, info, debug, trace"
-	RootCmdErrInvalidLogLevel = "Invalid log level. Valid options are: warn, info, debug, trace."
-	RootCmdFlagArch           = "Architecture for UDS bundles and Zarf packages"
+	RootCmdShort                 = "CLI for UDS Bundles"
+	RootCmdFlagSkipLogFile       = "Disable log file creation"
+	RootCmdFlagNoProgress        = "Disable fancy UI progress bars, spinners, logos, etc"
+	RootCmdFlagPlain             = "Print plain output without colors, spinners or progress bars, line by line (the default when stdout isn't a terminal)"
+	RootCmdFlagNoColor           = "Disable colors in output (the default when the NO_COLOR env var is set)"
+	RootCmdFlagCachePath         = "Specify the location of the Zarf cache directory"
+	RootCmdFlagTempDir           = "Specify the temporary directory to use for intermediate files"
+	RootCmdFlagInsecure          = "Allow access to insecure registries and disable other recommended security enforcements such as package checksum and signature validation. This flag should only be used if you have a specific reason and accept the reduced security posture."
+	RootCmdFlagLogLevel          = "Log level when running UDS-CLI. Valid options are: warn, info, debug, trace"
+	RootCmdErrInvalidLogLevel    = "Invalid log level. Valid options are: warn, info, debug, trace."
+	RootCmdErrInvalidConcurrency = "Invalid --oci-concurrency %d, must be at least 1"
+	RootCmdFlagArch              = "Architecture for UDS bundles and Zarf packages (defaults to the bundle's metadata.architecture, then the architecture of the machine)"
+	RootCmdFlagProxy             = "Proxy URL to use for connections to OCI registries (defaults to the HTTPS_PROXY, HTTP_PROXY and NO_PROXY environment variables)"
+	RootCmdFlagCAFile            = "Path to a PEM file of CA certificates to trust for connections to OCI registries, in addition to the system's (e.g. for a registry with a self-signed certificate)"
+	RootCmdFlagOCIRetries        = "Number of times a registry operation that failed with a transient error (5xx, 429 or a dropped connection) is retried"
+	RootCmdFlagRegistryUsername  = "Username to authenticate to OCI registries with (overrides the Docker config file)"
+	RootCmdFlagRegistryPassword  = "Password to authenticate to OCI registries with, given with --registry-username (or the UDS_REGISTRY_PASSWORD environment variable)"
+	RootCmdFlagRegistryToken     = "Bearer token to authenticate to OCI registries with (overrides the Docker config file)"
 
 	// bundle
-	CmdBundleShort           = "Commands for creating, deploying, removing, pulling, and inspecting bundles"
-	CmdBundleFlagConcurrency = "Number of concurrent layer operations to perform when interacting with a remote bundle."
+	CmdBundleShort                         = "Commands for creating, deploying, removing, pulling, and inspecting bundles"
+	CmdBundleFlagConcurrency               = "Number of concurrent layer operations to perform when interacting with a remote bundle (used by create, deploy, publish and pull), must be at least 1."
+	CmdBundleFlagSkipSignatureValidation   = "Skip validating the signature of a signed bundle. ONLY use with bundles you trust."
+	CmdBundleFlagStrictSignatureValidation = "Fail when the bundle is signed but no --key was provided to validate its signature"
 
 	// bundle create
 	CmdBundleCreateShort = "Create a bundle from a given directory or the current directory"
 	//CmdBundleCreateFlagConfirm            = "Confirm bundle creation without prompting"
 	CmdBundleCreateFlagOutput             = "Specify the output (an oci:// URL) for the created bundle"
-	CmdBundleCreateFlagSigningKey         = "Path to private key file for signing bundles"
-	CmdBundleCreateFlagSigningKeyPassword = "Password to the private key file used for signing bundles"
+	CmdBundleCreateFlagSigningKey         = "Path to a private key file (or a KMS URI) for signing bundles"
+	CmdBundleCreateFlagSigningKeyPassword = "Password to the private key file used for signing bundles (defaults to the COSIGN_PASSWORD environment variable)"
+	CmdBundleCreateFlagRequireDigests     = "Reject packages referenced by a mutable tag instead of pinning them to the digest the tag resolves to"
+	CmdBundleCreateFlagAllowArchMismatch  = "Only warn about packages built for an architecture other than the bundle's instead of failing"
 
 	// bundle deploy
-	CmdBundleDeployShort       = "Deploy a bundle from a local tarball or oci:// URL"
-	CmdBundleDeployFlagConfirm = "Confirms bundle deployment without prompting. ONLY use with bundles you trust. Skips prompts to review SBOM, configure variables, select optional components and review potential breaking changes."
+	CmdBundleDeployShort        = "Deploy a bundle from a local tarball or oci:// URL"
+	CmdBundleDeployFlagKey      = "Path to a public key file (or a cosign KMS URI) that will be used to validate a signed bundle"
+	CmdBundleDeployFlagRef      = "Deploy a package from a Zarf package ref instead of from the bundle (e.g. --ref pkg=ghcr.io/org/pkg:tag), the package's signature is not verified"
+	CmdBundleDeployFlagPackages = "Only deploy these packages of the bundle (e.g. --packages pkg1,pkg2), the packages they depend on must already be deployed"
+	CmdBundleDeployFlagTimeout  = "Maximum time the deploy can take (e.g. 30m), when it is exceeded the bundle's pulls and registry requests are canceled and no more packages are deployed (0 means no limit)"
+	CmdBundleDeployFlagConfirm  = "Confirms bundle deployment without prompting. ONLY use with bundles you trust. Skips prompts to review SBOM, configure variables, select optional components and review potential breaking changes."
 
 	// bundle inspect
 	CmdBundleInspectShort            = "Display the metadata of a bundle"
-	CmdBundleInspectFlagKey          = "Path to a public key file that will be used to validate a signed bundle"
+	CmdBundleInspectFlagKey          = "Path to a public key file (or a cosign KMS URI) that will be used to validate a signed bundle"
+	CmdBundleInspectFlagOutput       = "Output format of the bundle's metadata (yaml or json)"
+	CmdBundleInspectFlagNoBuildData  = "Omit the bundle's build data (ie. the user and machine that created it) from the output"
 	CmdPackageInspectFlagSBOM        = "Create a tarball of SBOMs contained in the bundle"
 	CmdPackageInspectFlagExtractSBOM = "Create a folder of SBOMs contained in the bundle"
 
+	// bundle diff
+	CmdBundleDiffShort      = "Show the differences between the packages of two bundles"
+	CmdBundleDiffFlagOutput = "Output format of the differences (text or json)"
+
 	// bundle remove
 	CmdBundleRemoveShort       = "Remove a bundle that has been deployed already"
 	CmdBundleRemoveFlagConfirm = "REQUIRED. Confirm the removal action to prevent accidental deletions"
 
 	// bundle publish
-	CmdPublishShort = "Publish a bundle from the local file system to a remote registry"
+	CmdPublishShort        = "Publish a bundle from the local file system to a remote registry"
+	CmdPublishFlagSizeOnly = "Only print the total size of the bundle that would be published, without pushing it"
 
 	// bundle pull
-	CmdBundlePullShort      = "Pull a bundle from a remote registry and save to the local file system"
-	CmdBundlePullFlagOutput = "Specify the output directory for the pulled bundle"
-	CmdBundlePullFlagKey    = "Path to a public key file that will be used to validate a signed bundle"
+	CmdBundlePullShort         = "Pull a bundle from a remote registry and save to the local file system"
+	CmdBundlePullFlagOutput    = "Specify the output directory for the pulled bundle"
+	CmdBundlePullFlagKey       = "Path to a public key file (or a cosign KMS URI) that will be used to validate a signed bundle"
+	CmdBundlePullFlagOutputDir = "Write the bundle's uds-bundle.yaml and each of its Zarf packages (as zarf-package-*.tar.zst) to this directory instead of a bundle tarball"
+	CmdBundlePullFlagForce     = "Overwrite existing files in the --output-dir"
+
+	// bundle verify
+	CmdBundleVerifyShort   = "Check that a bundle in a remote registry is complete (every layer of its packages exists and matches its checksums) without pulling it"
+	CmdBundleVerifyFlagKey = "Path to a public key file (or a cosign KMS URI) that will be used to validate a signed bundle"
+
+	// cache
+	CmdCacheShort              = "Inspect and clean up the cache of bundle layers and task file downloads (--uds-cache)"
+	CmdCacheListShort          = "List the cached bundle layers and task file downloads with their sizes and when they were last used"
+	CmdCachePruneShort         = "Remove entries from the cache, all of them unless --older-than is given"
+	CmdCachePruneFlagOlderThan = "Only remove the entries that haven't been used in this many days"
+	CmdCachePruneErrOlderThan  = "--older-than must be a positive number of days, got %d"
+	CmdCacheErr                = "Failed to access the cache: %s"
 
 	// cmd viper setup
 	CmdViperErrLoadingConfigFile = "failed to load config file: %s"
@@ -58,8 +96,14 @@ const (
 	CmdPackageChooseErr = "Bundle path selection canceled: %s"
 
 	// uds-cli version
-	CmdVersionShort = "Shows the version of the running UDS-CLI binary"
-	CmdVersionLong  = "Displays the version of the UDS-CLI release that the current binary was built from."
+	CmdVersionShort           = "Shows the version of the running UDS-CLI binary"
+	CmdVersionLong            = "Displays the version of the UDS-CLI release that the current binary was built from."
+	CmdVersionFlagOutput      = "Output format of the version (text or json), json includes the build metadata of the binary"
+	CmdVersionFlagCheckUpdate = "Check GitHub for a newer UDS-CLI release (the result is cached for 24h)"
+
+	// completion
+	CmdCompletionShort = "Generate the autocompletion script for the specified shell"
+	CmdCompletionLong  = "Generate the autocompletion script for uds for the specified shell (bash, zsh, fish or powershell), e.g. `source <(uds completion bash)`. Task names of `uds run` and local bundle tarballs are completed as well."
 
 	// uds-cli internal
 	CmdInternalShort             = "Internal cmds used by UDS-CLI"
@@ -67,6 +111,30 @@ const (
 	CmdInternalConfigSchemaErr   = "Unable to generate the uds-bundle.yaml schema"
 
 	// uds run
-	CmdRunFlag       = "Name and location of task file to run"
-	CmdRunSetVarFlag = "Set a runner variable from the command line (KEY=value)"
+	CmdRunFlag                = "Name and location of task file to run"
+	CmdRunSetVarFlag          = "Set a runner variable from the command line (KEY=value)"
+	CmdRunDeprecatedSetKey    = "Runner variables under %s in the config file are deprecated, set them under %s instead"
+	CmdRunVarsFileFlag        = "Set runner variables from a YAML file of KEY: value pairs, later files override earlier ones and --set overrides them all"
+	CmdRunEnvPrefixFlag       = "Set runner variables from the environment variables starting with this prefix, without it (e.g. UDS_FOO=bar sets FOO with --env-prefix UDS_), --vars-file and --set override them"
+	CmdRunListFlag            = "List the tasks in the task file"
+	CmdRunListAllFlag         = "List all tasks in the task file, including internal tasks"
+	CmdRunOutputFlag          = "Output format for --list (table or json)"
+	CmdRunCheckFlag           = "Validate the tasks file (task references, cycles, variables and actions) and report every problem found instead of running a task"
+	CmdRunValidateSchemaFlag  = "Validate the tasks file against the tasks JSON schema (tasks.schema.json) and report every field that doesn't match it with its line number instead of running a task"
+	CmdRunListErr             = "Unable to list tasks"
+	CmdRunDryRunFlag          = "Print the resolved commands and file operations of the task without running them"
+	CmdRunEnvFlag             = "Run the command given after -- (e.g. uds run --env build -- env) with the variables, env and working directory of this task instead of running it"
+	CmdRunStrictFlag          = "Fail commands that still reference an unset ${VAR} (uppercase) variable after templating instead of running them"
+	CmdRunDirFlag             = "Base directory of the run that relative file targets and working directories are resolved against (defaults to the directory of the tasks file)"
+	CmdRunConfirmFlag         = "Run actions marked with requireConfirmation without prompting, which is required to run them non-interactively"
+	CmdRunSelectorFlag        = "Run every task with these labels (e.g. --selector label=ci), in the order they are declared, instead of a single task"
+	CmdRunWatchFlag           = "Run the task again whenever a file in these files or directories changes (e.g. --watch src,run.yaml), until interrupted"
+	CmdRunStateFileFlag       = "Record the actions of the run that complete to this file (removed once the run succeeds), so that a failed run can be resumed with --resume"
+	CmdRunResumeFlag          = "Skip the actions that the previous (failed) run recorded as completed in the --state-file, unless they changed or are marked alwaysRun"
+	CmdRunResumeErr           = "Invalid --resume, it requires a --state-file to resume the run from"
+	CmdRunTimeoutFlag         = "Time budget of the whole run (e.g. 10m), once exceeded any running command is canceled and the run fails"
+	CmdRunLogFormatFlag       = "Format used to report the progress of the run (text or json), json writes a record of each action and a summary of the run to stderr as NDJSON"
+	CmdRunDownloadRetriesFlag = "Number of times a failed download of a task's file is retried (resuming it when the server supports range requests), unless the file sets retries"
+	CmdRunTraceFlag           = "Export an OpenTelemetry span for the run and each of its tasks and actions to the OTLP gRPC endpoint set by OTEL_EXPORTER_OTLP_ENDPOINT (localhost:4317 by default)"
+	CmdRunNoDefaultTask       = "No task name given and the task file has no default task, run one of the following tasks:"
 )
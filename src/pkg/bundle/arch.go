@@ -0,0 +1,100 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023-Present The UDS Authors
+
+// Package bundle contains functions for interacting with, managing and deploying UDS packages
+package bundle
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/defenseunicorns/zarf/src/pkg/message"
+
+	"github.com/defenseunicorns/uds-cli/src/pkg/utils"
+	"github.com/defenseunicorns/uds-cli/src/types"
+)
+
+// localPackageFilename returns the filename of a local Zarf pkg tarball built for arch
+func localPackageFilename(pkg types.BundleZarfPackage, arch string) string {
+	if pkg.Name == "init" {
+		return fmt.Sprintf("zarf-%s-%s-%s.tar.zst", pkg.Name, arch, pkg.Ref)
+	}
+	return fmt.Sprintf("zarf-package-%s-%s-%s.tar.zst", pkg.Name, arch, pkg.Ref)
+}
+
+// unavailableArchError returns the error for a Zarf pkg that isn't available for the bundle's arch, listing the
+// archs it is available for
+func unavailableArchError(pkg types.BundleZarfPackage, arch string, available []string) error {
+	source := pkg.Repository
+	if source == "" {
+		source = pkg.Path
+	}
+	if len(available) == 0 {
+		return fmt.Errorf("package %s (%s) is not available for architecture %s", pkg.Name, source, arch)
+	}
+	return fmt.Errorf("package %s (%s) is not available for architecture %s, only for: %s (set the bundle's architecture with --architecture)",
+		pkg.Name, source, arch, strings.Join(available, ", "))
+}
+
+// checkPackageArch returns an error if a Zarf pkg was built for an arch other than the bundle's, or only warns about
+// it when allowMismatch is set
+//
+// the package records the arch it was built for in its zarf.yaml, which a digest ref (or a misnamed tarball) doesn't pin
+func checkPackageArch(pkg types.BundleZarfPackage, pkgArch, bundleArch string, allowMismatch bool) error {
+	if pkgArch == "" || pkgArch == bundleArch {
+		return nil
+	}
+	if allowMismatch {
+		message.Warnf("Package %s is built for architecture %s, not the bundle's architecture %s, bundling it anyway", pkg.Name, pkgArch, bundleArch)
+		return nil
+	}
+	return fmt.Errorf("package %s is built for architecture %s, not the bundle's architecture %s (pass --allow-arch-mismatch to bundle it anyway)",
+		pkg.Name, pkgArch, bundleArch)
+}
+
+// remotePackageArchs returns the archs a remote Zarf pkg's ref is published for, from its <ref>-<arch> tags
+func remotePackageArchs(pkg types.BundleZarfPackage) ([]string, error) {
+	remote, err := utils.NewOrasRemote(packageURL(pkg))
+	if err != nil {
+		return nil, err
+	}
+	var tags []string
+	err = remote.Repo().Tags(context.TODO(), "", func(page []string) error {
+		tags = append(tags, page...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return archsFromTags(tags, pkg.Ref), nil
+}
+
+// archsFromTags returns the archs of the <ref>-<arch> tags of a Zarf pkg
+func archsFromTags(tags []string, ref string) []string {
+	var archs []string
+	for _, tag := range tags {
+		if arch, ok := strings.CutPrefix(tag, ref+"-"); ok && arch != "" && !strings.Contains(arch, "-") {
+			archs = append(archs, arch)
+		}
+	}
+	slices.Sort(archs)
+	return archs
+}
+
+// localPackageArchs returns the archs of the local Zarf pkg tarballs of a pkg's ref in its path
+func localPackageArchs(pkg types.BundleZarfPackage) []string {
+	prefix, suffix, _ := strings.Cut(localPackageFilename(pkg, "*"), "*")
+	matches, _ := filepath.Glob(filepath.Join(pkg.Path, localPackageFilename(pkg, "*")))
+	var archs []string
+	for _, match := range matches {
+		arch := strings.TrimSuffix(strings.TrimPrefix(filepath.Base(match), prefix), suffix)
+		if arch != "" && !strings.Contains(arch, "-") {
+			archs = append(archs, arch)
+		}
+	}
+	slices.Sort(archs)
+	return archs
+}
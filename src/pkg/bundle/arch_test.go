@@ -0,0 +1,47 @@
+package bundle
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/defenseunicorns/uds-cli/src/types"
+)
+
+func Test_archsFromTags(t *testing.T) {
+	tags := []string{"0.0.1", "0.0.1-arm64", "0.0.1-amd64", "0.0.1-rc1-amd64", "0.0.2-amd64", "latest"}
+	require.Equal(t, []string{"amd64", "arm64"}, archsFromTags(tags, "0.0.1"))
+	require.Empty(t, archsFromTags(tags, "0.0.3"))
+}
+
+func Test_localPackageArchs(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{
+		"zarf-package-podinfo-arm64-0.0.1.tar.zst",
+		"zarf-package-podinfo-amd64-0.0.1.tar.zst",
+		"zarf-package-podinfo-amd64-0.0.2.tar.zst",
+		"zarf-init-amd64-v0.31.1.tar.zst",
+	} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), nil, 0600))
+	}
+
+	podinfo := types.BundleZarfPackage{Name: "podinfo", Path: dir, Ref: "0.0.1"}
+	require.Equal(t, []string{"amd64", "arm64"}, localPackageArchs(podinfo))
+	initPkg := types.BundleZarfPackage{Name: "init", Path: dir, Ref: "v0.31.1"}
+	require.Equal(t, []string{"amd64"}, localPackageArchs(initPkg))
+
+	err := unavailableArchError(initPkg, "arm64", localPackageArchs(initPkg))
+	require.EqualError(t, err, "package init ("+dir+") is not available for architecture arm64, only for: amd64 (set the bundle's architecture with --architecture)")
+}
+
+func Test_checkPackageArch(t *testing.T) {
+	podinfo := types.BundleZarfPackage{Name: "podinfo", Repository: "ghcr.io/example/podinfo", Ref: "0.0.1"}
+	require.NoError(t, checkPackageArch(podinfo, "amd64", "amd64", false))
+	// packages that don't record their arch can't be checked
+	require.NoError(t, checkPackageArch(podinfo, "", "amd64", false))
+	require.EqualError(t, checkPackageArch(podinfo, "arm64", "amd64", false),
+		"package podinfo is built for architecture arm64, not the bundle's architecture amd64 (pass --allow-arch-mismatch to bundle it anyway)")
+	require.NoError(t, checkPackageArch(podinfo, "arm64", "amd64", true))
+}
@@ -12,12 +12,14 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/defenseunicorns/zarf/src/pkg/interactive"
 	"github.com/defenseunicorns/zarf/src/pkg/message"
 	"github.com/defenseunicorns/zarf/src/pkg/oci"
 	zarfUtils "github.com/defenseunicorns/zarf/src/pkg/utils"
 	goyaml "github.com/goccy/go-yaml"
 	"github.com/mholt/archiver/v4"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"golang.org/x/exp/maps"
 	"golang.org/x/sync/errgroup"
 	"oras.land/oras-go/v2/content"
 	ocistore "oras.land/oras-go/v2/content/oci"
@@ -28,9 +30,40 @@ import (
 	"github.com/defenseunicorns/uds-cli/src/types"
 )
 
-// Create creates the bundle and outputs to a local tarball
-func Create(b *Bundler, signature []byte) error {
-	message.HeaderInfof("🐕 Fetching Packages")
+// CreateOptions are the options of Create and CreateAndPublish
+type CreateOptions struct {
+	// SigningKeyPath is the cosign key (a path or KMS URI) to sign the uds-bundle.yaml with, if any
+	SigningKeyPath     string
+	SigningKeyPassword string
+	// Quiet disables every spinner, progress bar and hint printed while creating the bundle
+	Quiet bool
+	// Progress, when set, receives the progress of each step of creating the bundle instead of a spinner
+	Progress bundler.Progress
+}
+
+// silent returns true if creating the bundle shouldn't print anything
+func (opts CreateOptions) silent() bool {
+	return opts.Quiet || opts.Progress != nil
+}
+
+// step starts reporting a step of creating the bundle, to the caller's Progress if there is one
+func (opts CreateOptions) step(format string, a ...any) bundler.Progress {
+	switch {
+	case opts.Progress != nil:
+		opts.Progress.Updatef(format, a...)
+		return opts.Progress
+	case opts.Quiet:
+		return bundler.NoProgress{}
+	default:
+		return message.NewProgressSpinner(format, a...)
+	}
+}
+
+// Create creates the bundle and outputs to a local tarball, signing its uds-bundle.yaml when a signing key is given
+func Create(b *Bundler, opts CreateOptions) error {
+	if !opts.silent() {
+		message.HeaderInfof("🐕 Fetching Packages")
+	}
 
 	if b.bundle.Metadata.Architecture == "" {
 		return fmt.Errorf("architecture is required for bundling")
@@ -50,23 +83,36 @@ func Create(b *Bundler, signature []byte) error {
 		MediaType: ocispec.MediaTypeImageManifest,
 	}
 
+	// collect each Zarf pkg's SBOMs to aggregate into a bundle-level SBOM
+	var pkgSBOMs []utils.PackageSBOMs
+
+	// layers shared by several Zarf pkgs are only put in the OCI store once
+	layers := bundler.NewLayerTracker()
+
 	// grab all Zarf pkgs from OCI and put blobs in OCI store
 	for i, pkg := range bundle.ZarfPackages {
-		fetchSpinner := message.NewProgressSpinner("Fetching package %s", pkg.Name)
+		fetchSpinner := opts.step("Fetching package %s", pkg.Name)
 
 		defer fetchSpinner.Stop()
 
 		if pkg.Repository != "" {
-			url := fmt.Sprintf("%s:%s", pkg.Repository, pkg.Ref)
+			url := packageURL(pkg)
 			remoteBundler, err := bundler.NewRemoteBundler(pkg, url, store, nil, b.tmp)
 			if err != nil {
 				return err
 			}
+			remoteBundler.Layers = layers
+			remoteBundler.Quiet = opts.silent()
 
 			layerDescs, err := remoteBundler.LayersToBundle(fetchSpinner, i+1, len(bundle.ZarfPackages))
 			if err != nil {
 				return err
 			}
+			pkgLayers, err := remoteBundler.LayersToPush()
+			if err != nil {
+				return err
+			}
+			recordPackageLayers(bundle, pkg.Name, pkgLayers[1:])
 
 			// grab layers for archiving
 			for _, layerDesc := range layerDescs {
@@ -87,6 +133,12 @@ func Create(b *Bundler, signature []byte) error {
 				digest := layerDesc.Digest.Encoded()
 				artifactPathMap[filepath.Join(b.tmp, config.BlobsDir, digest)] = filepath.Join(config.BlobsDir, digest)
 			}
+
+			sbomTar, err := remoteBundler.SBOMs()
+			if err != nil {
+				return err
+			}
+			pkgSBOMs = appendPackageSBOMs(pkgSBOMs, pkg.Name, sbomTar)
 		} else if pkg.Path != "" {
 			pkgTmp, err := zarfUtils.MakeTempDir("")
 			defer os.RemoveAll(pkgTmp)
@@ -95,9 +147,7 @@ func Create(b *Bundler, signature []byte) error {
 			}
 
 			localBundler := bundler.NewLocalBundler(pkg.Path, pkgTmp)
-			if err != nil {
-				return err
-			}
+			localBundler.Layers = layers
 
 			err = localBundler.Extract()
 			if err != nil {
@@ -114,6 +164,21 @@ func Create(b *Bundler, signature []byte) error {
 			if err != nil {
 				return err
 			}
+			zarfPkgManifestBytes, err := content.FetchAll(ctx, store, zarfPkgDesc)
+			if err != nil {
+				return err
+			}
+			var zarfPkgManifest ocispec.Manifest
+			if err := json.Unmarshal(zarfPkgManifestBytes, &zarfPkgManifest); err != nil {
+				return err
+			}
+			recordPackageLayers(bundle, pkg.Name, append([]ocispec.Descriptor{zarfPkgManifest.Config}, zarfPkgManifest.Layers...))
+
+			sbomTar, err := localBundler.SBOMs()
+			if err != nil {
+				return err
+			}
+			pkgSBOMs = appendPackageSBOMs(pkgSBOMs, pkg.Name, sbomTar)
 
 			// put digest in uds-bundle.yaml to reference during deploy
 			bundle.ZarfPackages[i].Ref = bundle.ZarfPackages[i].Ref + "-" + bundle.Metadata.Architecture + "@sha256:" + zarfPkgDesc.Digest.Encoded()
@@ -129,11 +194,37 @@ func Create(b *Bundler, signature []byte) error {
 
 		fetchSpinner.Successf("Fetched package: %s", pkg.Name)
 	}
+	message.Debugf("Deduplicated %d layer(s) shared by more than one package", layers.Deduplicated())
 
-	message.HeaderInfof("🚧 Building Bundle")
+	if !opts.silent() {
+		message.HeaderInfof("🚧 Building Bundle")
+	}
+
+	// merge the Zarf pkgs' SBOMs and push the bundle-level SBOM to OCI store
+	if len(pkgSBOMs) > 0 {
+		sbomTar, err := utils.MergeSBOMs(pkgSBOMs)
+		if err != nil {
+			return err
+		}
+		sbomDesc := content.NewDescriptorFromBytes(oci.ZarfLayerMediaTypeBlob, sbomTar)
+		if err := store.Push(ctx, sbomDesc, bytes.NewReader(sbomTar)); err != nil {
+			return err
+		}
+		sbomDesc.Annotations = map[string]string{
+			ocispec.AnnotationTitle: config.BundleSBOMTar,
+		}
+		rootManifest.Layers = append(rootManifest.Layers, sbomDesc)
+		digest := sbomDesc.Digest.Encoded()
+		artifactPathMap[filepath.Join(b.tmp, config.BlobsDir, digest)] = filepath.Join(config.BlobsDir, digest)
+		message.Debug("Pushed", config.BundleSBOMTar+":", message.JSONValue(sbomDesc))
+	}
 
 	// push uds-bundle.yaml to OCI store
-	bundleYAMLDesc, err := pushBundleYAMLToStore(ctx, store, bundle)
+	bundleYAMLBytes, err := goyaml.Marshal(bundle)
+	if err != nil {
+		return err
+	}
+	bundleYAMLDesc, err := pushBundleYAMLToStore(ctx, store, bundleYAMLBytes)
 	if err != nil {
 		return err
 	}
@@ -143,6 +234,22 @@ func Create(b *Bundler, signature []byte) error {
 	digest := bundleYAMLDesc.Digest.Encoded()
 	artifactPathMap[filepath.Join(b.tmp, config.BlobsDir, digest)] = filepath.Join(config.BlobsDir, digest)
 
+	// sign uds-bundle.yaml and push the bundle's signature
+	if opts.SigningKeyPath != "" {
+		signature, err := signBundleYAML(bundleYAMLBytes, opts.SigningKeyPath, opts.SigningKeyPassword)
+		if err != nil {
+			return err
+		}
+		signatureDesc, err := pushBundleSignature(ctx, store, signature)
+		if err != nil {
+			return err
+		}
+		rootManifest.Layers = append(rootManifest.Layers, signatureDesc)
+		digest = signatureDesc.Digest.Encoded()
+		artifactPathMap[filepath.Join(b.tmp, config.BlobsDir, digest)] = filepath.Join(config.BlobsDir, digest)
+		message.Debug("Pushed", config.BundleYAMLSignature+":", message.JSONValue(signatureDesc))
+	}
+
 	// create and push bundle manifest config
 	manifestConfigDesc, err := pushManifestConfig(store, bundle.Metadata, bundle.Build)
 	if err != nil {
@@ -152,9 +259,7 @@ func Create(b *Bundler, signature []byte) error {
 	artifactPathMap[filepath.Join(b.tmp, config.BlobsDir, manifestConfigDigest)] = filepath.Join(config.BlobsDir, manifestConfigDigest)
 
 	rootManifest.Config = manifestConfigDesc
-	rootManifest.SchemaVersion = 2
-	rootManifest.Annotations = manifestAnnotationsFromMetadata(&bundle.Metadata) // maps to registry UI
-	rootManifestDesc, err := utils.ToOCIStore(rootManifest, ocispec.MediaTypeImageManifest, store)
+	rootManifestDesc, err := pushRootManifest(store, rootManifest, &bundle.Metadata)
 	if err != nil {
 		return err
 	}
@@ -167,16 +272,6 @@ func Create(b *Bundler, signature []byte) error {
 	// grab oci-layout
 	artifactPathMap[filepath.Join(b.tmp, "oci-layout")] = "oci-layout"
 
-	// push the bundle's signature todo: need to understand functionality and add tests
-	if len(signature) > 0 {
-		signatureDesc, err := pushBundleSignature(ctx, store, signature)
-		if err != nil {
-			return err
-		}
-		rootManifest.Layers = append(rootManifest.Layers, signatureDesc)
-		message.Debug("Pushed", config.BundleYAMLSignature+":", message.JSONValue(signatureDesc))
-	}
-
 	// tag the local bundle artifact
 	ref := fmt.Sprintf("%s-%s", bundle.Metadata.Version, bundle.Metadata.Architecture)
 	err = store.Tag(ctx, rootManifestDesc, ref)
@@ -189,7 +284,7 @@ func Create(b *Bundler, signature []byte) error {
 	}
 
 	// tarball the bundle
-	err = writeTarball(bundle, artifactPathMap)
+	err = writeTarball(bundle, artifactPathMap, opts)
 	if err != nil {
 		return err
 	}
@@ -198,7 +293,7 @@ func Create(b *Bundler, signature []byte) error {
 }
 
 // CreateAndPublish creates the bundle in an OCI registry publishes w/ optional signature to the remote repository.
-func CreateAndPublish(remoteDst *oci.OrasRemote, bundle *types.UDSBundle, signature []byte) error {
+func CreateAndPublish(remoteDst *oci.OrasRemote, bundle *types.UDSBundle, opts CreateOptions) error {
 	if bundle.Metadata.Architecture == "" {
 		return fmt.Errorf("architecture is required for bundling")
 	}
@@ -207,12 +302,126 @@ func CreateAndPublish(remoteDst *oci.OrasRemote, bundle *types.UDSBundle, signat
 
 	rootManifest := ocispec.Manifest{}
 
+	// gather the layers of every package (and the bundle's metadata) to report the bundle's size before pushing anything
+	var layersToPush []ocispec.Descriptor
+	var pkgSBOMs []utils.PackageSBOMs
+	remoteBundlers := make(map[int]*bundler.RemoteBundler)
+	localBundlers := make(map[int]*bundler.LocalBundler)
+	// layers shared by several Zarf pkgs are only pushed once
+	layers := bundler.NewLayerTracker()
 	for i, pkg := range bundle.ZarfPackages {
-		url := fmt.Sprintf("%s:%s", pkg.Repository, pkg.Ref)
+		// local Zarf pkgs are pushed from their tarball into the bundle as if they came from a registry
+		if pkg.Path != "" {
+			pkgTmp, err := zarfUtils.MakeTempDir("")
+			if err != nil {
+				return err
+			}
+			defer os.RemoveAll(pkgTmp)
+
+			localBundler := bundler.NewLocalBundler(pkg.Path, pkgTmp)
+			localBundler.Layers = layers
+			if err := localBundler.Extract(); err != nil {
+				return err
+			}
+			zarfPkg, err := localBundler.Load()
+			if err != nil {
+				return err
+			}
+			pkgLayers, err := localBundler.LayersToPush(zarfPkg)
+			if err != nil {
+				return err
+			}
+			layersToPush = append(layersToPush, pkgLayers...)
+			recordPackageLayers(bundle, pkg.Name, pkgLayers[1:])
+
+			sbomTar, err := localBundler.SBOMs()
+			if err != nil {
+				return err
+			}
+			pkgSBOMs = appendPackageSBOMs(pkgSBOMs, pkg.Name, sbomTar)
+
+			// put digest of the pkg's manifest in uds-bundle.yaml to reference during deploy
+			bundle.ZarfPackages[i].Ref = pkg.Ref + "-" + bundle.Metadata.Architecture + "@sha256:" + pkgLayers[0].Digest.Encoded()
+			localBundlers[i] = &localBundler
+			continue
+		}
+
+		url := packageURL(pkg)
 		remoteBundler, err := bundler.NewRemoteBundler(pkg, url, nil, remoteDst, "")
 		if err != nil {
 			return err
 		}
+		remoteBundler.Layers = layers
+		pkgLayers, err := remoteBundler.LayersToPush()
+		if err != nil {
+			return err
+		}
+		layersToPush = append(layersToPush, pkgLayers...)
+		recordPackageLayers(bundle, pkg.Name, pkgLayers[1:])
+
+		sbomTar, err := remoteBundler.SBOMs()
+		if err != nil {
+			return err
+		}
+		pkgSBOMs = appendPackageSBOMs(pkgSBOMs, pkg.Name, sbomTar)
+		remoteBundlers[i] = &remoteBundler
+	}
+
+	var bundleSBOM []byte
+	if len(pkgSBOMs) > 0 {
+		var err error
+		if bundleSBOM, err = utils.MergeSBOMs(pkgSBOMs); err != nil {
+			return err
+		}
+	}
+	bundleYamlBytes, err := goyaml.Marshal(bundle)
+	if err != nil {
+		return err
+	}
+	var signature []byte
+	if opts.SigningKeyPath != "" {
+		if signature, err = signBundleYAML(bundleYamlBytes, opts.SigningKeyPath, opts.SigningKeyPassword); err != nil {
+			return err
+		}
+	}
+	configBytes, err := json.Marshal(manifestConfigFromMetadata(&bundle.Metadata, &bundle.Build))
+	if err != nil {
+		return err
+	}
+	for _, metadata := range [][]byte{bundleSBOM, bundleYamlBytes, signature, configBytes} {
+		if len(metadata) > 0 {
+			layersToPush = append(layersToPush, content.NewDescriptorFromBytes(oci.ZarfLayerMediaTypeBlob, metadata))
+		}
+	}
+	size := zarfUtils.ByteFormat(float64(bundleSize(layersToPush)), 2)
+	if opts.silent() {
+		message.Debugf("Bundle size: %s", size)
+	} else {
+		message.Infof("Bundle size: %s", size)
+	}
+
+	for i, pkg := range bundle.ZarfPackages {
+		if localBundler, ok := localBundlers[i]; ok {
+			pushSpinner := opts.step("Pushing package %s layers to registry (package %d of %d)", pkg.Name, i+1, len(bundle.ZarfPackages))
+
+			defer pushSpinner.Stop()
+
+			zarfManifestDesc, err := localBundler.ToRemoteBundle(remoteDst, pushSpinner)
+			if err != nil {
+				return err
+			}
+
+			// hack the media type to be a manifest and append to bundle root manifest
+			zarfManifestDesc.MediaType = ocispec.MediaTypeImageManifest
+			message.Debugf("Pushed %s sub-manifest into %s: %s", pkg.Path, dstRef, message.JSONValue(zarfManifestDesc))
+			rootManifest.Layers = append(rootManifest.Layers, zarfManifestDesc)
+
+			pushSpinner.Successf("Pushed package: %s", pkg.Name)
+			continue
+		}
+
+		url := packageURL(pkg)
+		remoteBundler := remoteBundlers[i]
 
 		zarfManifestDesc, err := remoteBundler.PushManifest()
 		if err != nil {
@@ -224,7 +433,8 @@ func CreateAndPublish(remoteDst *oci.OrasRemote, bundle *types.UDSBundle, signat
 		message.Debugf("Pushed %s sub-manifest into %s: %s", url, dstRef, message.JSONValue(zarfManifestDesc))
 		rootManifest.Layers = append(rootManifest.Layers, zarfManifestDesc)
 
-		pushSpinner := message.NewProgressSpinner("")
+		remoteBundler.Quiet = opts.silent()
+		pushSpinner := opts.step("Pushing package %s layers to registry (package %d of %d)", pkg.Name, i+1, len(bundle.ZarfPackages))
 
 		defer pushSpinner.Stop()
 
@@ -235,32 +445,33 @@ func CreateAndPublish(remoteDst *oci.OrasRemote, bundle *types.UDSBundle, signat
 
 		pushSpinner.Successf("Pushed package: %s", pkg.Name)
 	}
+	message.Debugf("Deduplicated %d layer(s) shared by more than one package", layers.Deduplicated())
 
-	// push the bundle's metadata
-	bundleYamlBytes, err := goyaml.Marshal(bundle)
-	if err != nil {
-		return err
+	// push the bundle-level SBOM
+	if len(bundleSBOM) > 0 {
+		sbomDesc, err := pushBundleLayer(remoteDst, bundleSBOM, config.BundleSBOMTar)
+		if err != nil {
+			return err
+		}
+		rootManifest.Layers = append(rootManifest.Layers, sbomDesc)
+		message.Debug("Pushed", config.BundleSBOMTar+":", message.JSONValue(sbomDesc))
 	}
-	bundleYamlDesc, err := remoteDst.PushLayer(bundleYamlBytes, oci.ZarfLayerMediaTypeBlob)
+
+	// push the bundle's metadata
+	bundleYamlDesc, err := pushBundleLayer(remoteDst, bundleYamlBytes, config.BundleYAML)
 	if err != nil {
 		return err
 	}
-	bundleYamlDesc.Annotations = map[string]string{
-		ocispec.AnnotationTitle: config.BundleYAML,
-	}
 
 	message.Debug("Pushed", config.BundleYAML+":", message.JSONValue(bundleYamlDesc))
 	rootManifest.Layers = append(rootManifest.Layers, bundleYamlDesc)
 
 	// push the bundle's signature
 	if len(signature) > 0 {
-		bundleYamlSigDesc, err := remoteDst.PushLayer(signature, oci.ZarfLayerMediaTypeBlob)
+		bundleYamlSigDesc, err := pushBundleLayer(remoteDst, signature, config.BundleYAMLSignature)
 		if err != nil {
 			return err
 		}
-		bundleYamlSigDesc.Annotations = map[string]string{
-			ocispec.AnnotationTitle: config.BundleYAMLSignature,
-		}
 		rootManifest.Layers = append(rootManifest.Layers, bundleYamlSigDesc)
 		message.Debug("Pushed", config.BundleYAMLSignature+":", message.JSONValue(bundleYamlSigDesc))
 	}
@@ -277,11 +488,19 @@ func CreateAndPublish(remoteDst *oci.OrasRemote, bundle *types.UDSBundle, signat
 	rootManifest.SchemaVersion = 2
 	rootManifest.Annotations = manifestAnnotationsFromMetadata(&bundle.Metadata) // maps to registry UI
 
-	_, err = utils.ToOCIRemote(rootManifest, ocispec.MediaTypeImageManifest, remoteDst)
+	rootManifestDesc, err := utils.ToOCIRemote(rootManifest, ocispec.MediaTypeImageManifest, remoteDst)
 	if err != nil {
 		return err
 	}
 
+	// add the bundle to the multi-arch index tagged with the bundle's version
+	if _, err := utils.PushToIndex(remoteDst, bundle.Metadata.Version, rootManifestDesc, bundle.Metadata.Architecture); err != nil {
+		return err
+	}
+
+	if opts.silent() {
+		return nil
+	}
 	message.HorizontalRule()
 	flags := ""
 	if config.CommonOptions.Insecure {
@@ -295,29 +514,69 @@ func CreateAndPublish(remoteDst *oci.OrasRemote, bundle *types.UDSBundle, signat
 	return nil
 }
 
+// pushBundleLayer pushes a bundle-level layer (retrying transient failures) and titles its descriptor
+func pushBundleLayer(remoteDst *oci.OrasRemote, b []byte, title string) (ocispec.Descriptor, error) {
+	var desc ocispec.Descriptor
+	err := utils.RetryOCI(fmt.Sprintf("pushing %s", title), func() error {
+		var err error
+		desc, err = remoteDst.PushLayer(b, oci.ZarfLayerMediaTypeBlob)
+		return err
+	})
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	desc.Annotations = map[string]string{
+		ocispec.AnnotationTitle: title,
+	}
+	return desc, nil
+}
+
 // copied from: https://github.com/defenseunicorns/zarf/blob/main/src/pkg/oci/push.go
 func pushManifestConfigFromMetadata(r *oci.OrasRemote, metadata *types.UDSMetadata, build *types.UDSBuildData) (ocispec.Descriptor, error) {
+	manifestConfig := manifestConfigFromMetadata(metadata, build)
+	manifestConfigDesc, err := utils.ToOCIRemote(manifestConfig, oci.ZarfLayerMediaTypeBlob, r)
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	return manifestConfigDesc, nil
+}
+
+// manifestConfigFromMetadata creates the bundle manifest config of a bundle pushed to a remote
+func manifestConfigFromMetadata(metadata *types.UDSMetadata, build *types.UDSBuildData) oci.ConfigPartial {
 	annotations := map[string]string{
 		ocispec.AnnotationTitle:       metadata.Name,
 		ocispec.AnnotationDescription: metadata.Description,
 	}
-	manifestConfig := oci.ConfigPartial{
+	return oci.ConfigPartial{
 		Architecture: build.Architecture,
 		OCIVersion:   "1.0.1",
 		Annotations:  annotations,
 	}
-	manifestConfigDesc, err := utils.ToOCIRemote(manifestConfig, oci.ZarfLayerMediaTypeBlob, r)
-	if err != nil {
-		return ocispec.Descriptor{}, err
+}
+
+// recordPackageLayers records the digests of a Zarf pkg's layers in the bundle's build data, so pulls don't have to
+// probe the registry for which layers of the pkg are in the bundle
+func recordPackageLayers(bundle *types.UDSBundle, pkgName string, layers []ocispec.Descriptor) {
+	if bundle.Build.PackageLayers == nil {
+		bundle.Build.PackageLayers = make(map[string][]string)
 	}
-	return manifestConfigDesc, nil
+	digests := []string{}
+	for _, layer := range layers {
+		if layer.Digest != "" {
+			digests = append(digests, layer.Digest.String())
+		}
+	}
+	bundle.Build.PackageLayers[pkgName] = digests
 }
 
 // copied from: https://github.com/defenseunicorns/zarf/blob/main/src/pkg/oci/push.go
 func manifestAnnotationsFromMetadata(metadata *types.UDSMetadata) map[string]string {
-	annotations := map[string]string{
-		ocispec.AnnotationDescription: metadata.Description,
+	// extra annotations go first so the ones set from the metadata fields always win
+	annotations := maps.Clone(metadata.Annotations)
+	if annotations == nil {
+		annotations = map[string]string{}
 	}
+	annotations[ocispec.AnnotationDescription] = metadata.Description
 
 	if url := metadata.URL; url != "" {
 		annotations[ocispec.AnnotationURL] = url
@@ -338,17 +597,44 @@ func manifestAnnotationsFromMetadata(metadata *types.UDSMetadata) map[string]str
 	return annotations
 }
 
-// pushBundleYAMLToStore pushes the uds-bundle.yaml to a provided OCI store
-func pushBundleYAMLToStore(ctx context.Context, store *ocistore.Store, bundle *types.UDSBundle) (ocispec.Descriptor, error) {
-	bundleYAMLBytes, err := goyaml.Marshal(bundle)
-	if err != nil {
-		return ocispec.Descriptor{}, err
+// metadataAnnotations are the manifest annotations set from metadata fields, which metadata.annotations can't set
+var metadataAnnotations = map[string]string{
+	ocispec.AnnotationDescription:   "description",
+	ocispec.AnnotationURL:           "url",
+	ocispec.AnnotationAuthors:       "authors",
+	ocispec.AnnotationDocumentation: "documentation",
+	ocispec.AnnotationSource:        "source",
+	ocispec.AnnotationVendor:        "vendor",
+}
+
+// validateAnnotations errors if the extra annotations of a bundle's metadata set an annotation that is set from
+// one of its metadata fields
+func validateAnnotations(annotations map[string]string) error {
+	for key := range annotations {
+		if key == "" {
+			return fmt.Errorf("%s has an annotation without a key in metadata.annotations", config.BundleYAML)
+		}
+		if field, ok := metadataAnnotations[key]; ok {
+			return fmt.Errorf("annotation %s in metadata.annotations is set from metadata.%s, set that instead", key, field)
+		}
 	}
+	return nil
+}
+
+// pushRootManifest pushes the root manifest of a bundle to store, annotated with its metadata for registry UIs
+func pushRootManifest(store *ocistore.Store, rootManifest ocispec.Manifest, metadata *types.UDSMetadata) (ocispec.Descriptor, error) {
+	rootManifest.SchemaVersion = 2
+	rootManifest.Annotations = manifestAnnotationsFromMetadata(metadata) // maps to registry UI
+	return utils.ToOCIStore(rootManifest, ocispec.MediaTypeImageManifest, store)
+}
+
+// pushBundleYAMLToStore pushes the uds-bundle.yaml to a provided OCI store
+func pushBundleYAMLToStore(ctx context.Context, store *ocistore.Store, bundleYAMLBytes []byte) (ocispec.Descriptor, error) {
 	bundleYamlDesc := content.NewDescriptorFromBytes(oci.ZarfLayerMediaTypeBlob, bundleYAMLBytes)
 	bundleYamlDesc.Annotations = map[string]string{
 		ocispec.AnnotationTitle: config.BundleYAML,
 	}
-	err = store.Push(ctx, bundleYamlDesc, bytes.NewReader(bundleYAMLBytes))
+	err := store.Push(ctx, bundleYamlDesc, bytes.NewReader(bundleYAMLBytes))
 	if err != nil {
 		return ocispec.Descriptor{}, err
 	}
@@ -376,7 +662,7 @@ func pushManifestConfig(store *ocistore.Store, metadata types.UDSMetadata, build
 }
 
 // writeTarball builds and writes a bundle tarball to disk based on a file map
-func writeTarball(bundle *types.UDSBundle, artifactPathMap PathMap) error {
+func writeTarball(bundle *types.UDSBundle, artifactPathMap PathMap, opts CreateOptions) error {
 	format := archiver.CompressedArchive{
 		Compression: archiver.Zstd{},
 		Archival:    archiver.Tar{},
@@ -400,6 +686,17 @@ func writeTarball(bundle *types.UDSBundle, artifactPathMap PathMap) error {
 		return err
 	}
 
+	// the CLI shows a progress bar of the archived files, other callers only get the step
+	var archiveBar *message.ProgressBar
+	var progress bundler.Progress
+	if opts.silent() {
+		progress = opts.step("Creating bundle archive")
+		defer progress.Stop()
+	} else {
+		archiveBar = message.NewProgressBar(int64(len(files)), "Creating bundle archive")
+		defer archiveBar.Stop()
+	}
+
 	archiveErrorChan := make(chan error, len(files))
 	jobs := make(chan archiver.ArchiveAsyncJob, len(files))
 
@@ -415,10 +712,6 @@ func writeTarball(bundle *types.UDSBundle, artifactPathMap PathMap) error {
 
 	archiveErrGroup, ctx := errgroup.WithContext(context.TODO())
 
-	archiveBar := message.NewProgressBar(int64(len(jobs)), "Creating bundle archive")
-
-	defer archiveBar.Stop()
-
 	archiveErrGroup.Go(func() error {
 		return format.ArchiveAsync(ctx, out, jobs)
 	})
@@ -429,7 +722,7 @@ jobLoop:
 		case err := <-archiveErrorChan:
 			if err != nil {
 				return err
-			} else {
+			} else if archiveBar != nil {
 				archiveBar.Add(1)
 			}
 		case <-ctx.Done():
@@ -441,10 +734,52 @@ jobLoop:
 		return err
 	}
 
-	archiveBar.Successf("Created bundle archive at: %s", dst)
+	if archiveBar != nil {
+		archiveBar.Successf("Created bundle archive at: %s", dst)
+	} else {
+		progress.Successf("Created bundle archive at: %s", dst)
+	}
 	return nil
 }
 
+// signBundleYAML signs the marshaled uds-bundle.yaml with a cosign key (a path or KMS URI),
+// prompting for the key's password when none is given
+func signBundleYAML(bundleYAMLBytes []byte, signingKeyPath, signingKeyPassword string) ([]byte, error) {
+	tmp, err := zarfUtils.MakeTempDir("")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmp)
+
+	// write uds-bundle.yaml to disk so cosign can sign it
+	bundleYAMLPath := filepath.Join(tmp, config.BundleYAML)
+	if err := os.WriteFile(bundleYAMLPath, bundleYAMLBytes, 0600); err != nil {
+		return nil, err
+	}
+
+	getSigCreatePassword := func(_ bool) ([]byte, error) {
+		if signingKeyPassword != "" {
+			return []byte(signingKeyPassword), nil
+		}
+		return interactive.PromptSigPassword()
+	}
+	signaturePath := filepath.Join(tmp, config.BundleYAMLSignature)
+	signature, err := zarfUtils.CosignSignBlob(bundleYAMLPath, signaturePath, signingKeyPath, getSigCreatePassword)
+	if err != nil {
+		return nil, fmt.Errorf("unable to sign %s with %s: %w", config.BundleYAML, signingKeyPath, err)
+	}
+	return signature, nil
+}
+
+// appendPackageSBOMs adds a Zarf pkg's sboms.tar to the SBOMs to aggregate, skipping packages that have no SBOMs
+func appendPackageSBOMs(pkgSBOMs []utils.PackageSBOMs, pkgName string, sbomTar []byte) []utils.PackageSBOMs {
+	if sbomTar == nil {
+		message.Debugf("Package %s has no %s, skipping it in the bundle's SBOM", pkgName, config.SBOMsTar)
+		return pkgSBOMs
+	}
+	return append(pkgSBOMs, utils.PackageSBOMs{PkgName: pkgName, Tarball: sbomTar})
+}
+
 func pushBundleSignature(ctx context.Context, store *ocistore.Store, signature []byte) (ocispec.Descriptor, error) {
 	signatureDesc := content.NewDescriptorFromBytes(oci.ZarfLayerMediaTypeBlob, signature)
 	err := store.Push(ctx, signatureDesc, bytes.NewReader(signature))
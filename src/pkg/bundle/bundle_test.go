@@ -0,0 +1,94 @@
+package bundle
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/require"
+	"oras.land/oras-go/v2/content"
+	ocistore "oras.land/oras-go/v2/content/oci"
+
+	"github.com/defenseunicorns/uds-cli/src/types"
+)
+
+// recordingProgress records the updates and successes reported to it
+type recordingProgress struct {
+	updates   []string
+	successes []string
+	stops     int
+}
+
+func (p *recordingProgress) Updatef(format string, a ...any) {
+	p.updates = append(p.updates, fmt.Sprintf(format, a...))
+}
+
+func (p *recordingProgress) Successf(format string, a ...any) {
+	p.successes = append(p.successes, fmt.Sprintf(format, a...))
+}
+
+func (p *recordingProgress) Stop() {
+	p.stops++
+}
+
+func Test_writeTarballProgress(t *testing.T) {
+	tmp := t.TempDir()
+	cwd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(tmp))
+	defer func() { require.NoError(t, os.Chdir(cwd)) }()
+
+	layoutPath := filepath.Join(tmp, "oci-layout")
+	require.NoError(t, os.WriteFile(layoutPath, []byte(`{"imageLayoutVersion":"1.0.0"}`), 0600))
+	bundle := &types.UDSBundle{Metadata: types.UDSMetadata{Name: "progress", Architecture: "amd64", Version: "0.0.1"}}
+	dst := filepath.Join(tmp, "uds-bundle-progress-amd64-0.0.1.tar.zst")
+
+	progress := &recordingProgress{}
+	require.NoError(t, writeTarball(bundle, PathMap{layoutPath: "oci-layout"}, CreateOptions{Progress: progress}))
+	require.FileExists(t, dst)
+	require.Equal(t, []string{"Creating bundle archive"}, progress.updates)
+	require.Equal(t, []string{"Created bundle archive at: " + dst}, progress.successes)
+	require.Equal(t, 1, progress.stops)
+
+	// quiet creates the same archive without reporting anything
+	require.NoError(t, writeTarball(bundle, PathMap{layoutPath: "oci-layout"}, CreateOptions{Quiet: true}))
+	require.FileExists(t, dst)
+}
+
+func Test_pushRootManifestAnnotations(t *testing.T) {
+	metadata := types.UDSMetadata{
+		Name:        "example",
+		Description: "an example bundle",
+		URL:         "https://example.com",
+		Annotations: map[string]string{
+			"org.opencontainers.image.licenses": "Apache-2.0",
+			"com.example.support":               "https://example.com/support",
+			// ignored as the bundle's description is set from metadata.description
+			ocispec.AnnotationDescription: "overridden",
+		},
+	}
+
+	store, err := ocistore.New(t.TempDir())
+	require.NoError(t, err)
+	desc, err := pushRootManifest(store, ocispec.Manifest{}, &metadata)
+	require.NoError(t, err)
+
+	manifestBytes, err := content.FetchAll(context.Background(), store, desc)
+	require.NoError(t, err)
+	var manifest ocispec.Manifest
+	require.NoError(t, json.Unmarshal(manifestBytes, &manifest))
+	require.Equal(t, map[string]string{
+		ocispec.AnnotationDescription:       "an example bundle",
+		ocispec.AnnotationURL:               "https://example.com",
+		"org.opencontainers.image.licenses": "Apache-2.0",
+		"com.example.support":               "https://example.com/support",
+	}, manifest.Annotations)
+
+	require.NoError(t, validateAnnotations(map[string]string{"org.opencontainers.image.licenses": "Apache-2.0"}))
+	require.EqualError(t, validateAnnotations(map[string]string{ocispec.AnnotationSource: "https://example.com"}),
+		"annotation org.opencontainers.image.source in metadata.annotations is set from metadata.source, set that instead")
+}
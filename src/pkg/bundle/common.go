@@ -18,8 +18,12 @@ import (
 	"github.com/defenseunicorns/zarf/src/pkg/utils"
 	"github.com/defenseunicorns/zarf/src/pkg/utils/helpers"
 	zarfTypes "github.com/defenseunicorns/zarf/src/types"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/errdef"
 
 	"github.com/defenseunicorns/uds-cli/src/config"
+	udsUtils "github.com/defenseunicorns/uds-cli/src/pkg/utils"
 	"github.com/defenseunicorns/uds-cli/src/types"
 )
 
@@ -75,7 +79,6 @@ func (b *Bundler) ClearPaths() {
 
 // ValidateBundleResources validates the bundle's metadata and package references
 func (b *Bundler) ValidateBundleResources(bundle *types.UDSBundle, spinner *message.Spinner) error {
-	// TODO: need to validate arch of local OS
 	if bundle.Metadata.Architecture == "" {
 		// ValidateBundle was erroneously called before CalculateBuildInfo
 		if err := b.CalculateBuildInfo(); err != nil {
@@ -96,10 +99,18 @@ func (b *Bundler) ValidateBundleResources(bundle *types.UDSBundle, spinner *mess
 		return fmt.Errorf("%s is missing required list: packages", config.BundleYAML)
 	}
 
+	if err := validateAnnotations(bundle.Metadata.Annotations); err != nil {
+		return err
+	}
+
 	if err := validateBundleVars(bundle.ZarfPackages); err != nil {
 		return fmt.Errorf("error validating bundle vars: %s", err)
 	}
 
+	if _, err := deployOrder(bundle.ZarfPackages); err != nil {
+		return err
+	}
+
 	tmp, err := utils.MakeTempDir("")
 	if err != nil {
 		return err
@@ -123,38 +134,52 @@ func (b *Bundler) ValidateBundleResources(bundle *types.UDSBundle, spinner *mess
 		if pkg.Ref == "" {
 			return fmt.Errorf("%s .packages[%s] is missing required field: ref", config.BundleYAML, pkg.Repository)
 		}
+		if pkg.Repository != "" {
+			ref, err := normalizePackageRef(pkg.Ref)
+			if err != nil {
+				return fmt.Errorf("package %s: %w", pkg.Name, err)
+			}
+			pkg.Ref = ref
+			bundle.ZarfPackages[idx].Ref = ref
+		}
 		zarfYAML := zarfTypes.ZarfPackage{}
 		var url string
 		// if using a remote repository
 		if pkg.Repository != "" {
 			url = fmt.Sprintf("%s:%s-%s", pkg.Repository, pkg.Ref, bundle.Metadata.Architecture)
 			if strings.Contains(pkg.Ref, "@sha256:") {
-				url = fmt.Sprintf("%s:%s", pkg.Repository, pkg.Ref)
+				url = packageURL(pkg)
 			}
 			remotePkg, err := bundler.NewRemoteBundler(pkg, url, nil, nil, b.tmp)
 			if err != nil {
+				// a missing <ref>-<arch> tag means the package isn't published for the bundle's arch
+				if errors.Is(err, errdef.ErrNotFound) && !strings.Contains(pkg.Ref, "@sha256:") {
+					if archs, archErr := remotePackageArchs(pkg); archErr == nil {
+						return unavailableArchError(pkg, bundle.Metadata.Architecture, archs)
+					}
+				}
 				return err
 			}
 			if err := remotePkg.RemoteSrc.Repo().Reference.ValidateReferenceAsDigest(); err != nil {
-				manifestDesc, _ := remotePkg.RemoteSrc.ResolveRoot()
-				bundle.ZarfPackages[idx].Ref = pkg.Ref + "-" + bundle.Metadata.Architecture + "@sha256:" + manifestDesc.Digest.Encoded()
+				manifestDesc, err := remotePkg.RemoteSrc.ResolveRoot()
+				if err != nil {
+					return err
+				}
+				ref, err := pinPackageRef(pkg, bundle.Metadata.Architecture, manifestDesc, b.cfg.CreateOpts.RequireDigests)
+				if err != nil {
+					return err
+				}
+				bundle.ZarfPackages[idx].Ref = ref
 			}
 			zarfYAML, err = remotePkg.GetMetadata(url, tmp)
 			if err != nil {
 				return err
 			}
 		} else {
-			// atm we don't support outputting a bundle with local pkgs outputting to OCI
-			if b.cfg.CreateOpts.Output != "" {
-				return fmt.Errorf("detected local Zarf package: %s, outputting to an OCI registry is not supported when using local Zarf packages", pkg.Name)
+			path := filepath.Join(pkg.Path, localPackageFilename(pkg, bundle.Metadata.Architecture))
+			if utils.InvalidPath(path) {
+				return unavailableArchError(pkg, bundle.Metadata.Architecture, localPackageArchs(pkg))
 			}
-			var fullPkgName string
-			if pkg.Name == "init" {
-				fullPkgName = fmt.Sprintf("zarf-%s-%s-%s.tar.zst", pkg.Name, bundle.Metadata.Architecture, pkg.Ref)
-			} else {
-				fullPkgName = fmt.Sprintf("zarf-package-%s-%s-%s.tar.zst", pkg.Name, bundle.Metadata.Architecture, pkg.Ref)
-			}
-			path := filepath.Join(pkg.Path, fullPkgName)
 			bundle.ZarfPackages[idx].Path = path
 			p := bundler.NewLocalBundler(pkg.Path, tmp)
 			if err != nil {
@@ -168,6 +193,10 @@ func (b *Bundler) ValidateBundleResources(bundle *types.UDSBundle, spinner *mess
 
 		message.Debug("Validating package:", message.JSONValue(pkg))
 
+		if err := checkPackageArch(pkg, zarfYAML.Build.Architecture, bundle.Metadata.Architecture, b.cfg.CreateOpts.AllowArchMismatch); err != nil {
+			return err
+		}
+
 		defer os.RemoveAll(tmp)
 
 		// todo: need to packager.ValidatePackageSignature (or come up with a bundle-level signature scheme)
@@ -201,6 +230,40 @@ func (b *Bundler) ValidateBundleResources(bundle *types.UDSBundle, spinner *mess
 	return nil
 }
 
+// pinPackageRef returns the ref of a remote Zarf pkg referenced by a mutable tag pinned to the digest of the
+// manifest the tag resolved to, or an error if digests are required
+func pinPackageRef(pkg types.BundleZarfPackage, arch string, manifestDesc ocispec.Descriptor, requireDigests bool) (string, error) {
+	pinned := pkg.Ref + "-" + arch + "@sha256:" + manifestDesc.Digest.Encoded()
+	if requireDigests {
+		return "", fmt.Errorf("package %s references the mutable tag %s, which --require-digests doesn't allow (pin it with ref: %s)", pkg.Name, pkg.Ref, pinned)
+	}
+	message.Warnf("Package %s references the mutable tag %s, pinning it to the digest it currently resolves to: %s", pkg.Name, pkg.Ref, pinned)
+	return pinned, nil
+}
+
+// normalizePackageRef validates the ref of a remote Zarf pkg, which is a tag (e.g. 0.0.1), a tag pinned to a digest
+// (e.g. 0.0.1-amd64@sha256:<digest>) or just a digest (e.g. sha256:<digest>), returning a digest as @sha256:<digest>
+func normalizePackageRef(ref string) (string, error) {
+	if _, err := digest.Parse(ref); err == nil {
+		return "@" + ref, nil
+	}
+	if _, pinned, ok := strings.Cut(ref, "@"); ok {
+		if _, err := digest.Parse(pinned); err != nil || !strings.HasPrefix(pinned, "sha256:") {
+			return "", fmt.Errorf("invalid digest in ref %s, must be sha256:<digest>", ref)
+		}
+	}
+	return ref, nil
+}
+
+// packageURL returns the OCI URL of a remote Zarf pkg, joining its repository and ref with @ when the ref is only a
+// digest and with : otherwise (e.g. ghcr.io/org/pkg:0.0.1 or ghcr.io/org/pkg@sha256:<digest>)
+func packageURL(pkg types.BundleZarfPackage) string {
+	if strings.HasPrefix(pkg.Ref, "@") {
+		return pkg.Repository + pkg.Ref
+	}
+	return pkg.Repository + ":" + pkg.Ref
+}
+
 // validateBundleVars ensures imports and exports between Zarf pkgs match up
 func validateBundleVars(packages []types.BundleZarfPackage) error {
 	exports := make(map[string]string)
@@ -249,24 +312,39 @@ func (b *Bundler) CalculateBuildInfo() error {
 	return nil
 }
 
-// ValidateBundleSignature validates the bundle signature
-func ValidateBundleSignature(bundleYAMLPath, signaturePath, publicKeyPath string) error {
+// bundleSize sums the sizes of a bundle's layers, counting layers that are shared between packages once
+func bundleSize(layers []ocispec.Descriptor) int64 {
+	var size int64
+	seen := make(map[string]bool)
+	for _, layer := range layers {
+		digest := layer.Digest.String()
+		if digest == "" || seen[digest] {
+			continue
+		}
+		seen[digest] = true
+		size += layer.Size
+	}
+	return size
+}
+
+// ValidateBundleSignature validates the bundle signature according to policy
+func ValidateBundleSignature(bundleYAMLPath, signaturePath string, policy types.SignaturePolicy) error {
 	if utils.InvalidPath(bundleYAMLPath) {
 		return fmt.Errorf("path for %s at %s does not exist", config.BundleYAML, bundleYAMLPath)
 	}
-	// The package is not signed, and no public key was provided
-	if signaturePath == "" && publicKeyPath == "" {
-		return nil
+	signed := signaturePath != "" && !utils.InvalidPath(signaturePath)
+	verify, err := udsUtils.CheckSignaturePolicy(policy, signed)
+	if err != nil {
+		return err
 	}
-	// The package is not signed, but a public key was provided
-	if utils.InvalidPath(signaturePath) && !utils.InvalidPath(publicKeyPath) {
-		return fmt.Errorf("package is not signed, but a public key was provided")
+	switch {
+	case policy.SkipSignatureValidation:
+		message.Warn("SIGNATURE VALIDATION IS SKIPPED: the bundle's signature is not validated and its contents may have been tampered with")
+	case signed && !verify:
+		message.Warn("The bundle is signed but its signature was not verified, pass --key to verify it")
 	}
-	// The package is signed, but no public key was provided
-	if !utils.InvalidPath(signaturePath) && utils.InvalidPath(publicKeyPath) {
-		return fmt.Errorf("package is signed, but no public key was provided")
+	if !verify {
+		return nil
 	}
-
-	// The package is signed, and a public key was provided
-	return utils.CosignVerifyBlob(bundleYAMLPath, signaturePath, publicKeyPath)
+	return utils.CosignVerifyBlob(bundleYAMLPath, signaturePath, policy.PublicKeyPath)
 }
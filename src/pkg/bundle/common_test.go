@@ -1,8 +1,14 @@
 package bundle
 
 import (
+	"reflect"
+	"strings"
 	"testing"
 
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+
+	"github.com/defenseunicorns/uds-cli/src/pkg/utils"
 	"github.com/defenseunicorns/uds-cli/src/types"
 )
 
@@ -67,3 +73,128 @@ func Test_validateBundleVars(t *testing.T) {
 		})
 	}
 }
+
+func Test_bundleSize(t *testing.T) {
+	layer := func(data string) ocispec.Descriptor {
+		return ocispec.Descriptor{Digest: digest.FromString(data), Size: int64(len(data))}
+	}
+	tests := []struct {
+		name   string
+		layers []ocispec.Descriptor
+		want   int64
+	}{
+		{
+			name:   "SumsLayers",
+			layers: []ocispec.Descriptor{layer("foo"), layer("barbaz")},
+			want:   9,
+		},
+		{
+			name:   "CountsSharedLayersOnce",
+			layers: []ocispec.Descriptor{layer("foo"), layer("barbaz"), layer("foo")},
+			want:   9,
+		},
+		{
+			name:   "SkipsEmptyDescriptors",
+			layers: []ocispec.Descriptor{layer("foo"), {}},
+			want:   3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := bundleSize(tt.layers); got != tt.want {
+				t.Errorf("bundleSize() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_recordPackageLayers(t *testing.T) {
+	bundle := &types.UDSBundle{}
+	config := ocispec.Descriptor{Digest: digest.FromString("config")}
+	layer := ocispec.Descriptor{Digest: digest.FromString("layer")}
+
+	recordPackageLayers(bundle, "podinfo", []ocispec.Descriptor{config, {}, layer})
+
+	got := bundle.Build.PackageLayers["podinfo"]
+	want := []string{config.Digest.String(), layer.Digest.String()}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("recordPackageLayers() = %v, want %v", got, want)
+	}
+
+	manifestLayers := []ocispec.Descriptor{layer, {Digest: digest.FromString("optional")}}
+	if filtered := utils.LayersByDigest(manifestLayers, got); !reflect.DeepEqual(filtered, []ocispec.Descriptor{layer}) {
+		t.Errorf("LayersByDigest() = %v, want only the recorded layer", filtered)
+	}
+}
+
+func Test_pinPackageRef(t *testing.T) {
+	pkg := types.BundleZarfPackage{Name: "podinfo", Repository: "ghcr.io/podinfo", Ref: "0.0.1"}
+	manifestDesc := ocispec.Descriptor{Digest: digest.FromString("manifest")}
+	want := "0.0.1-amd64@sha256:" + manifestDesc.Digest.Encoded()
+
+	got, err := pinPackageRef(pkg, "amd64", manifestDesc, false)
+	if err != nil || got != want {
+		t.Errorf("pinPackageRef() = %v, %v, want %v", got, err, want)
+	}
+
+	if _, err := pinPackageRef(pkg, "amd64", manifestDesc, true); err == nil || !strings.Contains(err.Error(), want) {
+		t.Errorf("pinPackageRef() error = %v, want an error suggesting %v", err, want)
+	}
+}
+
+func Test_packageRefs(t *testing.T) {
+	sha := digest.FromString("manifest").String()
+	tests := []struct {
+		ref     string
+		wantRef string
+		wantURL string
+		wantErr bool
+	}{
+		{ref: "0.0.1", wantRef: "0.0.1", wantURL: "ghcr.io/podinfo:0.0.1"},
+		{ref: "0.0.1-amd64@" + sha, wantRef: "0.0.1-amd64@" + sha, wantURL: "ghcr.io/podinfo:0.0.1-amd64@" + sha},
+		{ref: sha, wantRef: "@" + sha, wantURL: "ghcr.io/podinfo@" + sha},
+		{ref: "@" + sha, wantRef: "@" + sha, wantURL: "ghcr.io/podinfo@" + sha},
+		{ref: "0.0.1@sha256:abc", wantErr: true},
+		{ref: "0.0.1@" + digest.SHA512.FromString("manifest").String(), wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := normalizePackageRef(tt.ref)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("normalizePackageRef(%q) error = %v, wantErr %v", tt.ref, err, tt.wantErr)
+			continue
+		}
+		if tt.wantErr {
+			continue
+		}
+		if got != tt.wantRef {
+			t.Errorf("normalizePackageRef(%q) = %v, want %v", tt.ref, got, tt.wantRef)
+		}
+		if url := packageURL(types.BundleZarfPackage{Repository: "ghcr.io/podinfo", Ref: got}); url != tt.wantURL {
+			t.Errorf("packageURL(%q) = %v, want %v", got, url, tt.wantURL)
+		}
+	}
+}
+
+func Test_NewInspectReport(t *testing.T) {
+	bundle := types.UDSBundle{
+		Metadata: types.UDSMetadata{Name: "example", Architecture: "arm64"},
+		ZarfPackages: []types.BundleZarfPackage{
+			{Name: "nginx", Repository: "localhost:888/nginx", Ref: "0.0.1-arm64@sha256:abc"},
+			{Name: "local", Path: "../packages", Ref: "0.0.1-arm64@sha256:def"},
+		},
+	}
+	manifestDesc := ocispec.Descriptor{MediaType: ocispec.MediaTypeImageManifest, Digest: digest.FromString("manifest")}
+
+	got := NewInspectReport(bundle, manifestDesc)
+	want := []InspectedPackage{
+		{Name: "nginx", Repository: "localhost:888/nginx", Ref: "0.0.1-arm64@sha256:abc", Architecture: "arm64"},
+		{Name: "local", Ref: "0.0.1-arm64@sha256:def", Architecture: "arm64"},
+	}
+	if !reflect.DeepEqual(got.Packages, want) {
+		t.Errorf("NewInspectReport() packages = %v, want %v", got.Packages, want)
+	}
+	if got.Manifest.Digest != manifestDesc.Digest {
+		t.Errorf("NewInspectReport() manifest = %v, want %v", got.Manifest.Digest, manifestDesc.Digest)
+	}
+}
@@ -8,21 +8,19 @@ import (
 	"errors"
 	"fmt"
 	"os"
-	"path/filepath"
 	"strings"
 
 	"oras.land/oras-go/v2/registry"
 
 	"github.com/AlecAivazis/survey/v2"
 	zarfConfig "github.com/defenseunicorns/zarf/src/config"
-	"github.com/defenseunicorns/zarf/src/pkg/interactive"
 	"github.com/defenseunicorns/zarf/src/pkg/message"
-	"github.com/defenseunicorns/zarf/src/pkg/oci"
 	"github.com/defenseunicorns/zarf/src/pkg/utils"
 	"github.com/defenseunicorns/zarf/src/pkg/utils/helpers"
 	"github.com/pterm/pterm"
 
 	"github.com/defenseunicorns/uds-cli/src/config"
+	udsUtils "github.com/defenseunicorns/uds-cli/src/pkg/utils"
 	"github.com/defenseunicorns/uds-cli/src/types"
 )
 
@@ -70,44 +68,19 @@ func (b *Bundler) Create() error {
 	validateSpinner.Successf("Bundle Validated")
 	pterm.Print()
 
-	var signatureBytes []byte
-
-	// sign the bundle if a signing key was provided
-	if b.cfg.CreateOpts.SigningKeyPath != "" {
-		// write the bundle to disk so we can sign it
-		bundlePath := filepath.Join(b.tmp, config.BundleYAML)
-		if err := utils.WriteYaml(bundlePath, &b.bundle, 0600); err != nil {
-			return err
-		}
-
-		getSigCreatePassword := func(_ bool) ([]byte, error) {
-			if b.cfg.CreateOpts.SigningKeyPassword != "" {
-				return []byte(b.cfg.CreateOpts.SigningKeyPassword), nil
-			}
-			return interactive.PromptSigPassword()
-		}
-		// sign the bundle
-		signaturePath := filepath.Join(b.tmp, config.BundleYAMLSignature)
-		bytes, err := utils.CosignSignBlob(bundlePath, signaturePath, b.cfg.CreateOpts.SigningKeyPath, getSigCreatePassword)
-		if err != nil {
-			return err
-		}
-		signatureBytes = bytes
-	}
-
 	if b.cfg.CreateOpts.Output != "" {
 		// set the remote's reference from the bundle's metadata
 		ref, err := referenceFromMetadata(b.cfg.CreateOpts.Output, &b.bundle.Metadata, b.bundle.Metadata.Architecture)
 		if err != nil {
 			return err
 		}
-		remote, err := oci.NewOrasRemote(ref)
+		remote, err := udsUtils.NewOrasRemote(ref)
 		if err != nil {
 			return err
 		}
-		return CreateAndPublish(remote, &b.bundle, signatureBytes)
+		return CreateAndPublish(remote, &b.bundle, b.createOptions())
 	}
-	return Create(b, signatureBytes)
+	return Create(b, b.createOptions())
 }
 
 // confirmBundleCreation prompts the user to confirm bundle creation
@@ -160,3 +133,11 @@ func referenceFromMetadata(registryLocation string, metadata *types.UDSMetadata,
 
 	return ref.String(), nil
 }
+
+// createOptions returns the options of creating the bundle from the bundler's config
+func (b *Bundler) createOptions() CreateOptions {
+	return CreateOptions{
+		SigningKeyPath:     b.cfg.CreateOpts.SigningKeyPath,
+		SigningKeyPassword: b.cfg.CreateOpts.SigningKeyPassword,
+	}
+}
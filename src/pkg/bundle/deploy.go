@@ -10,17 +10,20 @@ import (
 	"os"
 	"path/filepath"
 	"runtime/debug"
+	"slices"
 	"strings"
 
 	"github.com/AlecAivazis/survey/v2"
 	"github.com/pterm/pterm"
 	"golang.org/x/exp/maps"
+	"golang.org/x/sync/errgroup"
 	"helm.sh/helm/v3/pkg/cli/values"
 	"helm.sh/helm/v3/pkg/getter"
 
 	zarfConfig "github.com/defenseunicorns/zarf/src/config"
 	"github.com/defenseunicorns/zarf/src/pkg/message"
 	"github.com/defenseunicorns/zarf/src/pkg/packager"
+	zarfSources "github.com/defenseunicorns/zarf/src/pkg/packager/sources"
 	"github.com/defenseunicorns/zarf/src/pkg/utils"
 	zarfTypes "github.com/defenseunicorns/zarf/src/types"
 
@@ -38,12 +41,12 @@ type ZarfOverrideMap map[string]map[string]map[string]interface{}
 // : pull the bundle's metadata + sig
 // : read the metadata into memory
 // : validate the sig (if present)
+// : download the packages of a remote bundle concurrently, each into a fresh temp dir
 // : loop through each package
-// : : load the package into a fresh temp dir
+// : : load the package from its temp dir
 // : : validate the sig (if present)
 // : : deploy the package
-func (b *Bundler) Deploy() error {
-	ctx := context.TODO()
+func (b *Bundler) Deploy(ctx context.Context) error {
 
 	pterm.Println()
 	metadataSpinner := message.NewProgressSpinner("Loading bundle metadata")
@@ -63,7 +66,7 @@ func (b *Bundler) Deploy() error {
 	}
 
 	// validate the sig (if present)
-	if err := ValidateBundleSignature(loaded[config.BundleYAML], loaded[config.BundleYAMLSignature], b.cfg.DeployOpts.PublicKeyPath); err != nil {
+	if err := ValidateBundleSignature(loaded[config.BundleYAML], loaded[config.BundleYAMLSignature], b.cfg.DeployOpts.SignaturePolicy); err != nil {
 		return err
 	}
 
@@ -74,16 +77,35 @@ func (b *Bundler) Deploy() error {
 
 	metadataSpinner.Successf("Loaded bundle metadata")
 
+	// order the packages by their dependencies
+	order, err := deployOrder(b.bundle.ZarfPackages)
+	if err != nil {
+		return err
+	}
+
+	// only deploy the selected packages (if any)
+	if order, err = selectPackages(b.bundle.ZarfPackages, order, b.cfg.DeployOpts.Packages); err != nil {
+		return err
+	}
+
+	// warn about the packages being deployed from a ref instead of from the bundle before confirming
+	if err := warnPackageRefs(b.bundle.ZarfPackages, order, b.cfg.DeployOpts.PackageRefs); err != nil {
+		return err
+	}
+
 	// confirm deploy
-	if ok := b.confirmBundleDeploy(); !ok {
+	if ok := b.confirmBundleDeploy(order); !ok {
 		return fmt.Errorf("bundle deployment cancelled")
 	}
 
 	// map of Zarf pkgs and their vars
 	bundleExportedVars := make(map[string]map[string]string)
 
-	// deploy each package
-	for _, pkg := range b.bundle.ZarfPackages {
+	// create a source for each package being deployed, loading it into a fresh temp dir
+	pkgTmps := make([]string, len(b.bundle.ZarfPackages))
+	pkgSources := make([]zarfSources.PackageSource, len(b.bundle.ZarfPackages))
+	for _, i := range order {
+		pkg := b.bundle.ZarfPackages[i]
 		sha := strings.Split(pkg.Ref, "@sha256:")[1] // using appended SHA from create!
 		pkgTmp, err := utils.MakeTempDir(config.CommonOptions.TempDirectory)
 		if err != nil {
@@ -91,6 +113,39 @@ func (b *Bundler) Deploy() error {
 		}
 		defer os.RemoveAll(pkgTmp)
 
+		opts := zarfTypes.ZarfPackageOptions{
+			PackageSource:      pkgTmp,
+			OptionalComponents: strings.Join(pkg.OptionalComponents, ","),
+		}
+		var source zarfSources.PackageSource
+		if ref, ok := b.cfg.DeployOpts.PackageRefs[pkg.Name]; ok {
+			source, err = sources.NewOverride(ctx, ref, pkg.Name, opts)
+		} else {
+			source, err = sources.New(ctx, b.cfg.DeployOpts.Source, pkg.Name, opts, sha, b.cfg.DeployOpts.SignaturePolicy)
+		}
+		if err != nil {
+			return err
+		}
+		pkgTmps[i] = pkgTmp
+		pkgSources[i] = source
+	}
+
+	// download the packages of a remote bundle in the background, deploying each one as soon as it's downloaded
+	downloads := prefetchPackages(ctx, order, pkgSources)
+	defer downloads.stop()
+
+	// deploy each package
+	for _, i := range order {
+		// a canceled deploy stops before the next package, the package being deployed isn't interrupted
+		if ctx.Err() != nil {
+			return context.Cause(ctx)
+		}
+		if err := downloads.wait(i); err != nil {
+			return err
+		}
+		pkg := b.bundle.ZarfPackages[i]
+		pkgTmp := pkgTmps[i]
+
 		publicKeyPath := filepath.Join(b.tmp, config.PublicKeyFile)
 		if pkg.PublicKey != "" {
 			if err := utils.WriteFile(publicKeyPath, []byte(pkg.PublicKey)); err != nil {
@@ -137,12 +192,7 @@ func (b *Bundler) Deploy() error {
 		// Automatically confirm the package deployment
 		zarfConfig.CommonOptions.Confirm = true
 
-		source, err := sources.New(b.cfg.DeployOpts.Source, pkg.Name, opts, sha)
-		if err != nil {
-			return err
-		}
-
-		pkgClient := packager.NewOrDie(&pkgCfg, packager.WithSource(source), packager.WithTemp(opts.PackageSource))
+		pkgClient := packager.NewOrDie(&pkgCfg, packager.WithSource(pkgSources[i]), packager.WithTemp(opts.PackageSource))
 		if err != nil {
 			return err
 		}
@@ -160,6 +210,92 @@ func (b *Bundler) Deploy() error {
 	return nil
 }
 
+// warnPackageRefs warns about each package being deployed from a ref given with --ref instead of from the bundle,
+// erroring if a ref is given for a package that isn't in the bundle
+func warnPackageRefs(packages []types.BundleZarfPackage, order []int, refs map[string]string) error {
+	for name := range refs {
+		if !slices.ContainsFunc(packages, func(pkg types.BundleZarfPackage) bool { return pkg.Name == name }) {
+			return fmt.Errorf("package %s is not a package in the bundle", name)
+		}
+	}
+	for _, i := range order {
+		pkg := packages[i]
+		if ref, ok := refs[pkg.Name]; ok {
+			message.Warnf("Package %s will be deployed from %s instead of from the bundle. It isn't the package the "+
+				"bundle was created with and its signature will NOT be verified", pkg.Name, ref)
+		}
+	}
+	return nil
+}
+
+// packageDownloads are the packages of a remote bundle being downloaded in the background while the bundle deploys
+type packageDownloads struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	group  *errgroup.Group
+	done   []chan error
+	queued chan struct{}
+}
+
+// prefetchPackages starts downloading the packages of a remote bundle in deploy order, --oci-concurrency packages at a
+// time, so each package can be deployed as soon as it's downloaded while the later ones keep downloading; a failed
+// download cancels the remaining ones
+func prefetchPackages(ctx context.Context, order []int, pkgSources []zarfSources.PackageSource) *packageDownloads {
+	ctx, cancel := context.WithCancel(ctx)
+	g, ctx := errgroup.WithContext(ctx)
+	if config.CommonOptions.OCIConcurrency > 0 {
+		g.SetLimit(config.CommonOptions.OCIConcurrency)
+	}
+	d := &packageDownloads{
+		ctx:    ctx,
+		cancel: cancel,
+		group:  g,
+		done:   make([]chan error, len(pkgSources)),
+		queued: make(chan struct{}),
+	}
+	for _, i := range order {
+		d.done[i] = make(chan error, 1)
+	}
+
+	// queue the downloads from a goroutine as g.Go blocks once --oci-concurrency downloads are running
+	go func() {
+		defer close(d.queued)
+		for _, i := range order {
+			i := i
+			// packages deployed from a ref or from a local bundle aren't prefetched
+			remoteBundle, ok := pkgSources[i].(*sources.RemoteBundle)
+			if !ok {
+				d.done[i] <- nil
+				continue
+			}
+			g.Go(func() error {
+				err := remoteBundle.Prefetch(ctx)
+				d.done[i] <- err
+				return err
+			})
+		}
+	}()
+	return d
+}
+
+// wait blocks until the package at index i is downloaded, returning the error that stopped the downloads if it wasn't
+func (d *packageDownloads) wait(i int) error {
+	if err := <-d.done[i]; err != nil {
+		if cause := context.Cause(d.ctx); cause != nil {
+			return cause
+		}
+		return err
+	}
+	return nil
+}
+
+// stop cancels the downloads that are still running and waits for them to return, before their temp dirs are removed
+func (d *packageDownloads) stop() {
+	d.cancel()
+	<-d.queued
+	_ = d.group.Wait()
+}
+
 // loadVariables loads and sets precedence for config-level and imported variables
 func (b *Bundler) loadVariables(pkg types.BundleZarfPackage, bundleExportedVars map[string]map[string]string) map[string]string {
 	pkgVars := make(map[string]string)
@@ -179,11 +315,17 @@ func (b *Bundler) loadVariables(pkg types.BundleZarfPackage, bundleExportedVars
 }
 
 // confirmBundleDeploy prompts the user to confirm bundle creation
-func (b *Bundler) confirmBundleDeploy() (confirm bool) {
+func (b *Bundler) confirmBundleDeploy(order []int) (confirm bool) {
 
 	message.HeaderInfof("🎁 BUNDLE DEFINITION")
 	utils.ColorPrintYAML(b.bundle, nil, false)
 
+	names := make([]string, 0, len(order))
+	for _, i := range order {
+		names = append(names, b.bundle.ZarfPackages[i].Name)
+	}
+	message.Infof("Packages will be deployed in this order: %s", strings.Join(names, ", "))
+
 	message.HorizontalRule()
 
 	// Display prompt if not auto-confirmed
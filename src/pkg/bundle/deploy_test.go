@@ -0,0 +1,19 @@
+package bundle
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/defenseunicorns/uds-cli/src/types"
+)
+
+func Test_warnPackageRefs(t *testing.T) {
+	packages := []types.BundleZarfPackage{{Name: "app"}, {Name: "other"}}
+
+	require.NoError(t, warnPackageRefs(packages, []int{0, 1}, nil))
+	require.NoError(t, warnPackageRefs(packages, []int{0}, map[string]string{"app": "ghcr.io/org/app:dev"}))
+
+	err := warnPackageRefs(packages, []int{0, 1}, map[string]string{"missing": "ghcr.io/org/missing:dev"})
+	require.EqualError(t, err, "package missing is not a package in the bundle")
+}
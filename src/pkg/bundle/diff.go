@@ -0,0 +1,256 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023-Present The UDS Authors
+
+// Package bundle contains functions for interacting with, managing and deploying UDS packages
+package bundle
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/defenseunicorns/zarf/src/pkg/oci"
+	"github.com/defenseunicorns/zarf/src/pkg/utils"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+
+	"github.com/defenseunicorns/uds-cli/src/config"
+	"github.com/defenseunicorns/uds-cli/src/types"
+)
+
+// DiffReport is the structured (ie. JSON) output of the differences between two bundles
+type DiffReport struct {
+	From     string        `json:"from"`
+	To       string        `json:"to"`
+	Added    []DiffPackage `json:"added"`
+	Removed  []DiffPackage `json:"removed"`
+	Modified []PackageDiff `json:"modified"`
+}
+
+// DiffPackage is a Zarf package that is only in one of the diffed bundles
+type DiffPackage struct {
+	Name       string `json:"name"`
+	Repository string `json:"repository,omitempty"`
+	Ref        string `json:"ref"`
+}
+
+// PackageDiff is the differences of a Zarf package that is in both diffed bundles
+type PackageDiff struct {
+	Name           string      `json:"name"`
+	FromRepository string      `json:"fromRepository,omitempty"`
+	ToRepository   string      `json:"toRepository,omitempty"`
+	FromRef        string      `json:"fromRef"`
+	ToRef          string      `json:"toRef"`
+	Layers         []LayerDiff `json:"layers"`
+}
+
+// LayerDiff is a layer of a Zarf package whose digest differs between the diffed bundles, an empty digest means the
+// layer isn't in that bundle
+type LayerDiff struct {
+	Title      string `json:"title"`
+	FromDigest string `json:"fromDigest,omitempty"`
+	ToDigest   string `json:"toDigest,omitempty"`
+}
+
+// IsEmpty returns true if the diffed bundles contain the same packages
+func (r DiffReport) IsEmpty() bool {
+	return len(r.Added) == 0 && len(r.Removed) == 0 && len(r.Modified) == 0
+}
+
+// bundleContents is a bundle's uds-bundle.yaml along with the manifests of its packages, keyed by package name
+type bundleContents struct {
+	bundle    types.UDSBundle
+	manifests map[string]*oci.ZarfOCIManifest
+}
+
+// Diff fetches the metadata of two bundles and shows the differences between their packages
+func (b *Bundler) Diff() error {
+	from, err := b.loadBundleContents(b.cfg.DiffOpts.Source, filepath.Join(b.tmp, "from"))
+	if err != nil {
+		return err
+	}
+	to, err := b.loadBundleContents(b.cfg.DiffOpts.Target, filepath.Join(b.tmp, "to"))
+	if err != nil {
+		return err
+	}
+	report := diffBundles(from, to)
+	report.From = b.cfg.DiffOpts.Source
+	report.To = b.cfg.DiffOpts.Target
+
+	switch b.cfg.DiffOpts.Output {
+	case "json":
+		output, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(output))
+	case "text", "":
+		fmt.Print(report.String())
+	default:
+		return fmt.Errorf("unsupported output format %q, must be one of: text, json", b.cfg.DiffOpts.Output)
+	}
+	return nil
+}
+
+// loadBundleContents pulls/unpacks the uds-bundle.yaml of the bundle at source into dst and fetches the manifests of
+// its packages
+func (b *Bundler) loadBundleContents(source string, dst string) (bundleContents, error) {
+	if err := os.MkdirAll(dst, 0700); err != nil {
+		return bundleContents{}, err
+	}
+	provider, err := NewBundleProvider(context.TODO(), source, dst)
+	if err != nil {
+		return bundleContents{}, err
+	}
+	loaded, err := provider.LoadBundleMetadata()
+	if err != nil {
+		return bundleContents{}, err
+	}
+	contents := bundleContents{manifests: map[string]*oci.ZarfOCIManifest{}}
+	if err := utils.ReadYaml(loaded[config.BundleYAML], &contents.bundle); err != nil {
+		return bundleContents{}, err
+	}
+	for _, pkg := range contents.bundle.ZarfPackages {
+		_, sha, ok := strings.Cut(pkg.Ref, "@sha256:")
+		if !ok {
+			return bundleContents{}, fmt.Errorf("package %s in %s has no manifest sha in its ref %s", pkg.Name, source, pkg.Ref)
+		}
+		manifest, err := provider.getPackageManifest(sha)
+		if err != nil {
+			return bundleContents{}, err
+		}
+		contents.manifests[pkg.Name] = manifest
+	}
+	return contents, nil
+}
+
+// diffBundles returns the packages added to and removed from the from bundle, and the packages in both whose ref,
+// repository or layers differ
+func diffBundles(from, to bundleContents) DiffReport {
+	report := DiffReport{Added: []DiffPackage{}, Removed: []DiffPackage{}, Modified: []PackageDiff{}}
+	fromPkgs := map[string]types.BundleZarfPackage{}
+	for _, pkg := range from.bundle.ZarfPackages {
+		fromPkgs[pkg.Name] = pkg
+	}
+	toPkgs := map[string]types.BundleZarfPackage{}
+	for _, pkg := range to.bundle.ZarfPackages {
+		toPkgs[pkg.Name] = pkg
+	}
+
+	for _, pkg := range from.bundle.ZarfPackages {
+		if _, ok := toPkgs[pkg.Name]; !ok {
+			report.Removed = append(report.Removed, DiffPackage{Name: pkg.Name, Repository: pkg.Repository, Ref: pkg.Ref})
+		}
+	}
+	for _, toPkg := range to.bundle.ZarfPackages {
+		fromPkg, ok := fromPkgs[toPkg.Name]
+		if !ok {
+			report.Added = append(report.Added, DiffPackage{Name: toPkg.Name, Repository: toPkg.Repository, Ref: toPkg.Ref})
+			continue
+		}
+		layers := diffLayers(from.manifests[toPkg.Name], to.manifests[toPkg.Name])
+		if fromPkg.Ref == toPkg.Ref && fromPkg.Repository == toPkg.Repository && len(layers) == 0 {
+			continue
+		}
+		report.Modified = append(report.Modified, PackageDiff{
+			Name:           toPkg.Name,
+			FromRepository: fromPkg.Repository,
+			ToRepository:   toPkg.Repository,
+			FromRef:        fromPkg.Ref,
+			ToRef:          toPkg.Ref,
+			Layers:         layers,
+		})
+	}
+	return report
+}
+
+// diffLayers returns the layers, matched by title, whose digest differs between two package manifests
+func diffLayers(from, to *oci.ZarfOCIManifest) []LayerDiff {
+	diffs := []LayerDiff{}
+	fromDigests := layerDigests(from)
+	toDigests := layerDigests(to)
+	if from != nil {
+		for _, layer := range from.Layers {
+			title := layerTitle(layer)
+			if toDigest := toDigests[title]; toDigest != layer.Digest.String() {
+				diffs = append(diffs, LayerDiff{Title: title, FromDigest: layer.Digest.String(), ToDigest: toDigest})
+			}
+		}
+	}
+	if to != nil {
+		for _, layer := range to.Layers {
+			title := layerTitle(layer)
+			if _, ok := fromDigests[title]; !ok {
+				diffs = append(diffs, LayerDiff{Title: title, ToDigest: layer.Digest.String()})
+			}
+		}
+	}
+	return diffs
+}
+
+// layerDigests maps the titles of a package manifest's layers to their digests
+func layerDigests(manifest *oci.ZarfOCIManifest) map[string]string {
+	digests := map[string]string{}
+	if manifest == nil {
+		return digests
+	}
+	for _, layer := range manifest.Layers {
+		digests[layerTitle(layer)] = layer.Digest.String()
+	}
+	return digests
+}
+
+// layerTitle returns the title of a layer, falling back to its digest for untitled layers
+func layerTitle(layer ocispec.Descriptor) string {
+	if title := layer.Annotations[ocispec.AnnotationTitle]; title != "" {
+		return title
+	}
+	return layer.Digest.String()
+}
+
+// String returns the human-readable differences between the bundles
+func (r DiffReport) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Comparing %s to %s\n", r.From, r.To)
+	if r.IsEmpty() {
+		sb.WriteString("\nThe bundles contain the same packages\n")
+		return sb.String()
+	}
+	if len(r.Added) > 0 {
+		sb.WriteString("\nAdded packages:\n")
+		for _, pkg := range r.Added {
+			fmt.Fprintf(&sb, "  + %s %s\n", pkg.Name, pkg.Ref)
+		}
+	}
+	if len(r.Removed) > 0 {
+		sb.WriteString("\nRemoved packages:\n")
+		for _, pkg := range r.Removed {
+			fmt.Fprintf(&sb, "  - %s %s\n", pkg.Name, pkg.Ref)
+		}
+	}
+	if len(r.Modified) > 0 {
+		sb.WriteString("\nModified packages:\n")
+		for _, pkg := range r.Modified {
+			fmt.Fprintf(&sb, "  ~ %s\n", pkg.Name)
+			if pkg.FromRepository != pkg.ToRepository {
+				fmt.Fprintf(&sb, "      repository: %s -> %s\n", pkg.FromRepository, pkg.ToRepository)
+			}
+			if pkg.FromRef != pkg.ToRef {
+				fmt.Fprintf(&sb, "      ref: %s -> %s\n", pkg.FromRef, pkg.ToRef)
+			}
+			for _, layer := range pkg.Layers {
+				switch {
+				case layer.FromDigest == "":
+					fmt.Fprintf(&sb, "      + %s (%s)\n", layer.Title, layer.ToDigest)
+				case layer.ToDigest == "":
+					fmt.Fprintf(&sb, "      - %s (%s)\n", layer.Title, layer.FromDigest)
+				default:
+					fmt.Fprintf(&sb, "      ~ %s (%s -> %s)\n", layer.Title, layer.FromDigest, layer.ToDigest)
+				}
+			}
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,75 @@
+package bundle
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/defenseunicorns/zarf/src/pkg/oci"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/require"
+
+	"github.com/defenseunicorns/uds-cli/src/types"
+)
+
+func Test_diffBundles(t *testing.T) {
+	layer := func(title string, content string) ocispec.Descriptor {
+		return ocispec.Descriptor{
+			Digest:      digest.FromString(content),
+			Annotations: map[string]string{ocispec.AnnotationTitle: title},
+		}
+	}
+	manifest := func(layers ...ocispec.Descriptor) *oci.ZarfOCIManifest {
+		return &oci.ZarfOCIManifest{Manifest: ocispec.Manifest{Layers: layers}}
+	}
+
+	from := bundleContents{
+		bundle: types.UDSBundle{ZarfPackages: []types.BundleZarfPackage{
+			{Name: "init", Repository: "ghcr.io/init", Ref: "0.0.1@sha256:a"},
+			{Name: "podinfo", Repository: "ghcr.io/podinfo", Ref: "0.0.1@sha256:b"},
+			{Name: "nginx", Repository: "ghcr.io/nginx", Ref: "0.0.1@sha256:c"},
+		}},
+		manifests: map[string]*oci.ZarfOCIManifest{
+			"init":    manifest(layer("zarf.yaml", "init")),
+			"podinfo": manifest(layer("zarf.yaml", "podinfo"), layer("components/podinfo.tar", "podinfo 0.0.1"), layer("sboms.tar", "sboms")),
+			"nginx":   manifest(layer("zarf.yaml", "nginx")),
+		},
+	}
+	to := bundleContents{
+		bundle: types.UDSBundle{ZarfPackages: []types.BundleZarfPackage{
+			{Name: "init", Repository: "ghcr.io/init", Ref: "0.0.1@sha256:a"},
+			{Name: "podinfo", Repository: "ghcr.io/podinfo", Ref: "0.0.2@sha256:d"},
+			{Name: "redis", Repository: "ghcr.io/redis", Ref: "0.0.1@sha256:e"},
+		}},
+		manifests: map[string]*oci.ZarfOCIManifest{
+			"init":    manifest(layer("zarf.yaml", "init")),
+			"podinfo": manifest(layer("zarf.yaml", "podinfo"), layer("components/podinfo.tar", "podinfo 0.0.2"), layer("images/index.json", "index")),
+			"redis":   manifest(layer("zarf.yaml", "redis")),
+		},
+	}
+
+	report := diffBundles(from, to)
+	require.Equal(t, []DiffPackage{{Name: "redis", Repository: "ghcr.io/redis", Ref: "0.0.1@sha256:e"}}, report.Added)
+	require.Equal(t, []DiffPackage{{Name: "nginx", Repository: "ghcr.io/nginx", Ref: "0.0.1@sha256:c"}}, report.Removed)
+	require.Equal(t, []PackageDiff{{
+		Name:           "podinfo",
+		FromRepository: "ghcr.io/podinfo",
+		ToRepository:   "ghcr.io/podinfo",
+		FromRef:        "0.0.1@sha256:b",
+		ToRef:          "0.0.2@sha256:d",
+		Layers: []LayerDiff{
+			{Title: "components/podinfo.tar", FromDigest: digest.FromString("podinfo 0.0.1").String(), ToDigest: digest.FromString("podinfo 0.0.2").String()},
+			{Title: "sboms.tar", FromDigest: digest.FromString("sboms").String()},
+			{Title: "images/index.json", ToDigest: digest.FromString("index").String()},
+		},
+	}}, report.Modified)
+
+	output := report.String()
+	require.Contains(t, output, "  + redis 0.0.1@sha256:e\n")
+	require.Contains(t, output, "  - nginx 0.0.1@sha256:c\n")
+	require.Contains(t, output, "      ref: 0.0.1@sha256:b -> 0.0.2@sha256:d\n")
+	require.NotContains(t, output, "repository:")
+	require.Equal(t, 1, strings.Count(output, "      - sboms.tar"))
+
+	require.True(t, diffBundles(from, from).IsEmpty())
+}
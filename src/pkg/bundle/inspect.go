@@ -6,47 +6,116 @@ package bundle
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 
-	"github.com/defenseunicorns/uds-cli/src/config"
 	"github.com/defenseunicorns/zarf/src/pkg/utils"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+
+	"github.com/defenseunicorns/uds-cli/src/config"
+	"github.com/defenseunicorns/uds-cli/src/types"
 )
 
+// InspectReport is the structured (ie. JSON) output of an inspected bundle
+type InspectReport struct {
+	Bundle   types.UDSBundle    `json:"bundle"`
+	Packages []InspectedPackage `json:"packages"`
+	Manifest ocispec.Descriptor `json:"manifest"`
+}
+
+// InspectedPackage is a Zarf package contained in an inspected bundle
+type InspectedPackage struct {
+	Name         string `json:"name"`
+	Repository   string `json:"repository,omitempty"`
+	Ref          string `json:"ref"`
+	Architecture string `json:"architecture"`
+}
+
 // Inspect pulls/unpacks a bundle's metadata and shows it
 func (b *Bundler) Inspect() error {
+	_, manifestDesc, err := b.LoadMetadata()
+	if err != nil {
+		return err
+	}
+
+	if b.cfg.InspectOpts.NoBuildData {
+		b.bundle.Build = types.UDSBuildData{}
+	}
+
+	// show the bundle's metadata
+	switch b.cfg.InspectOpts.Output {
+	case "json":
+		output, err := json.MarshalIndent(NewInspectReport(b.bundle, manifestDesc), "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(output))
+	case "yaml", "":
+		utils.ColorPrintYAML(b.bundle, nil, false)
+	default:
+		return fmt.Errorf("unsupported output format %q, must be one of: yaml, json", b.cfg.InspectOpts.Output)
+	}
+
+	// TODO: showing package metadata?
+	// TODO: could be cool to have an interactive mode that lets you select a package and show its metadata
+	return nil
+}
+
+// LoadMetadata pulls/unpacks a bundle's metadata (and its SBOMs if requested), validates its signature and reads its
+// uds-bundle.yaml, returning it along with the descriptor of the bundle's root manifest
+func (b *Bundler) LoadMetadata() (types.UDSBundle, ocispec.Descriptor, error) {
 	ctx := context.TODO()
 	// create a new provider
 	provider, err := NewBundleProvider(ctx, b.cfg.InspectOpts.Source, b.tmp)
 	if err != nil {
-		return err
+		return types.UDSBundle{}, ocispec.Descriptor{}, err
 	}
 
 	// pull the bundle's metadata + sig + sboms (optional)
 	loaded, err := provider.LoadBundleMetadata()
 	if err != nil {
-		return err
+		return types.UDSBundle{}, ocispec.Descriptor{}, err
 	}
 
 	// validate the sig (if present)
-	if err := ValidateBundleSignature(loaded[config.BundleYAML], loaded[config.BundleYAMLSignature], b.cfg.InspectOpts.PublicKeyPath); err != nil {
-		return err
+	if err := ValidateBundleSignature(loaded[config.BundleYAML], loaded[config.BundleYAMLSignature], b.cfg.InspectOpts.SignaturePolicy); err != nil {
+		return types.UDSBundle{}, ocispec.Descriptor{}, err
 	}
 
 	// pull sbom
 	if b.cfg.InspectOpts.IncludeSBOM {
 		err := provider.CreateBundleSBOM(b.cfg.InspectOpts.ExtractSBOM)
 		if err != nil {
-			return err
+			return types.UDSBundle{}, ocispec.Descriptor{}, err
 		}
 	}
 	// read the bundle's metadata into memory
 	if err := utils.ReadYaml(loaded[config.BundleYAML], &b.bundle); err != nil {
-		return err
+		return types.UDSBundle{}, ocispec.Descriptor{}, err
 	}
 
-	// show the bundle's metadata
-	utils.ColorPrintYAML(b.bundle, nil, false)
+	manifestDesc, err := provider.getBundleManifestDesc()
+	if err != nil {
+		return types.UDSBundle{}, ocispec.Descriptor{}, err
+	}
+	return b.bundle, manifestDesc, nil
+}
 
-	// TODO: showing package metadata?
-	// TODO: could be cool to have an interactive mode that lets you select a package and show its metadata
-	return nil
+// NewInspectReport creates the structured output of an inspected bundle
+func NewInspectReport(bundle types.UDSBundle, manifestDesc ocispec.Descriptor) InspectReport {
+	packages := make([]InspectedPackage, 0, len(bundle.ZarfPackages))
+	for _, pkg := range bundle.ZarfPackages {
+		// every package in a bundle is pulled for the bundle's architecture
+		packages = append(packages, InspectedPackage{
+			Name:         pkg.Name,
+			Repository:   pkg.Repository,
+			Ref:          pkg.Ref,
+			Architecture: bundle.Metadata.Architecture,
+		})
+	}
+	return InspectReport{
+		Bundle:   bundle,
+		Packages: packages,
+		Manifest: manifestDesc,
+	}
 }
@@ -0,0 +1,125 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023-Present The UDS Authors
+
+// Package bundle contains functions for interacting with, managing and deploying UDS packages
+package bundle
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/defenseunicorns/zarf/src/pkg/message"
+	"github.com/defenseunicorns/zarf/src/pkg/oci"
+	"github.com/defenseunicorns/zarf/src/pkg/utils"
+	"github.com/mholt/archiver/v4"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+
+	"github.com/defenseunicorns/uds-cli/src/config"
+	"github.com/defenseunicorns/uds-cli/src/types"
+)
+
+// writeBundleLayout writes the uds-bundle.yaml (and its signature) of a pulled bundle along with each of its Zarf
+// pkgs as a standard Zarf pkg tarball into dir, so the bundle can be browsed and its pkgs deployed on their own.
+// loaded maps the bundle's metadata files and blob shas to their paths, and existing files are only overwritten
+// when force is set
+func writeBundleLayout(bundle types.UDSBundle, loaded PathMap, dir string, force bool) error {
+	if err := utils.CreateDirectory(dir, 0755); err != nil {
+		return err
+	}
+
+	metadata := []string{}
+	for _, name := range []string{config.BundleYAML, config.BundleYAMLSignature} {
+		if _, ok := loaded[name]; ok {
+			metadata = append(metadata, name)
+		}
+	}
+	if !force {
+		names := append([]string{}, metadata...)
+		for _, pkg := range bundle.ZarfPackages {
+			names = append(names, layoutPackageFilename(pkg, bundle.Metadata.Architecture))
+		}
+		for _, name := range names {
+			if _, err := os.Stat(filepath.Join(dir, name)); err == nil {
+				return fmt.Errorf("%s already exists in %s, use --force to overwrite it", name, dir)
+			}
+		}
+	}
+
+	for _, name := range metadata {
+		if err := utils.CreatePathAndCopy(loaded[name], filepath.Join(dir, name)); err != nil {
+			return err
+		}
+	}
+
+	for _, pkg := range bundle.ZarfPackages {
+		dst := filepath.Join(dir, layoutPackageFilename(pkg, bundle.Metadata.Architecture))
+		if err := writePackageTarball(pkg, loaded, dst); err != nil {
+			return fmt.Errorf("unable to write package %s: %w", pkg.Name, err)
+		}
+		message.Infof("Wrote package %s to %s", pkg.Name, dst)
+	}
+	return nil
+}
+
+// layoutPackageFilename returns the filename Zarf would give a bundled Zarf pkg's tarball
+func layoutPackageFilename(pkg types.BundleZarfPackage, arch string) string {
+	version, _, _ := strings.Cut(pkg.Ref, "@")
+	return localPackageFilename(types.BundleZarfPackage{Name: pkg.Name, Ref: version}, arch)
+}
+
+// writePackageTarball re-assembles a bundled Zarf pkg from its layers (placed at their titles) into a tarball at dst
+func writePackageTarball(pkg types.BundleZarfPackage, loaded PathMap, dst string) error {
+	_, sha, ok := strings.Cut(pkg.Ref, "@sha256:")
+	if !ok {
+		return fmt.Errorf("no manifest sha in its ref %s", pkg.Ref)
+	}
+	manifestPath, ok := loaded[sha]
+	if !ok {
+		return fmt.Errorf("manifest %s was not pulled", sha)
+	}
+	manifestBytes, err := os.ReadFile(manifestPath)
+	if err != nil {
+		return err
+	}
+	var manifest oci.ZarfOCIManifest
+	if err := json.Unmarshal(manifestBytes, &manifest); err != nil {
+		return err
+	}
+
+	pathMap := packagePathMap(manifest, loaded)
+	if len(pathMap) < len(manifest.Layers) {
+		message.Warnf("Package %s is missing %d layer(s) (ie. optional components that weren't bundled)",
+			pkg.Name, len(manifest.Layers)-len(pathMap))
+	}
+
+	files, err := archiver.FilesFromDisk(nil, pathMap)
+	if err != nil {
+		return err
+	}
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+	format := archiver.CompressedArchive{
+		Compression: archiver.Zstd{},
+		Archival:    archiver.Tar{},
+	}
+	return format.Archive(context.TODO(), out, files)
+}
+
+// packagePathMap maps the pulled blobs of a Zarf pkg's layers to their paths inside the pkg
+func packagePathMap(manifest oci.ZarfOCIManifest, loaded PathMap) PathMap {
+	pathMap := PathMap{}
+	for _, layer := range manifest.Layers {
+		title := layer.Annotations[ocispec.AnnotationTitle]
+		if abs, ok := loaded[layer.Digest.Encoded()]; ok && title != "" {
+			pathMap[abs] = title
+		}
+	}
+	return pathMap
+}
@@ -0,0 +1,74 @@
+package bundle
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/defenseunicorns/zarf/src/pkg/oci"
+	"github.com/mholt/archiver/v4"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/require"
+
+	"github.com/defenseunicorns/uds-cli/src/config"
+	"github.com/defenseunicorns/uds-cli/src/types"
+)
+
+func Test_writeBundleLayout(t *testing.T) {
+	blobs := t.TempDir()
+	loaded := PathMap{}
+	writeBlob := func(content []byte) digest.Digest {
+		d := digest.FromBytes(content)
+		path := filepath.Join(blobs, d.Encoded())
+		require.NoError(t, os.WriteFile(path, content, 0600))
+		loaded[d.Encoded()] = path
+		return d
+	}
+	layer := func(title string, content string) ocispec.Descriptor {
+		return ocispec.Descriptor{Digest: writeBlob([]byte(content)), Annotations: map[string]string{ocispec.AnnotationTitle: title}}
+	}
+
+	manifest := oci.ZarfOCIManifest{Manifest: ocispec.Manifest{Layers: []ocispec.Descriptor{
+		layer("zarf.yaml", "kind: ZarfPackageConfig"),
+		layer("components/podinfo.tar", "podinfo"),
+	}}}
+	manifestBytes, err := json.Marshal(manifest)
+	require.NoError(t, err)
+	manifestDigest := writeBlob(manifestBytes)
+
+	loaded[config.BundleYAML] = filepath.Join(blobs, config.BundleYAML)
+	require.NoError(t, os.WriteFile(loaded[config.BundleYAML], []byte("kind: UDSBundle"), 0600))
+
+	bundle := types.UDSBundle{
+		Metadata:     types.UDSMetadata{Architecture: "amd64"},
+		ZarfPackages: []types.BundleZarfPackage{{Name: "podinfo", Ref: "0.0.1@" + manifestDigest.String()}},
+	}
+	dir := t.TempDir()
+	require.NoError(t, writeBundleLayout(bundle, loaded, dir, false))
+
+	require.FileExists(t, filepath.Join(dir, config.BundleYAML))
+	tarball, err := os.Open(filepath.Join(dir, "zarf-package-podinfo-amd64-0.0.1.tar.zst"))
+	require.NoError(t, err)
+	defer tarball.Close()
+	contents := map[string]string{}
+	format := archiver.CompressedArchive{Compression: archiver.Zstd{}, Archival: archiver.Tar{}}
+	err = format.Extract(context.TODO(), tarball, nil, func(_ context.Context, f archiver.File) error {
+		rc, err := f.Open()
+		if err != nil {
+			return err
+		}
+		defer rc.Close()
+		b, err := io.ReadAll(rc)
+		contents[f.NameInArchive] = string(b)
+		return err
+	})
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"zarf.yaml": "kind: ZarfPackageConfig", "components/podinfo.tar": "podinfo"}, contents)
+
+	require.ErrorContains(t, writeBundleLayout(bundle, loaded, dir, false), "use --force to overwrite it")
+	require.NoError(t, writeBundleLayout(bundle, loaded, dir, true))
+}
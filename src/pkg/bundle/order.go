@@ -0,0 +1,107 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023-Present The UDS Authors
+
+// Package bundle contains functions for interacting with, managing and deploying UDS packages
+package bundle
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/defenseunicorns/zarf/src/pkg/message"
+
+	"github.com/defenseunicorns/uds-cli/src/types"
+)
+
+// deployOrder returns the indexes of a bundle's packages in the order they are deployed
+//
+// packages are deployed in the order they are listed, except that a package is deployed after the packages it
+// depends on (and the packages it imports variables from), which fails when the dependencies form a cycle
+func deployOrder(packages []types.BundleZarfPackage) ([]int, error) {
+	indexes := make(map[string]int, len(packages))
+	for i, pkg := range packages {
+		indexes[pkg.Name] = i
+	}
+
+	var (
+		order   []int
+		visited = make([]bool, len(packages))
+		path    []string
+	)
+	var visit func(i int) error
+	visit = func(i int) error {
+		pkg := packages[i]
+		if slices.Contains(path, pkg.Name) {
+			cycle := append(path[slices.Index(path, pkg.Name):], pkg.Name)
+			return fmt.Errorf("package dependency cycle detected: %s", strings.Join(cycle, " -> "))
+		}
+		if visited[i] {
+			return nil
+		}
+
+		path = append(path, pkg.Name)
+		for _, dep := range packageDependencies(pkg) {
+			j, ok := indexes[dep]
+			if !ok {
+				return fmt.Errorf("package %s depends on %s, which is not a package in the bundle", pkg.Name, dep)
+			}
+			if err := visit(j); err != nil {
+				return err
+			}
+		}
+		path = path[:len(path)-1]
+
+		visited[i] = true
+		order = append(order, i)
+		return nil
+	}
+
+	for i := range packages {
+		if err := visit(i); err != nil {
+			return nil, err
+		}
+	}
+	return order, nil
+}
+
+// selectPackages narrows the deploy order of a bundle's packages to the packages named in names (all of them when no
+// names are given), erroring if a name isn't a package in the bundle. Packages that the selected packages depend on
+// are expected to be deployed already, and variables imported from them can't be set
+func selectPackages(packages []types.BundleZarfPackage, order []int, names []string) ([]int, error) {
+	if len(names) == 0 {
+		return order, nil
+	}
+	for _, name := range names {
+		if !slices.ContainsFunc(packages, func(pkg types.BundleZarfPackage) bool { return pkg.Name == name }) {
+			return nil, fmt.Errorf("package %s is not a package in the bundle", name)
+		}
+	}
+
+	var selected []int
+	for _, i := range order {
+		pkg := packages[i]
+		if !slices.Contains(names, pkg.Name) {
+			continue
+		}
+		for _, imp := range pkg.Imports {
+			if !slices.Contains(names, imp.Package) {
+				message.Warnf("Package %s imports %s from %s, which isn't being deployed so it won't be set",
+					pkg.Name, imp.Name, imp.Package)
+			}
+		}
+		selected = append(selected, i)
+	}
+	return selected, nil
+}
+
+// packageDependencies returns the names of the packages that must be deployed before a package
+func packageDependencies(pkg types.BundleZarfPackage) []string {
+	deps := slices.Clone(pkg.DependsOn)
+	for _, imp := range pkg.Imports {
+		if !slices.Contains(deps, imp.Package) {
+			deps = append(deps, imp.Package)
+		}
+	}
+	return deps
+}
@@ -0,0 +1,89 @@
+package bundle
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/defenseunicorns/uds-cli/src/types"
+)
+
+func Test_deployOrder(t *testing.T) {
+	pkg := func(name string, dependsOn ...string) types.BundleZarfPackage {
+		return types.BundleZarfPackage{Name: name, DependsOn: dependsOn}
+	}
+
+	tests := []struct {
+		name     string
+		packages []types.BundleZarfPackage
+		want     []int
+		wantErr  string
+	}{
+		{
+			name:     "ListedOrder",
+			packages: []types.BundleZarfPackage{pkg("a"), pkg("b"), pkg("c")},
+			want:     []int{0, 1, 2},
+		},
+		{
+			name:     "DependenciesFirst",
+			packages: []types.BundleZarfPackage{pkg("app", "crds"), pkg("other"), pkg("crds")},
+			want:     []int{2, 0, 1},
+		},
+		{
+			name: "Imports",
+			packages: []types.BundleZarfPackage{
+				pkg("a", "c"),
+				{Name: "b", Imports: []types.BundleVariableImport{{Name: "VAR", Package: "a"}}},
+				pkg("c"),
+			},
+			want: []int{2, 0, 1},
+		},
+		{
+			name:     "Cycle",
+			packages: []types.BundleZarfPackage{pkg("a", "b"), pkg("b", "c"), pkg("c", "a")},
+			wantErr:  "package dependency cycle detected: a -> b -> c -> a",
+		},
+		{
+			name:     "SelfCycle",
+			packages: []types.BundleZarfPackage{pkg("a", "a")},
+			wantErr:  "package dependency cycle detected: a -> a",
+		},
+		{
+			name:     "UnknownPackage",
+			packages: []types.BundleZarfPackage{pkg("a", "missing")},
+			wantErr:  "package a depends on missing, which is not a package in the bundle",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order, err := deployOrder(tt.packages)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, order)
+		})
+	}
+}
+
+func Test_selectPackages(t *testing.T) {
+	packages := []types.BundleZarfPackage{
+		{Name: "app", DependsOn: []string{"crds"}},
+		{Name: "other"},
+		{Name: "crds"},
+	}
+	order := []int{2, 0, 1}
+
+	selected, err := selectPackages(packages, order, nil)
+	require.NoError(t, err)
+	require.Equal(t, order, selected)
+
+	// the selection keeps the deploy order rather than the order it was given in
+	selected, err = selectPackages(packages, order, []string{"other", "crds"})
+	require.NoError(t, err)
+	require.Equal(t, []int{2, 1}, selected)
+
+	_, err = selectPackages(packages, order, []string{"app", "missing"})
+	require.EqualError(t, err, "package missing is not a package in the bundle")
+}
@@ -10,7 +10,9 @@ import (
 
 	"github.com/defenseunicorns/zarf/src/pkg/oci"
 	"github.com/defenseunicorns/zarf/src/pkg/utils/helpers"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 
+	"github.com/defenseunicorns/uds-cli/src/config"
 	"github.com/defenseunicorns/uds-cli/src/pkg/utils"
 	"github.com/defenseunicorns/uds-cli/src/types"
 )
@@ -38,9 +40,16 @@ type Provider interface {
 	// CreateBundleSBOM creates a bundle-level SBOM from the underlying Zarf packages, if the Zarf package contains an SBOM
 	CreateBundleSBOM(extractSBOM bool) error
 
-	PublishBundle(bundle types.UDSBundle, remote *oci.OrasRemote) error
+	// PublishBundle publishes a bundle to a remote, or only reports the bundle's size when sizeOnly is set
+	PublishBundle(bundle types.UDSBundle, remote *oci.OrasRemote, sizeOnly bool) error
 
 	getBundleManifest() error
+
+	// getBundleManifestDesc returns the descriptor of the bundle's root manifest
+	getBundleManifestDesc() (ocispec.Descriptor, error)
+
+	// getPackageManifest returns the manifest of the Zarf package in the bundle with the given manifest sha
+	getPackageManifest(sha string) (*oci.ZarfOCIManifest, error)
 }
 
 // PathMap is a map of either absolute paths to relative paths or relative paths to absolute paths
@@ -50,10 +59,15 @@ type PathMap map[string]string
 func NewBundleProvider(ctx context.Context, source, destination string) (Provider, error) {
 	if helpers.IsOCIURL(source) {
 		provider := ociProvider{ctx: ctx, src: source, dst: destination}
-		remote, err := oci.NewOrasRemote(source)
+		remote, err := utils.NewOrasRemote(source)
 		if err != nil {
 			return nil, err
 		}
+		// select the bundle for the target architecture if source is a multi-arch index
+		remote.WithContext(ctx)
+		if err := utils.ResolveIndex(ctx, remote, config.GetArch()); err != nil {
+			return nil, err
+		}
 		provider.OrasRemote = remote
 		return &provider, nil
 	}
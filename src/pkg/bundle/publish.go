@@ -10,11 +10,11 @@ import (
 	"os"
 	"path/filepath"
 
-	oci "github.com/defenseunicorns/zarf/src/pkg/oci"
 	"github.com/defenseunicorns/zarf/src/pkg/utils"
 	av3 "github.com/mholt/archiver/v3"
 
 	"github.com/defenseunicorns/uds-cli/src/config"
+	udsUtils "github.com/defenseunicorns/uds-cli/src/pkg/utils"
 )
 
 // Publish publishes a bundle to a remote OCI registry
@@ -47,11 +47,11 @@ func (b *Bundler) Publish() error {
 	bundleName := b.bundle.Metadata.Name
 	bundleTag := b.bundle.Metadata.Version
 	bundleArch := b.bundle.Metadata.Architecture
-	remote, err := oci.NewOrasRemote(fmt.Sprintf("%s/%s:%s-%s", ociURL, bundleName, bundleTag, bundleArch))
+	remote, err := udsUtils.NewOrasRemote(fmt.Sprintf("%s/%s:%s-%s", ociURL, bundleName, bundleTag, bundleArch))
 	if err != nil {
 		return err
 	}
-	err = provider.PublishBundle(b.bundle, remote)
+	err = provider.PublishBundle(b.bundle, remote, b.cfg.PublishOpts.SizeOnly)
 	if err != nil {
 		return err
 	}
@@ -13,23 +13,24 @@ import (
 
 	zarfConfig "github.com/defenseunicorns/zarf/src/config"
 	"github.com/defenseunicorns/zarf/src/pkg/message"
-	"github.com/defenseunicorns/zarf/src/pkg/oci"
 	"github.com/defenseunicorns/zarf/src/pkg/utils"
 	"github.com/mholt/archiver/v4"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 
 	"github.com/defenseunicorns/uds-cli/src/config"
+	udsUtils "github.com/defenseunicorns/uds-cli/src/pkg/utils"
 )
 
 // Pull pulls a bundle and saves it locally + caches it
 func (b *Bundler) Pull() error {
+	ctx := context.TODO()
 	cacheDir := filepath.Join(zarfConfig.GetAbsCachePath(), "packages")
 	// create the cache directory if it doesn't exist
 	if err := utils.CreateDirectory(cacheDir, 0755); err != nil {
 		return err
 	}
 
-	provider, err := NewBundleProvider(context.TODO(), b.cfg.PullOpts.Source, cacheDir)
+	provider, err := NewBundleProvider(ctx, b.cfg.PullOpts.Source, cacheDir)
 	if err != nil {
 		return err
 	}
@@ -44,21 +45,29 @@ func (b *Bundler) Pull() error {
 	}
 
 	// validate the sig (if present)
-	if err := ValidateBundleSignature(loadedMetadata[config.BundleYAML], loadedMetadata[config.BundleYAMLSignature], b.cfg.PullOpts.PublicKeyPath); err != nil {
+	if err := ValidateBundleSignature(loadedMetadata[config.BundleYAML], loadedMetadata[config.BundleYAMLSignature], b.cfg.PullOpts.SignaturePolicy); err != nil {
 		return err
 	}
 
 	// pull the bundle
-	loaded, err := provider.LoadBundle(zarfConfig.CommonOptions.OCIConcurrency)
+	loaded, err := provider.LoadBundle(config.CommonOptions.OCIConcurrency)
 	if err != nil {
 		return err
 	}
 
+	// lay out the bundle's metadata and Zarf pkgs instead of tarballing the bundle
+	if b.cfg.PullOpts.PackagesDirectory != "" {
+		return writeBundleLayout(b.bundle, loaded, b.cfg.PullOpts.PackagesDirectory, b.cfg.PullOpts.Force)
+	}
+
 	// create a remote client just to resolve the root descriptor
-	remote, err := oci.NewOrasRemote(b.cfg.PullOpts.Source)
+	remote, err := udsUtils.NewOrasRemote(b.cfg.PullOpts.Source)
 	if err != nil {
 		return err
 	}
+	if err := udsUtils.ResolveIndex(ctx, remote, b.bundle.Metadata.Architecture); err != nil {
+		return err
+	}
 
 	// fetch the bundle's root descriptor
 	rootDesc, err := remote.ResolveRoot()
@@ -34,6 +34,8 @@ type ociProvider struct {
 	dst string
 	*oci.OrasRemote
 	manifest *oci.ZarfOCIManifest
+	// rootDesc is the verified descriptor of the bundle's root manifest
+	rootDesc ocispec.Descriptor
 }
 
 func (op *ociProvider) getBundleManifest() error {
@@ -48,11 +50,49 @@ func (op *ociProvider) getBundleManifest() error {
 	return nil
 }
 
+func (op *ociProvider) getBundleManifestDesc() (ocispec.Descriptor, error) {
+	return op.ResolveRoot()
+}
+
+func (op *ociProvider) getPackageManifest(sha string) (*oci.ZarfOCIManifest, error) {
+	if err := op.getBundleManifest(); err != nil {
+		return nil, err
+	}
+	manifestDesc := op.manifest.Locate(sha)
+	if oci.IsEmptyDescriptor(manifestDesc) {
+		return nil, fmt.Errorf("zarf package with manifest sha %s not found in %s", sha, op.src)
+	}
+	var manifestBytes []byte
+	err := utils.RetryOCI(fmt.Sprintf("fetching manifest %s", sha), func() (err error) {
+		manifestBytes, err = op.FetchLayer(manifestDesc)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	var manifest *oci.ZarfOCIManifest
+	if err := json.Unmarshal(manifestBytes, &manifest); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
 // LoadBundleMetadata loads a remote bundle's metadata
 func (op *ociProvider) LoadBundleMetadata() (PathMap, error) {
 	if err := zarfUtils.CreateDirectory(filepath.Join(op.dst, config.BlobsDir), 0700); err != nil {
 		return nil, err
 	}
+
+	// verify the bundle's root manifest before pulling anything it references
+	rootDesc, err := utils.VerifyRootDigest(op.OrasRemote)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := op.Repo().Reference.Digest(); err != nil && op.rootDesc.Digest == "" {
+		message.Infof("Resolved %s to %s", op.src, rootDesc.Digest)
+	}
+	op.rootDesc = rootDesc
+
 	layers, err := op.PullPackagePaths(config.BundleAlwaysPull, filepath.Join(op.dst, config.BlobsDir))
 	if err != nil {
 		return nil, err
@@ -82,6 +122,14 @@ func (op *ociProvider) CreateBundleSBOM(extractSBOM bool) error {
 	if err != nil {
 		return err
 	}
+	// use the bundle-level SBOM aggregated when the bundle was created
+	if sbomDesc := root.Locate(config.BundleSBOMTar); !oci.IsEmptyDescriptor(sbomDesc) {
+		sbomTar, err := op.OrasRemote.FetchLayer(sbomDesc)
+		if err != nil {
+			return err
+		}
+		return utils.OutputBundleSBOM(sbomTar, extractSBOM)
+	}
 	// make tmp dir for pkg SBOM extraction
 	err = os.Mkdir(filepath.Join(op.dst, config.BundleSBOM), 0700)
 	if err != nil {
@@ -95,11 +143,9 @@ func (op *ociProvider) CreateBundleSBOM(extractSBOM bool) error {
 		}
 		// grab descriptor for sboms.tar
 		sbomDesc := zarfManifest.Locate(config.SBOMsTar)
-		if err != nil {
-			return err
-		}
-		if sbomDesc.Annotations == nil {
-			message.Warnf("%s not found in Zarf pkg", config.SBOMsTar)
+		if oci.IsEmptyDescriptor(sbomDesc) {
+			message.Debugf("%s not found in Zarf pkg", config.SBOMsTar)
+			continue
 		}
 		// grab sboms.tar and extract
 		sbomBytes, err := op.OrasRemote.FetchLayer(sbomDesc)
@@ -131,7 +177,7 @@ func (op *ociProvider) CreateBundleSBOM(extractSBOM bool) error {
 }
 
 // LoadBundle loads a bundle from a remote source
-func (op *ociProvider) LoadBundle(_ int) (PathMap, error) {
+func (op *ociProvider) LoadBundle(concurrency int) (PathMap, error) {
 	var layersToPull []ocispec.Descriptor
 	estimatedBytes := int64(0)
 
@@ -160,7 +206,11 @@ func (op *ociProvider) LoadBundle(_ int) (PathMap, error) {
 		if err != nil {
 			return nil, err
 		}
-		manifestBytes, err := op.FetchLayer(manifestDesc)
+		var manifestBytes []byte
+		err = utils.RetryOCI(fmt.Sprintf("fetching manifest of %s", pkg.Name), func() error {
+			manifestBytes, err = op.FetchLayer(manifestDesc)
+			return err
+		})
 		if err != nil {
 			return nil, err
 		}
@@ -169,9 +219,22 @@ func (op *ociProvider) LoadBundle(_ int) (PathMap, error) {
 			return nil, err
 		}
 		layersToPull = append(layersToPull, manifestDesc)
+
+		// use the pkg's layers recorded when the bundle was created, bundles created without them are probed
+		if digests, ok := bundle.Build.PackageLayers[pkg.Name]; ok {
+			for _, layer := range utils.LayersByDigest(manifest.Layers, digests) {
+				layersToPull = append(layersToPull, layer)
+				estimatedBytes += layer.Size
+			}
+			continue
+		}
 		progressBar := message.NewProgressBar(int64(len(manifest.Layers)), fmt.Sprintf("Verifying layers in Zarf package: %s", pkg.Name))
 		for _, layer := range manifest.Layers {
-			ok, err := op.Repo().Blobs().Exists(op.ctx, layer)
+			var ok bool
+			err = utils.RetryOCI(fmt.Sprintf("checking layer %s", layer.Digest.Encoded()), func() error {
+				ok, err = op.Repo().Blobs().Exists(op.ctx, layer)
+				return err
+			})
 			progressBar.Add(1)
 			estimatedBytes += layer.Size
 			if err != nil {
@@ -184,6 +247,12 @@ func (op *ociProvider) LoadBundle(_ int) (PathMap, error) {
 		progressBar.Successf("Verified %s package", pkg.Name)
 	}
 
+	// grab the bundle-level SBOM (if present)
+	if sbomDesc := op.manifest.Locate(config.BundleSBOMTar); !oci.IsEmptyDescriptor(sbomDesc) {
+		layersToPull = append(layersToPull, sbomDesc)
+		estimatedBytes += sbomDesc.Size
+	}
+
 	store, err := ocistore.NewWithContext(op.ctx, op.dst)
 	if err != nil {
 		return nil, err
@@ -193,10 +262,14 @@ func (op *ociProvider) LoadBundle(_ int) (PathMap, error) {
 	if err != nil {
 		return nil, err
 	}
+	// a tag could have been moved to another bundle since its metadata was verified
+	if rootDesc.Digest != op.rootDesc.Digest {
+		return nil, fmt.Errorf("%s changed from %s to %s while it was being pulled", op.src, op.rootDesc.Digest, rootDesc.Digest)
+	}
 	layersToPull = append(layersToPull, rootDesc)
 
 	// copy bundle
-	copyOpts := utils.CreateCopyOpts(layersToPull, config.CommonOptions.OCIConcurrency)
+	copyOpts := utils.CreateCopyOpts(layersToPull, concurrency)
 
 	// Create a thread to update a progress bar as we save the package to disk
 	doneSaving := make(chan int)
@@ -221,7 +294,7 @@ func (op *ociProvider) LoadBundle(_ int) (PathMap, error) {
 	return loaded, nil
 }
 
-func (op *ociProvider) PublishBundle(_ types.UDSBundle, _ *oci.OrasRemote) error {
+func (op *ociProvider) PublishBundle(_ types.UDSBundle, _ *oci.OrasRemote, _ bool) error {
 	// todo: implement moving bundles from one registry to another
 	return fmt.Errorf("moving bundles in between remote registries not yet supported")
 }
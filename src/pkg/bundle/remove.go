@@ -14,6 +14,7 @@ import (
 
 	"github.com/defenseunicorns/uds-cli/src/config"
 	"github.com/defenseunicorns/uds-cli/src/pkg/sources"
+	udsTypes "github.com/defenseunicorns/uds-cli/src/types"
 )
 
 // Remove removes packages deployed from a bundle
@@ -36,9 +37,14 @@ func (b *Bundler) Remove() error {
 		return err
 	}
 
-	// remove in reverse order
-	for i := len(b.bundle.ZarfPackages) - 1; i >= 0; i-- {
-		pkg := b.bundle.ZarfPackages[i]
+	order, err := deployOrder(b.bundle.ZarfPackages)
+	if err != nil {
+		return err
+	}
+
+	// remove in reverse deploy order
+	for i := len(order) - 1; i >= 0; i-- {
+		pkg := b.bundle.ZarfPackages[order[i]]
 		if err != nil {
 			return err
 		}
@@ -55,7 +61,7 @@ func (b *Bundler) Remove() error {
 		}
 
 		sha := strings.Split(pkg.Ref, "sha256:")[1]
-		source, err := sources.New(b.cfg.RemoveOpts.Source, pkg.Name, opts, sha)
+		source, err := sources.New(context.TODO(), b.cfg.RemoveOpts.Source, pkg.Name, opts, sha, udsTypes.SignaturePolicy{})
 		if err != nil {
 			return err
 		}
@@ -0,0 +1,52 @@
+package bundle
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sigstore/cosign/v2/pkg/cosign"
+	"github.com/stretchr/testify/require"
+
+	"github.com/defenseunicorns/uds-cli/src/types"
+)
+
+func Test_signBundleYAML(t *testing.T) {
+	tmp := t.TempDir()
+	password := "password"
+	keys, err := cosign.GenerateKeyPair(func(_ bool) ([]byte, error) { return []byte(password), nil })
+	require.NoError(t, err)
+	privateKeyPath := filepath.Join(tmp, "cosign.key")
+	publicKeyPath := filepath.Join(tmp, "cosign.pub")
+	require.NoError(t, os.WriteFile(privateKeyPath, keys.PrivateBytes, 0600))
+	require.NoError(t, os.WriteFile(publicKeyPath, keys.PublicBytes, 0600))
+
+	bundleYAML := []byte("kind: UDSBundle\nmetadata:\n  name: signed\n")
+	signature, err := signBundleYAML(bundleYAML, privateKeyPath, password)
+	require.NoError(t, err)
+
+	bundleYAMLPath := filepath.Join(tmp, "uds-bundle.yaml")
+	signaturePath := filepath.Join(tmp, "uds-bundle.yaml.sig")
+	require.NoError(t, os.WriteFile(signaturePath, signature, 0600))
+
+	// the signature covers the exact bytes that were signed
+	require.NoError(t, os.WriteFile(bundleYAMLPath, bundleYAML, 0600))
+	require.NoError(t, ValidateBundleSignature(bundleYAMLPath, signaturePath, types.SignaturePolicy{PublicKeyPath: publicKeyPath}))
+
+	// a signed bundle can be used without a key, with a warning, unless the policy is strict
+	require.NoError(t, ValidateBundleSignature(bundleYAMLPath, signaturePath, types.SignaturePolicy{}))
+	require.Error(t, ValidateBundleSignature(bundleYAMLPath, signaturePath, types.SignaturePolicy{StrictSignatureValidation: true}))
+	require.NoError(t, ValidateBundleSignature(bundleYAMLPath, signaturePath, types.SignaturePolicy{SkipSignatureValidation: true}))
+
+	// an unsigned bundle can't be validated with a key
+	require.NoError(t, ValidateBundleSignature(bundleYAMLPath, "", types.SignaturePolicy{StrictSignatureValidation: true}))
+	require.Error(t, ValidateBundleSignature(bundleYAMLPath, "", types.SignaturePolicy{PublicKeyPath: publicKeyPath}))
+
+	require.NoError(t, os.WriteFile(bundleYAMLPath, []byte("kind: UDSBundle\nmetadata:\n  name: tampered\n"), 0600))
+	require.Error(t, ValidateBundleSignature(bundleYAMLPath, signaturePath, types.SignaturePolicy{PublicKeyPath: publicKeyPath}))
+	// skipping validation accepts a tampered bundle
+	require.NoError(t, ValidateBundleSignature(bundleYAMLPath, signaturePath, types.SignaturePolicy{SkipSignatureValidation: true}))
+
+	_, err = signBundleYAML(bundleYAML, privateKeyPath, "wrong")
+	require.Error(t, err)
+}
@@ -41,6 +41,18 @@ func (tp *tarballBundleProvider) CreateBundleSBOM(extractSBOM bool) error {
 	if err != nil {
 		return err
 	}
+	// use the bundle-level SBOM aggregated when the bundle was created
+	if sbomDesc := tp.manifest.Locate(config.BundleSBOMTar); !oci.IsEmptyDescriptor(sbomDesc) {
+		sbomFilePath := filepath.Join(config.BlobsDir, sbomDesc.Digest.Encoded())
+		if err := av3.Extract(tp.src, sbomFilePath, tp.dst); err != nil {
+			return fmt.Errorf("failed to extract %s from %s: %w", config.BundleSBOMTar, tp.src, err)
+		}
+		sbomTar, err := os.ReadFile(filepath.Join(tp.dst, sbomFilePath))
+		if err != nil {
+			return err
+		}
+		return utils.OutputBundleSBOM(sbomTar, extractSBOM)
+	}
 	// make tmp dir for pkg SBOM extraction
 	err = os.Mkdir(filepath.Join(tp.dst, config.BundleSBOM), 0700)
 	if err != nil {
@@ -70,6 +82,10 @@ func (tp *tarballBundleProvider) CreateBundleSBOM(extractSBOM bool) error {
 
 		// find sbom layer descriptor and extract sbom tar from archive
 		sbomDesc := zarfImageManifest.Locate(config.SBOMsTar)
+		if oci.IsEmptyDescriptor(sbomDesc) {
+			message.Debugf("%s not found in Zarf pkg", config.SBOMsTar)
+			continue
+		}
 		sbomFilePath := filepath.Join(config.BlobsDir, sbomDesc.Digest.Encoded())
 		if err := av3.Extract(tp.src, sbomFilePath, tp.dst); err != nil {
 			return fmt.Errorf("failed to extract %s from %s: %w", layer.Digest.Encoded(), tp.src, err)
@@ -163,6 +179,41 @@ func (tp *tarballBundleProvider) getBundleManifest() error {
 	return nil
 }
 
+func (tp *tarballBundleProvider) getBundleManifestDesc() (ocispec.Descriptor, error) {
+	if err := tp.getBundleManifest(); err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	return tp.manifestDesc, nil
+}
+
+func (tp *tarballBundleProvider) getPackageManifest(sha string) (*oci.ZarfOCIManifest, error) {
+	if err := tp.getBundleManifest(); err != nil {
+		return nil, err
+	}
+	manifestDesc := tp.manifest.Locate(sha)
+	if oci.IsEmptyDescriptor(manifestDesc) {
+		return nil, fmt.Errorf("zarf package with manifest sha %s not found in %s", sha, tp.src)
+	}
+	manifestRelativePath := filepath.Join(config.BlobsDir, sha)
+	if err := av3.Extract(tp.src, manifestRelativePath, tp.dst); err != nil {
+		return nil, fmt.Errorf("failed to extract %s from %s: %w", sha, tp.src, err)
+	}
+	manifestPath := filepath.Join(tp.dst, manifestRelativePath)
+	defer os.Remove(manifestPath)
+	if err := zarfUtils.SHAsMatch(manifestPath, sha); err != nil {
+		return nil, err
+	}
+	b, err := os.ReadFile(manifestPath)
+	if err != nil {
+		return nil, err
+	}
+	var manifest *oci.ZarfOCIManifest
+	if err := json.Unmarshal(b, &manifest); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
 // LoadBundle loads a bundle from a tarball
 func (tp *tarballBundleProvider) LoadBundle(_ int) (PathMap, error) {
 	loaded := make(PathMap)
@@ -288,7 +339,7 @@ func (tp *tarballBundleProvider) getZarfLayers(store *ocistore.Store, pkgManifes
 	return layersToPull, estimatedPkgSize, nil
 }
 
-func (tp *tarballBundleProvider) PublishBundle(bundle types.UDSBundle, remote *oci.OrasRemote) error {
+func (tp *tarballBundleProvider) PublishBundle(bundle types.UDSBundle, remote *oci.OrasRemote, sizeOnly bool) error {
 	var layersToPull []ocispec.Descriptor
 	if err := tp.getBundleManifest(); err != nil {
 		return err
@@ -320,6 +371,11 @@ func (tp *tarballBundleProvider) PublishBundle(bundle types.UDSBundle, remote *o
 	// grab image config
 	layersToPull = append(layersToPull, tp.manifest.Config)
 
+	message.Infof("Bundle size: %s", zarfUtils.ByteFormat(float64(bundleSize(layersToPull)), 2))
+	if sizeOnly {
+		return nil
+	}
+
 	// copy bundle
 	copyOpts := utils.CreateCopyOpts(layersToPull, config.CommonOptions.OCIConcurrency)
 	if err != nil {
@@ -328,11 +384,21 @@ func (tp *tarballBundleProvider) PublishBundle(bundle types.UDSBundle, remote *o
 	remote.Transport.ProgressBar = message.NewProgressBar(estimatedBytes, fmt.Sprintf("Publishing %s:%s", remote.Repo().Reference.Repository, remote.Repo().Reference.Reference))
 	defer remote.Transport.ProgressBar.Stop()
 	ref := fmt.Sprintf("%s-%s", bundle.Metadata.Version, bundle.Metadata.Architecture)
-	_, err = oras.Copy(tp.ctx, store, ref, remote.Repo(), ref, copyOpts)
+	// oras.Copy skips blobs that already exist in the remote, so a retry resumes an interrupted publish
+	var rootDesc ocispec.Descriptor
+	err = utils.RetryOCI(fmt.Sprintf("publishing %s", remote.Repo().Reference), func() error {
+		rootDesc, err = oras.Copy(tp.ctx, store, ref, remote.Repo(), ref, copyOpts)
+		return err
+	})
 	if err != nil {
 		return err
 	}
 	remote.Transport.ProgressBar.Successf("Published %s", remote.Repo().Reference)
 
+	// add the bundle to the multi-arch index tagged with the bundle's version
+	if _, err := utils.PushToIndex(remote, bundle.Metadata.Version, rootDesc, bundle.Metadata.Architecture); err != nil {
+		return err
+	}
+
 	return nil
 }
@@ -0,0 +1,200 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023-Present The UDS Authors
+
+// Package bundle contains functions for interacting with, managing and deploying UDS packages
+package bundle
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/defenseunicorns/zarf/src/pkg/message"
+	"github.com/defenseunicorns/zarf/src/pkg/oci"
+	zarfUtils "github.com/defenseunicorns/zarf/src/pkg/utils"
+	"github.com/defenseunicorns/zarf/src/pkg/utils/helpers"
+	zarfTypes "github.com/defenseunicorns/zarf/src/types"
+	goyaml "github.com/goccy/go-yaml"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/errdef"
+
+	"github.com/defenseunicorns/uds-cli/src/config"
+	"github.com/defenseunicorns/uds-cli/src/pkg/utils"
+	"github.com/defenseunicorns/uds-cli/src/types"
+)
+
+// layerStore is where Verify looks up the layers of a bundle's packages
+type layerStore interface {
+	// resolve returns the descriptor of a blob in the registry, or an error wrapping errdef.ErrNotFound
+	resolve(ctx context.Context, desc ocispec.Descriptor) (ocispec.Descriptor, error)
+	// fetch returns the content of a blob in the registry
+	fetch(ctx context.Context, desc ocispec.Descriptor) ([]byte, error)
+}
+
+// remoteLayerStore looks up layers in the repository of a remote bundle
+type remoteLayerStore struct {
+	remote *oci.OrasRemote
+}
+
+func (s remoteLayerStore) resolve(ctx context.Context, desc ocispec.Descriptor) (resolved ocispec.Descriptor, err error) {
+	err = utils.RetryOCI(fmt.Sprintf("checking layer %s", desc.Digest.Encoded()), func() error {
+		resolved, err = s.remote.Repo().Blobs().Resolve(ctx, desc.Digest.String())
+		return err
+	})
+	return resolved, err
+}
+
+func (s remoteLayerStore) fetch(_ context.Context, desc ocispec.Descriptor) (content []byte, err error) {
+	err = utils.RetryOCI(fmt.Sprintf("fetching layer %s", desc.Digest.Encoded()), func() error {
+		content, err = s.remote.FetchLayer(desc)
+		return err
+	})
+	return content, err
+}
+
+// Verify checks that a bundle in an OCI registry is complete without pulling or deploying it: that the manifest of
+// each of its packages exists, that every layer the package has in the bundle exists in the registry with the size its
+// manifest expects, and that the package's checksums.txt matches its layers
+func (b *Bundler) Verify() error {
+	source := b.cfg.VerifyOpts.Source
+	if !helpers.IsOCIURL(source) {
+		return fmt.Errorf("only bundles in an OCI registry can be verified, %s is not an OCI ref", source)
+	}
+	provider, err := NewBundleProvider(context.TODO(), source, b.tmp)
+	if err != nil {
+		return err
+	}
+	op := provider.(*ociProvider)
+
+	loaded, err := provider.LoadBundleMetadata()
+	if err != nil {
+		return err
+	}
+	if err := ValidateBundleSignature(loaded[config.BundleYAML], loaded[config.BundleYAMLSignature], b.cfg.VerifyOpts.SignaturePolicy); err != nil {
+		return err
+	}
+	if err := zarfUtils.ReadYaml(loaded[config.BundleYAML], &b.bundle); err != nil {
+		return err
+	}
+
+	store := remoteLayerStore{remote: op.OrasRemote}
+	var incomplete []string
+	for _, pkg := range b.bundle.ZarfPackages {
+		spinner := message.NewProgressSpinner("Verifying package %s", pkg.Name)
+		problems := b.verifyPackage(op, store, pkg)
+		if len(problems) == 0 {
+			spinner.Successf("Verified package %s", pkg.Name)
+			continue
+		}
+		spinner.Stop()
+		for _, problem := range problems {
+			message.Warnf("Package %s: %s", pkg.Name, problem)
+		}
+		incomplete = append(incomplete, pkg.Name)
+	}
+
+	if len(incomplete) > 0 {
+		return fmt.Errorf("bundle %s is incomplete, found problems in package(s) %s", source, strings.Join(incomplete, ", "))
+	}
+	message.Successf("Bundle %s is complete", source)
+	return nil
+}
+
+// verifyPackage returns the problems found with a package of a remote bundle
+func (b *Bundler) verifyPackage(op *ociProvider, store layerStore, pkg types.BundleZarfPackage) []string {
+	_, sha, ok := strings.Cut(pkg.Ref, "@sha256:")
+	if !ok {
+		return []string{fmt.Sprintf("ref %s isn't pinned to a manifest digest", pkg.Ref)}
+	}
+	manifest, err := op.getPackageManifest(sha)
+	if err != nil {
+		return []string{fmt.Sprintf("unable to fetch the package's manifest: %s", err)}
+	}
+
+	// only the layers recorded when the bundle was created are expected, as the layers of optional components that
+	// weren't selected aren't in the bundle. Bundles created without them are expected to have every layer
+	expected := manifest.Layers
+	if digests, ok := b.bundle.Build.PackageLayers[pkg.Name]; ok {
+		expected = utils.LayersByDigest(manifest.Layers, digests)
+	}
+	return verifyPackageLayers(op.ctx, store, manifest, expected)
+}
+
+// verifyPackageLayers returns the problems found with the expected layers of a Zarf package's manifest: layers that
+// are missing from (or have another size in) the store, and layers that don't match the package's checksums.txt
+func verifyPackageLayers(ctx context.Context, store layerStore, manifest *oci.ZarfOCIManifest, expected []ocispec.Descriptor) []string {
+	var problems []string
+	for _, layer := range expected {
+		resolved, err := store.resolve(ctx, layer)
+		switch {
+		case errors.Is(err, errdef.ErrNotFound):
+			problems = append(problems, fmt.Sprintf("layer %s is missing", layerName(layer)))
+		case err != nil:
+			problems = append(problems, fmt.Sprintf("unable to check layer %s: %s", layerName(layer), err))
+		case resolved.Size != layer.Size:
+			problems = append(problems, fmt.Sprintf("layer %s is %d bytes but its manifest expects %d bytes",
+				layerName(layer), resolved.Size, layer.Size))
+		}
+	}
+	if len(problems) > 0 {
+		return problems
+	}
+
+	// fetch the package's zarf.yaml and checksums.txt to check its layers against
+	var pkg zarfTypes.ZarfPackage
+	var checksums []byte
+	for _, path := range []string{config.ZarfYAML, config.ChecksumsTxt} {
+		layer := manifest.Locate(path)
+		if oci.IsEmptyDescriptor(layer) {
+			return append(problems, fmt.Sprintf("the package has no %s", path))
+		}
+		content, err := store.fetch(ctx, layer)
+		if err != nil {
+			return append(problems, fmt.Sprintf("unable to fetch %s: %s", path, err))
+		}
+		if path == config.ChecksumsTxt {
+			checksums = content
+		} else if err := goyaml.Unmarshal(content, &pkg); err != nil {
+			return append(problems, fmt.Sprintf("unable to read %s: %s", path, err))
+		}
+	}
+
+	sum := sha256.Sum256(checksums)
+	if hex.EncodeToString(sum[:]) != pkg.Metadata.AggregateChecksum {
+		problems = append(problems, fmt.Sprintf("%s doesn't match the aggregate checksum in %s", config.ChecksumsTxt, config.ZarfYAML))
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(checksums))
+	for scanner.Scan() {
+		checksum, path, ok := strings.Cut(scanner.Text(), " ")
+		if !ok {
+			problems = append(problems, fmt.Sprintf("invalid %s line: %s", config.ChecksumsTxt, scanner.Text()))
+			continue
+		}
+		layer := manifest.Locate(path)
+		switch {
+		case oci.IsEmptyDescriptor(layer):
+			problems = append(problems, fmt.Sprintf("%s is in %s but isn't a layer of the package", path, config.ChecksumsTxt))
+		case !slices.ContainsFunc(expected, func(desc ocispec.Descriptor) bool { return desc.Digest == layer.Digest }):
+			// the layer of a component that isn't in the bundle
+		case layer.Digest.Encoded() != checksum:
+			problems = append(problems, fmt.Sprintf("layer %s has digest %s but %s expects sha256:%s",
+				path, layer.Digest, config.ChecksumsTxt, checksum))
+		}
+	}
+	return problems
+}
+
+// layerName returns the title of a layer (or its digest when it has none) for problems
+func layerName(layer ocispec.Descriptor) string {
+	if title := layer.Annotations[ocispec.AnnotationTitle]; title != "" {
+		return fmt.Sprintf("%s (%s)", title, layer.Digest)
+	}
+	return layer.Digest.String()
+}
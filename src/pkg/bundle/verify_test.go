@@ -0,0 +1,91 @@
+package bundle
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	"github.com/defenseunicorns/zarf/src/pkg/oci"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/require"
+	"oras.land/oras-go/v2/errdef"
+
+	"github.com/defenseunicorns/uds-cli/src/config"
+)
+
+// memoryLayerStore is a layerStore of blobs by digest
+type memoryLayerStore map[digest.Digest][]byte
+
+func (s memoryLayerStore) resolve(_ context.Context, desc ocispec.Descriptor) (ocispec.Descriptor, error) {
+	content, ok := s[desc.Digest]
+	if !ok {
+		return ocispec.Descriptor{}, fmt.Errorf("%s: %w", desc.Digest, errdef.ErrNotFound)
+	}
+	return ocispec.Descriptor{Digest: desc.Digest, Size: int64(len(content))}, nil
+}
+
+func (s memoryLayerStore) fetch(ctx context.Context, desc ocispec.Descriptor) ([]byte, error) {
+	if _, err := s.resolve(ctx, desc); err != nil {
+		return nil, err
+	}
+	return s[desc.Digest], nil
+}
+
+func Test_verifyPackageLayers(t *testing.T) {
+	layer := func(title string, content []byte) ocispec.Descriptor {
+		return ocispec.Descriptor{
+			Digest:      digest.FromBytes(content),
+			Size:        int64(len(content)),
+			Annotations: map[string]string{ocispec.AnnotationTitle: title},
+		}
+	}
+	required, optional := []byte("required component"), []byte("optional component")
+	checksums := []byte(fmt.Sprintf("%s components/required.tar\n%s components/optional.tar\n",
+		digest.FromBytes(required).Encoded(), digest.FromBytes(optional).Encoded()))
+	sum := sha256.Sum256(checksums)
+	zarfYAML := []byte("metadata:\n  name: example\n  aggregateChecksum: " + hex.EncodeToString(sum[:]) + "\n")
+
+	manifest := &oci.ZarfOCIManifest{Manifest: ocispec.Manifest{Layers: []ocispec.Descriptor{
+		layer(config.ZarfYAML, zarfYAML),
+		layer(config.ChecksumsTxt, checksums),
+		layer("components/required.tar", required),
+		layer("components/optional.tar", optional),
+	}}}
+	// the optional component wasn't selected, so it isn't in the bundle
+	expected := manifest.Layers[:3]
+	newStore := func() memoryLayerStore {
+		store := memoryLayerStore{}
+		for _, content := range [][]byte{zarfYAML, checksums, required} {
+			store[digest.FromBytes(content)] = content
+		}
+		return store
+	}
+	ctx := context.Background()
+
+	require.Empty(t, verifyPackageLayers(ctx, newStore(), manifest, expected))
+
+	// every layer is expected by a bundle created without recorded layers
+	require.Equal(t, []string{
+		fmt.Sprintf("layer components/optional.tar (%s) is missing", digest.FromBytes(optional)),
+	}, verifyPackageLayers(ctx, newStore(), manifest, manifest.Layers))
+
+	// a layer that was only partially pushed
+	store := newStore()
+	store[digest.FromBytes(required)] = required[:4]
+	require.Equal(t, []string{
+		fmt.Sprintf("layer components/required.tar (%s) is 4 bytes but its manifest expects %d bytes", digest.FromBytes(required), len(required)),
+	}, verifyPackageLayers(ctx, store, manifest, expected))
+
+	// a layer that doesn't match checksums.txt
+	replaced := []byte("replaced component")
+	tampered := &oci.ZarfOCIManifest{Manifest: ocispec.Manifest{Layers: append(manifest.Layers[:2:2],
+		layer("components/required.tar", replaced), manifest.Layers[3])}}
+	store = newStore()
+	store[digest.FromBytes(replaced)] = replaced
+	require.Equal(t, []string{
+		fmt.Sprintf("layer components/required.tar has digest %s but checksums.txt expects %s", digest.FromBytes(replaced), digest.FromBytes(required)),
+	}, verifyPackageLayers(ctx, store, tampered, tampered.Layers[:3]))
+}
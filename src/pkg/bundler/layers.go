@@ -0,0 +1,51 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023-Present The UDS Authors
+
+// Package bundler defines behavior for bundling packages
+package bundler
+
+import (
+	"sync"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// LayerTracker tracks the layers added to a bundle, so that layers shared by several of its Zarf pkgs (ie. the same
+// base image) are only pushed once; a nil LayerTracker tracks nothing
+type LayerTracker struct {
+	mu           sync.Mutex
+	added        map[digest.Digest]bool
+	deduplicated int
+}
+
+// NewLayerTracker creates a LayerTracker for a single bundle
+func NewLayerTracker() *LayerTracker {
+	return &LayerTracker{added: map[digest.Digest]bool{}}
+}
+
+// Add records that layer is being added to the bundle, returning false (and counting the layer as deduplicated) if
+// another Zarf pkg already added it
+func (t *LayerTracker) Add(layer ocispec.Descriptor) bool {
+	if t == nil {
+		return true
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.added[layer.Digest] {
+		t.deduplicated++
+		return false
+	}
+	t.added[layer.Digest] = true
+	return true
+}
+
+// Deduplicated returns the number of times a layer wasn't added to the bundle because it already was
+func (t *LayerTracker) Deduplicated() int {
+	if t == nil {
+		return 0
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.deduplicated
+}
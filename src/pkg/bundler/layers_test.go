@@ -0,0 +1,27 @@
+package bundler
+
+import (
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_LayerTracker(t *testing.T) {
+	base := ocispec.Descriptor{Digest: digest.FromString("base image layer")}
+	app := ocispec.Descriptor{Digest: digest.FromString("app layer")}
+
+	layers := NewLayerTracker()
+	require.True(t, layers.Add(base))
+	require.True(t, layers.Add(app))
+	// the same base image layer in another package
+	require.False(t, layers.Add(base))
+	require.False(t, layers.Add(base))
+	require.Equal(t, 2, layers.Deduplicated())
+
+	var untracked *LayerTracker
+	require.True(t, untracked.Add(base))
+	require.True(t, untracked.Add(base))
+	require.Equal(t, 0, untracked.Deduplicated())
+}
@@ -0,0 +1,24 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023-Present The UDS Authors
+
+// Package bundler defines behavior for bundling packages
+package bundler
+
+// Progress reports the progress of a step of bundling, a *message.Spinner is the Progress used by the CLI
+type Progress interface {
+	Updatef(format string, a ...any)
+	Successf(format string, a ...any)
+	Stop()
+}
+
+// NoProgress is a Progress that reports nothing
+type NoProgress struct{}
+
+// Updatef does nothing
+func (NoProgress) Updatef(string, ...any) {}
+
+// Successf does nothing
+func (NoProgress) Successf(string, ...any) {}
+
+// Stop does nothing
+func (NoProgress) Stop() {}
@@ -6,6 +6,7 @@ package bundler
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"path/filepath"
@@ -18,6 +19,7 @@ import (
 	zarfTypes "github.com/defenseunicorns/zarf/src/types"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
 	ocistore "oras.land/oras-go/v2/content/oci"
 
 	"github.com/defenseunicorns/uds-cli/src/config"
@@ -35,15 +37,26 @@ type RemoteBundler struct {
 	RemoteDst       *oci.OrasRemote
 	localDst        *ocistore.Store
 	tmpDir          string
+	layersToCopy    []ocispec.Descriptor
+	// Layers tracks the layers already added to the bundle by other Zarf pkgs
+	Layers *LayerTracker
+	// Quiet disables the bundler's own spinners and progress bars
+	Quiet bool
 }
 
 // NewRemoteBundler creates a bundler to pull remote Zarf pkgs
 // todo: document this fn better or break out into multiple constructors
 func NewRemoteBundler(pkg types.BundleZarfPackage, url string, localDst *ocistore.Store, remoteDst *oci.OrasRemote, tmpDir string) (RemoteBundler, error) {
-	src, err := oci.NewOrasRemote(url)
+	src, err := utils.NewOrasRemote(url)
 	if err != nil {
 		return RemoteBundler{}, err
 	}
+	// a ref pinned to a digest must resolve to a root manifest with that digest
+	if _, err := src.Repo().Reference.Digest(); err == nil {
+		if _, err := utils.VerifyRootDigest(src); err != nil {
+			return RemoteBundler{}, err
+		}
+	}
 	pkgRootManifest, err := src.FetchRoot()
 	if err != nil {
 		return RemoteBundler{}, err
@@ -56,7 +69,7 @@ func NewRemoteBundler(pkg types.BundleZarfPackage, url string, localDst *ocistor
 
 // GetMetadata grabs metadata from a remote Zarf package's zarf.yaml
 func (b *RemoteBundler) GetMetadata(url string, tmpDir string) (zarfTypes.ZarfPackage, error) {
-	remote, err := oci.NewOrasRemote(url)
+	remote, err := utils.NewOrasRemote(url)
 	if err != nil {
 		return zarfTypes.ZarfPackage{}, err
 	}
@@ -74,6 +87,48 @@ func (b *RemoteBundler) GetMetadata(url string, tmpDir string) (zarfTypes.ZarfPa
 	return zarfYAML, err
 }
 
+// SBOMs fetches the Zarf pkg's sboms.tar, returning nil if the package has no SBOMs
+func (b *RemoteBundler) SBOMs() ([]byte, error) {
+	sbomDesc := b.PkgRootManifest.Locate(config.SBOMsTar)
+	if oci.IsEmptyDescriptor(sbomDesc) {
+		return nil, nil
+	}
+	// use the copy of sboms.tar that was already pulled into the bundle when possible
+	if b.localDst != nil {
+		if exists, _ := b.localDst.Exists(b.ctx, sbomDesc); exists {
+			return content.FetchAll(b.ctx, b.localDst, sbomDesc)
+		}
+	}
+	return b.RemoteSrc.FetchLayer(sbomDesc)
+}
+
+// LayersToPush returns the descriptors of the Zarf pkg's manifest and of the layers required by its components
+func (b *RemoteBundler) LayersToPush() ([]ocispec.Descriptor, error) {
+	layersToCopy, err := b.getLayersToCopy()
+	if err != nil {
+		return nil, err
+	}
+	manifestBytes, err := json.Marshal(b.PkgRootManifest)
+	if err != nil {
+		return nil, err
+	}
+	manifestDesc := content.NewDescriptorFromBytes(oci.ZarfLayerMediaTypeBlob, manifestBytes)
+	return append([]ocispec.Descriptor{manifestDesc}, layersToCopy...), nil
+}
+
+// getLayersToCopy grabs (once) the layers that are required by the Zarf pkg's components
+func (b *RemoteBundler) getLayersToCopy() ([]ocispec.Descriptor, error) {
+	if b.layersToCopy != nil {
+		return b.layersToCopy, nil
+	}
+	layersToCopy, err := getZarfLayers(b.RemoteSrc, b.pkg, b.PkgRootManifest)
+	if err != nil {
+		return nil, err
+	}
+	b.layersToCopy = layersToCopy
+	return layersToCopy, nil
+}
+
 // PushManifest pushes the Zarf pkg's manifest to either a local or remote bundle
 func (b *RemoteBundler) PushManifest() (ocispec.Descriptor, error) {
 	var zarfManifestDesc ocispec.Descriptor
@@ -94,10 +149,10 @@ func (b *RemoteBundler) PushManifest() (ocispec.Descriptor, error) {
 }
 
 // LayersToBundle pushes a remote Zarf pkg's layers to either a local or remote bundle
-func (b *RemoteBundler) LayersToBundle(spinner *message.Spinner, currentPackageIter int, totalPackages int) ([]ocispec.Descriptor, error) {
+func (b *RemoteBundler) LayersToBundle(spinner Progress, currentPackageIter int, totalPackages int) ([]ocispec.Descriptor, error) {
 	spinner.Updatef("Fetching %s package layer metadata (package %d of %d)", b.pkg.Name, currentPackageIter, totalPackages)
 	// get only the layers that are required by the components
-	layersToCopy, err := getZarfLayers(b.RemoteSrc, b.pkg, b.PkgRootManifest)
+	layersToCopy, err := b.getLayersToCopy()
 	if err != nil {
 		return nil, err
 	}
@@ -126,31 +181,60 @@ func (b *RemoteBundler) remoteToRemote(layersToCopy []ocispec.Descriptor) error
 	if srcRef.Registry != dstRef.Registry {
 		message.Debugf("Streaming layers from %s --> %s", srcRef, dstRef)
 
+		// skip the layers that another Zarf pkg in the bundle already copied
+		var newLayers []ocispec.Descriptor
+		for _, layer := range layersToCopy {
+			if b.Layers.Add(layer) {
+				newLayers = append(newLayers, layer)
+			}
+		}
+
 		// filterLayers returns true if the layer is in the list of layers to copy, this allows for
 		// copying only the layers that are required by the required + specified optional components
 		filterLayers := func(d ocispec.Descriptor) bool {
-			for _, layer := range layersToCopy {
+			for _, layer := range newLayers {
 				if layer.Digest == d.Digest {
 					return true
 				}
 			}
 			return false
 		}
-		if err := oci.CopyPackage(b.ctx, b.RemoteSrc, b.RemoteDst, filterLayers, config.CommonOptions.OCIConcurrency); err != nil {
+		// CopyPackage skips layers that already exist in the destination, so a retry resumes where the last attempt stopped
+		if err := utils.RetryOCI(fmt.Sprintf("copying %s", srcRef), func() error {
+			return oci.CopyPackage(b.ctx, b.RemoteSrc, b.RemoteDst, filterLayers, config.CommonOptions.OCIConcurrency)
+		}); err != nil {
 			return err
 		}
 	} else {
 		// blob mount if same registry
 		message.Debugf("Performing a cross repository blob mount on %s from %s --> %s", dstRef, dstRef.Repository, dstRef.Repository)
-		spinner := message.NewProgressSpinner("Mounting layers from %s", srcRef.Repository)
+		var spinner Progress = NoProgress{}
+		if !b.Quiet {
+			spinner = message.NewProgressSpinner("Mounting layers from %s", srcRef.Repository)
+		}
 		layersToCopy = append(layersToCopy, b.PkgRootManifest.Config)
 		for _, layer := range layersToCopy {
 			if layer.Digest == "" {
 				continue
 			}
+			if !b.Layers.Add(layer) {
+				message.Debugf("Layer %s was already mounted by another package, skipping", layer.Digest.Encoded())
+				continue
+			}
+			// skip layers already in the destination (ie. from an interrupted publish)
+			exists, err := b.RemoteDst.Repo().Blobs().Exists(b.ctx, layer)
+			if err != nil {
+				return err
+			}
+			if exists {
+				message.Debugf("Layer %s already exists in %s, skipping", layer.Digest.Encoded(), dstRef)
+				continue
+			}
 			spinner.Updatef("Mounting %s", layer.Digest.Encoded())
-			if err := b.RemoteDst.Repo().Mount(b.ctx, layer, srcRef.Repository, func() (io.ReadCloser, error) {
-				return b.RemoteSrc.Repo().Fetch(b.ctx, layer)
+			if err := utils.RetryOCI(fmt.Sprintf("mounting %s", layer.Digest.Encoded()), func() error {
+				return b.RemoteDst.Repo().Mount(b.ctx, layer, srcRef.Repository, func() (io.ReadCloser, error) {
+					return b.RemoteSrc.Repo().Fetch(b.ctx, layer)
+				})
 			}); err != nil {
 				return err
 			}
@@ -166,21 +250,26 @@ func (b *RemoteBundler) remoteToLocal(layersToCopy []ocispec.Descriptor) ([]ocis
 	var layerDescsToArchive []ocispec.Descriptor
 	var layersToPull []ocispec.Descriptor
 	estimatedBytes := int64(0)
+	cacheHits := 0
 	// grab descriptors of layers to copy
 	for _, layer := range layersToCopy {
 		if layer.Digest == "" {
 			continue
 		}
-		// check if layer already exists
+		// check if layer already exists (ie. another Zarf pkg in the bundle already pulled it)
+		if !b.Layers.Add(layer) {
+			continue
+		}
 		if exists, _ := b.localDst.Exists(b.ctx, layer); exists {
 			continue
 		} else if cache.Exists(layer.Digest.Encoded()) {
-			err := cache.Use(layer.Digest.Encoded(), filepath.Join(b.tmpDir, config.BlobsDir))
-			if err != nil {
-				return nil, err
+			// the layer may have been pruned from the cache since, in which case it is pulled
+			if err := cache.Use(layer.Digest.Encoded(), filepath.Join(b.tmpDir, config.BlobsDir)); err == nil {
+				layerDescsToArchive = append(layerDescsToArchive, layer)
+				cacheHits++
+				continue
 			}
-			layerDescsToArchive = append(layerDescsToArchive, layer)
-			continue
+			message.Debugf("Unable to use cached layer %s, pulling it", layer.Digest.Encoded())
 		}
 		// grab layer to pull from OCI
 		if layer.MediaType != ocispec.MediaTypeImageManifest {
@@ -199,14 +288,21 @@ func (b *RemoteBundler) remoteToLocal(layersToCopy []ocispec.Descriptor) ([]ocis
 		doneSaving := make(chan int)
 		errChan := make(chan int)
 		var wg sync.WaitGroup
-		wg.Add(1)
-		go zarfUtils.RenderProgressBarForLocalDirWrite(b.tmpDir, estimatedBytes, &wg, doneSaving, errChan, fmt.Sprintf("Pulling bundle: %s", b.pkg.Name), fmt.Sprintf("Successfully pulled bundle: %s", b.pkg.Name))
+		if !b.Quiet {
+			wg.Add(1)
+			progress := utils.NewPullProgress(b.tmpDir, estimatedBytes, cacheHits)
+			go progress.Render(&wg, doneSaving, errChan, fmt.Sprintf("Pulling bundle: %s", b.pkg.Name), fmt.Sprintf("Successfully pulled bundle: %s", b.pkg.Name))
+		}
 		rootPkgDesc, err := oras.Copy(context.TODO(), b.RemoteSrc.Repo(), b.RemoteSrc.Repo().Reference.String(), b.localDst, "", copyOpts)
 		if err != nil {
-			errChan <- 1
+			if !b.Quiet {
+				errChan <- 1
+			}
 			return nil, err
 		}
-		doneSaving <- 1
+		if !b.Quiet {
+			doneSaving <- 1
+		}
 		wg.Wait()
 
 		// grab pkg root manifest for archiving
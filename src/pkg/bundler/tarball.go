@@ -6,11 +6,14 @@ package bundler
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
 
+	"github.com/defenseunicorns/zarf/src/pkg/message"
 	"github.com/defenseunicorns/zarf/src/pkg/oci"
 	zarfUtils "github.com/defenseunicorns/zarf/src/pkg/utils"
 	zarfTypes "github.com/defenseunicorns/zarf/src/types"
@@ -19,6 +22,7 @@ import (
 	av4 "github.com/mholt/archiver/v4"
 	"github.com/opencontainers/image-spec/specs-go"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/content"
 	"oras.land/oras-go/v2/content/file"
 	ocistore "oras.land/oras-go/v2/content/oci"
 
@@ -31,6 +35,12 @@ type LocalBundler struct {
 	ctx          context.Context
 	tarballSrc   string
 	extractedDst string
+	pkgSrc       *file.Store
+	layers       []ocispec.Descriptor
+	config       []byte
+	manifest     []byte
+	// Layers tracks the layers already added to the bundle by other Zarf pkgs
+	Layers *LayerTracker
 }
 
 // NewLocalBundler creates a bundler for bundling local Zarf pkgs
@@ -102,17 +112,154 @@ func (b *LocalBundler) Load() (zarfTypes.ZarfPackage, error) {
 	return pkg, err
 }
 
+// SBOMs reads the extracted Zarf pkg's sboms.tar, returning nil if the package has no SBOMs
+func (b *LocalBundler) SBOMs() ([]byte, error) {
+	sbomTar, err := os.ReadFile(filepath.Join(b.extractedDst, config.SBOMsTar))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	return sbomTar, err
+}
+
 // ToBundle transfers a Zarf package to a given Bundle
 func (b *LocalBundler) ToBundle(bundleStore *ocistore.Store, pkg zarfTypes.ZarfPackage, artifactPathMap map[string]string, bundleTmpDir string, packageTmpDir string) (ocispec.Descriptor, error) {
 	// todo: only grab components that are required + specified in optional-components
 	ctx := b.ctx
-	src, err := file.New(packageTmpDir)
+	src, descs, err := addPackageLayers(ctx, packageTmpDir)
 	if err != nil {
 		return ocispec.Descriptor{}, err
 	}
+
+	for _, desc := range descs {
+		// push if layer doesn't already exist in bundleStore (ie. another Zarf pkg in the bundle already pushed it)
+		if b.Layers.Add(desc) {
+			exists, err := bundleStore.Exists(ctx, desc)
+			if err != nil {
+				return ocispec.Descriptor{}, err
+			}
+			if !exists {
+				if err := pushToStore(ctx, src, bundleStore, desc); err != nil {
+					return ocispec.Descriptor{}, err
+				}
+			}
+		}
+
+		digest := desc.Digest.Encoded()
+		artifactPathMap[filepath.Join(bundleTmpDir, config.BlobsDir, digest)] = filepath.Join(config.BlobsDir, digest)
+	}
+	// push the manifest config
+	manifestConfigDesc, err := pushZarfManifestConfigFromMetadata(bundleStore, &pkg.Metadata, &pkg.Build)
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	// push the manifest
+	rootManifest, err := generatePkgManifest(bundleStore, descs, manifestConfigDesc)
+
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	return rootManifest, err
+}
+
+// LayersToPush adds the extracted Zarf pkg's files to a file store and returns the descriptors of the pkg's manifest
+// (always first), config and layers as they will be pushed to a remote bundle
+func (b *LocalBundler) LayersToPush(pkg zarfTypes.ZarfPackage) ([]ocispec.Descriptor, error) {
+	if b.manifest == nil {
+		src, descs, err := addPackageLayers(b.ctx, b.extractedDst)
+		if err != nil {
+			return nil, err
+		}
+		// build the same manifest and config Zarf does when publishing a package, so the pkg looks like a remote one
+		configBytes, err := json.Marshal(zarfManifestConfigFromMetadata(&pkg.Metadata, &pkg.Build))
+		if err != nil {
+			return nil, err
+		}
+		manifest := ocispec.Manifest{
+			Versioned: specs.Versioned{
+				SchemaVersion: 2,
+			},
+			MediaType:   ocispec.MediaTypeImageManifest,
+			Config:      content.NewDescriptorFromBytes(oci.ZarfConfigMediaType, configBytes),
+			Layers:      descs,
+			Annotations: zarfManifestAnnotationsFromMetadata(&pkg.Metadata),
+		}
+		manifestBytes, err := json.Marshal(manifest)
+		if err != nil {
+			return nil, err
+		}
+		b.pkgSrc, b.layers, b.config, b.manifest = src, descs, configBytes, manifestBytes
+	}
+	manifestDesc := content.NewDescriptorFromBytes(oci.ZarfLayerMediaTypeBlob, b.manifest)
+	configDesc := content.NewDescriptorFromBytes(oci.ZarfConfigMediaType, b.config)
+	return append([]ocispec.Descriptor{manifestDesc, configDesc}, b.layers...), nil
+}
+
+// ToRemoteBundle pushes the Zarf pkg's layers, config and manifest to a remote bundle, skipping layers that already
+// exist in the remote; LayersToPush must be called first
+func (b *LocalBundler) ToRemoteBundle(remoteDst *oci.OrasRemote, spinner Progress) (ocispec.Descriptor, error) {
+	if b.manifest == nil {
+		return ocispec.Descriptor{}, fmt.Errorf("the layers of %s have not been loaded", b.tarballSrc)
+	}
+	for _, layer := range b.layers {
+		if !b.Layers.Add(layer) {
+			message.Debugf("Layer %s was already pushed by another package, skipping", layer.Digest.Encoded())
+			continue
+		}
+		exists, err := remoteDst.Repo().Blobs().Exists(b.ctx, layer)
+		if err != nil {
+			return ocispec.Descriptor{}, err
+		}
+		if exists {
+			message.Debugf("Layer %s already exists in %s, skipping", layer.Digest.Encoded(), remoteDst.Repo().Reference)
+			continue
+		}
+		spinner.Updatef("Pushing %s", layer.Annotations[ocispec.AnnotationTitle])
+		err = utils.RetryOCI(fmt.Sprintf("pushing %s", layer.Digest.Encoded()), func() error {
+			rc, err := b.pkgSrc.Fetch(b.ctx, layer)
+			if err != nil {
+				return err
+			}
+			defer rc.Close()
+			return remoteDst.Repo().Push(b.ctx, layer, rc)
+		})
+		if err != nil {
+			return ocispec.Descriptor{}, err
+		}
+	}
+	err := utils.RetryOCI("pushing manifest config", func() error {
+		_, err := remoteDst.PushLayer(b.config, oci.ZarfConfigMediaType)
+		return err
+	})
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	var manifestDesc ocispec.Descriptor
+	err = utils.RetryOCI("pushing manifest", func() error {
+		manifestDesc, err = remoteDst.PushLayer(b.manifest, oci.ZarfLayerMediaTypeBlob)
+		return err
+	})
+	return manifestDesc, err
+}
+
+// pushToStore copies a layer of an extracted Zarf pkg into the bundle's store
+func pushToStore(ctx context.Context, src *file.Store, bundleStore *ocistore.Store, desc ocispec.Descriptor) error {
+	layer, err := src.Fetch(ctx, desc)
+	if err != nil {
+		return err
+	}
+	defer layer.Close()
+	return bundleStore.Push(ctx, desc, layer)
+}
+
+// addPackageLayers adds every file of an extracted Zarf pkg to a file store and returns their descriptors
+func addPackageLayers(ctx context.Context, packageDir string) (*file.Store, []ocispec.Descriptor, error) {
+	src, err := file.New(packageDir)
+	if err != nil {
+		return nil, nil, err
+	}
 	// Grab Zarf layers
 	paths := []string{}
-	err = filepath.Walk(packageTmpDir, func(path string, info os.FileInfo, err error) error {
+	err = filepath.Walk(packageDir, func(path string, info os.FileInfo, err error) error {
 		// Catch any errors that happened during the walk
 		if err != nil {
 			return err
@@ -125,66 +272,65 @@ func (b *LocalBundler) ToBundle(bundleStore *ocistore.Store, pkg zarfTypes.ZarfP
 		return nil
 	})
 	if err != nil {
-		return ocispec.Descriptor{}, fmt.Errorf("unable to get the layers in the package to publish: %w", err)
+		return nil, nil, fmt.Errorf("unable to get the layers in the package to publish: %w", err)
 	}
 
 	var descs []ocispec.Descriptor
 	for _, path := range paths {
-		name, err := filepath.Rel(packageTmpDir, path)
+		name, err := filepath.Rel(packageDir, path)
 		if err != nil {
-			return ocispec.Descriptor{}, err
+			return nil, nil, err
 		}
 
 		mediaType := oci.ZarfLayerMediaTypeBlob
 
-		// get descriptor, push bytes
+		// get descriptor
 		desc, err := src.Add(ctx, name, mediaType, path)
 		if err != nil {
-			return ocispec.Descriptor{}, err
-		}
-		layer, err := src.Fetch(ctx, desc)
-		if err != nil {
-			return ocispec.Descriptor{}, err
-		}
-
-		// push if layer doesn't already exist in bundleStore
-		if exists, err := bundleStore.Exists(ctx, desc); !exists && err == nil {
-			if err := bundleStore.Push(ctx, desc, layer); err != nil {
-				return ocispec.Descriptor{}, err
-			}
-		} else if err != nil {
-			return ocispec.Descriptor{}, err
+			return nil, nil, err
 		}
-
-		digest := desc.Digest.Encoded()
-		artifactPathMap[filepath.Join(bundleTmpDir, config.BlobsDir, digest)] = filepath.Join(config.BlobsDir, digest)
 		descs = append(descs, desc)
 	}
-	// push the manifest config
-	manifestConfigDesc, err := pushZarfManifestConfigFromMetadata(bundleStore, &pkg.Metadata, &pkg.Build)
-	if err != nil {
-		return ocispec.Descriptor{}, err
-	}
-	// push the manifest
-	rootManifest, err := generatePkgManifest(bundleStore, descs, manifestConfigDesc)
+	return src, descs, nil
+}
 
-	if err != nil {
-		return ocispec.Descriptor{}, err
+// zarfManifestAnnotationsFromMetadata returns the manifest annotations Zarf sets when publishing a package
+func zarfManifestAnnotationsFromMetadata(metadata *zarfTypes.ZarfMetadata) map[string]string {
+	annotations := map[string]string{
+		ocispec.AnnotationDescription: metadata.Description,
 	}
-	return rootManifest, err
+	if url := metadata.URL; url != "" {
+		annotations[ocispec.AnnotationURL] = url
+	}
+	if authors := metadata.Authors; authors != "" {
+		annotations[ocispec.AnnotationAuthors] = authors
+	}
+	if documentation := metadata.Documentation; documentation != "" {
+		annotations[ocispec.AnnotationDocumentation] = documentation
+	}
+	if source := metadata.Source; source != "" {
+		annotations[ocispec.AnnotationSource] = source
+	}
+	if vendor := metadata.Vendor; vendor != "" {
+		annotations[ocispec.AnnotationVendor] = vendor
+	}
+	return annotations
 }
 
-func pushZarfManifestConfigFromMetadata(store *ocistore.Store, metadata *zarfTypes.ZarfMetadata, build *zarfTypes.ZarfBuildData) (ocispec.Descriptor, error) {
+func zarfManifestConfigFromMetadata(metadata *zarfTypes.ZarfMetadata, build *zarfTypes.ZarfBuildData) oci.ConfigPartial {
 	annotations := map[string]string{
 		ocispec.AnnotationTitle:       metadata.Name,
 		ocispec.AnnotationDescription: metadata.Description,
 	}
-	manifestConfig := oci.ConfigPartial{
+	return oci.ConfigPartial{
 		Architecture: build.Architecture,
 		OCIVersion:   "1.0.1",
 		Annotations:  annotations,
 	}
+}
 
+func pushZarfManifestConfigFromMetadata(store *ocistore.Store, metadata *zarfTypes.ZarfMetadata, build *zarfTypes.ZarfBuildData) (ocispec.Descriptor, error) {
+	manifestConfig := zarfManifestConfigFromMetadata(metadata, build)
 	manifestConfigDesc, err := utils.ToOCIStore(manifestConfig, ocispec.MediaTypeImageManifest, store)
 	if err != nil {
 		return ocispec.Descriptor{}, err
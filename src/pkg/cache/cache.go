@@ -29,7 +29,7 @@ func expandTilde(cachePath string) string {
 // Add adds a file to the cache
 func Add(filePathToAdd string) error {
 	// ensure cache dir exists
-	cacheDir := config.CommonOptions.CachePath
+	cacheDir := expandTilde(config.CommonOptions.CachePath)
 	if err := os.MkdirAll(filepath.Join(cacheDir, "images"), 0755); err != nil {
 		return err
 	}
@@ -40,19 +40,32 @@ func Add(filePathToAdd string) error {
 		return nil
 	}
 
-	srcFile, err := os.Open(filePathToAdd)
+	return copyAtomically(filePathToAdd, filepath.Join(cacheDir, "images", filename))
+}
+
+// copyAtomically copies src to dst through a temp file in dst's dir, so that concurrent readers (ie. packages
+// downloaded in parallel) never see a partial copy in the cache
+func copyAtomically(src, dst string) error {
+	srcFile, err := os.Open(src)
 	if err != nil {
 		return err
 	}
 	defer srcFile.Close()
 
-	dstFile, err := os.Create(filepath.Join(cacheDir, "images", filename))
+	tmpFile, err := os.CreateTemp(filepath.Dir(dst), filepath.Base(dst)+".*.tmp")
 	if err != nil {
 		return err
 	}
-	defer dstFile.Close()
-	_, err = io.Copy(dstFile, srcFile)
-	return err
+	if _, err = io.Copy(tmpFile, srcFile); err != nil {
+		tmpFile.Close()
+		os.Remove(tmpFile.Name())
+		return err
+	}
+	if err := tmpFile.Close(); err != nil {
+		os.Remove(tmpFile.Name())
+		return err
+	}
+	return os.Rename(tmpFile.Name(), dst)
 }
 
 // Exists checks if a layer exists in the cache
@@ -75,6 +88,7 @@ func Use(layerDigest, dstDir string) error {
 		return err
 	}
 	defer srcFile.Close()
+	touch(layerCachePath)
 
 	// ensure blobs/sha256 dir has been created
 	if err := os.MkdirAll(dstDir, 0755); err != nil {
@@ -89,3 +103,51 @@ func Use(layerDigest, dstDir string) error {
 	_, err = io.Copy(dstFile, srcFile)
 	return err
 }
+
+// filePath returns the cache path of a file with the given shasum
+func filePath(shasum string) string {
+	return filepath.Join(expandTilde(config.CommonOptions.CachePath), "files", strings.ToLower(shasum))
+}
+
+// AddFile adds a file with a known shasum (e.g. a task file download) to the cache
+func AddFile(filePathToAdd, shasum string) error {
+	if FileExists(shasum) {
+		return nil
+	}
+
+	cachePath := filePath(shasum)
+	if err := os.MkdirAll(filepath.Dir(cachePath), 0755); err != nil {
+		return err
+	}
+
+	// write to a temp file first so a partial copy is never used
+	return copyAtomically(filePathToAdd, cachePath)
+}
+
+// FileExists checks if a file with the given shasum exists in the cache
+func FileExists(shasum string) bool {
+	_, err := os.Stat(filePath(shasum))
+	return err == nil
+}
+
+// UseFile copies a file with the given shasum from the cache to dst
+func UseFile(shasum, dst string) error {
+	srcFile, err := os.Open(filePath(shasum))
+	if err != nil {
+		return err
+	}
+	defer srcFile.Close()
+	touch(filePath(shasum))
+
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+
+	dstFile, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	defer dstFile.Close()
+	_, err = io.Copy(dstFile, srcFile)
+	return err
+}
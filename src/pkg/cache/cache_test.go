@@ -0,0 +1,119 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/defenseunicorns/uds-cli/src/config"
+)
+
+func TestFileCache(t *testing.T) {
+	tmp := t.TempDir()
+	config.CommonOptions.CachePath = filepath.Join(tmp, "cache")
+
+	src := filepath.Join(tmp, "src.txt")
+	require.NoError(t, os.WriteFile(src, []byte("cached"), 0600))
+	shasum := "ABC123"
+
+	require.False(t, FileExists(shasum))
+	require.NoError(t, AddFile(src, shasum))
+	require.True(t, FileExists(shasum))
+	// shasums are case insensitive
+	require.True(t, FileExists("abc123"))
+
+	dst := filepath.Join(tmp, "nested", "dst.txt")
+	require.NoError(t, UseFile(shasum, dst))
+	contents, err := os.ReadFile(dst)
+	require.NoError(t, err)
+	require.Equal(t, "cached", string(contents))
+
+	require.Error(t, UseFile("missing", dst))
+}
+
+func TestConcurrentLayerCache(t *testing.T) {
+	tmp := t.TempDir()
+	config.CommonOptions.CachePath = filepath.Join(tmp, "cache")
+
+	layer := filepath.Join(tmp, config.BlobsDir, "abc123")
+	require.NoError(t, os.MkdirAll(filepath.Dir(layer), 0755))
+	require.NoError(t, os.WriteFile(layer, []byte("layer"), 0600))
+
+	// packages downloaded in parallel may cache and use the same layer at once
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			require.NoError(t, Add(layer))
+			dst := filepath.Join(tmp, "pkg", string(rune('a'+i)))
+			require.NoError(t, Use("abc123", dst))
+			contents, err := os.ReadFile(filepath.Join(dst, "abc123"))
+			require.NoError(t, err)
+			require.Equal(t, "layer", string(contents))
+		}(i)
+	}
+	wg.Wait()
+
+	leftovers, err := filepath.Glob(filepath.Join(tmp, "cache", "images", "*.tmp"))
+	require.NoError(t, err)
+	require.Empty(t, leftovers)
+}
+
+func TestListAndPrune(t *testing.T) {
+	tmp := t.TempDir()
+	config.CommonOptions.CachePath = filepath.Join(tmp, "cache")
+
+	entries, err := List()
+	require.NoError(t, err)
+	require.Empty(t, entries)
+
+	layer := filepath.Join(tmp, config.BlobsDir, "abc123")
+	require.NoError(t, os.MkdirAll(filepath.Dir(layer), 0755))
+	require.NoError(t, os.WriteFile(layer, []byte("layer"), 0600))
+	require.NoError(t, Add(layer))
+	src := filepath.Join(tmp, "src.txt")
+	require.NoError(t, os.WriteFile(src, []byte("file"), 0600))
+	require.NoError(t, AddFile(src, "def456"))
+
+	// a copy into the cache in progress isn't an entry and isn't pruned
+	tmpFile := filepath.Join(config.CommonOptions.CachePath, "images", "ghi789.123.tmp")
+	require.NoError(t, os.WriteFile(tmpFile, []byte("partial"), 0600))
+
+	// both entries were last used a week ago, using the file makes it recent again
+	weekAgo := time.Now().Add(-7 * 24 * time.Hour)
+	for _, path := range []string{filepath.Join(config.CommonOptions.CachePath, "images", "abc123"), filePath("def456")} {
+		require.NoError(t, os.Chtimes(path, weekAgo, weekAgo))
+	}
+	require.NoError(t, UseFile("def456", filepath.Join(tmp, "dst.txt")))
+
+	entries, err = List()
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	require.Equal(t, KindLayer, entries[0].Kind)
+	require.Equal(t, "abc123", entries[0].Digest)
+	require.Equal(t, int64(5), entries[0].Size)
+	require.Equal(t, KindFile, entries[1].Kind)
+	require.WithinDuration(t, time.Now(), entries[1].LastUsed, time.Minute)
+
+	pruned, err := Prune(24 * time.Hour)
+	require.NoError(t, err)
+	require.Len(t, pruned, 1)
+	require.Equal(t, "abc123", pruned[0].Digest)
+	require.False(t, Exists("abc123"))
+	require.True(t, FileExists("def456"))
+	require.FileExists(t, tmpFile)
+
+	// a stale temp file is removed along with every entry
+	hourAgo := time.Now().Add(-2 * time.Hour)
+	require.NoError(t, os.Chtimes(tmpFile, hourAgo, hourAgo))
+	pruned, err = Prune(0)
+	require.NoError(t, err)
+	require.Len(t, pruned, 1)
+	require.False(t, FileExists("def456"))
+	require.NoFileExists(t, tmpFile)
+}
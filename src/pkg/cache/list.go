@@ -0,0 +1,121 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023-Present The UDS Authors
+
+// Package cache provides a primitive cache mechanism for bundle layers
+package cache
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/defenseunicorns/uds-cli/src/config"
+)
+
+const (
+	// KindLayer is a cached bundle layer
+	KindLayer = "layer"
+	// KindFile is a cached task file download
+	KindFile = "file"
+)
+
+// staleTmpAge is how old the temp file of a copy into the cache has to be before Prune considers it abandoned, younger
+// ones may belong to a copy in progress
+const staleTmpAge = time.Hour
+
+// Entry is a layer or file in the cache
+type Entry struct {
+	Kind   string
+	Digest string
+	Size   int64
+	// LastUsed is when the entry was added to the cache or last used from it
+	LastUsed time.Time
+	path     string
+}
+
+// List returns the entries of the cache, from the least recently used
+func List() ([]Entry, error) {
+	cacheDir := expandTilde(config.CommonOptions.CachePath)
+	var entries []Entry
+	for kind, dir := range map[string]string{KindLayer: "images", KindFile: "files"} {
+		dirEntries, err := os.ReadDir(filepath.Join(cacheDir, dir))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		for _, dirEntry := range dirEntries {
+			// skip anything that isn't a complete entry (ie. a copy into the cache in progress)
+			if !dirEntry.Type().IsRegular() || strings.HasSuffix(dirEntry.Name(), ".tmp") {
+				continue
+			}
+			info, err := dirEntry.Info()
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			} else if err != nil {
+				return nil, err
+			}
+			entries = append(entries, Entry{
+				Kind:     kind,
+				Digest:   dirEntry.Name(),
+				Size:     info.Size(),
+				LastUsed: info.ModTime(),
+				path:     filepath.Join(cacheDir, dir, dirEntry.Name()),
+			})
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].LastUsed.Before(entries[j].LastUsed)
+	})
+	return entries, nil
+}
+
+// Prune removes the entries of the cache that haven't been used for olderThan (all of them when olderThan is 0) and
+// returns them. Pulls that find a pruned entry missing download it instead, and temp files of copies into the cache
+// are only removed once they are stale
+func Prune(olderThan time.Duration) ([]Entry, error) {
+	entries, err := List()
+	if err != nil {
+		return nil, err
+	}
+	cutoff := time.Now().Add(-olderThan)
+	var pruned []Entry
+	for _, entry := range entries {
+		if olderThan > 0 && entry.LastUsed.After(cutoff) {
+			continue
+		}
+		if err := os.Remove(entry.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return pruned, err
+		}
+		pruned = append(pruned, entry)
+	}
+	return pruned, removeStaleTmpFiles(max(olderThan, staleTmpAge))
+}
+
+// removeStaleTmpFiles removes the temp files of copies into the cache that are older than age
+func removeStaleTmpFiles(age time.Duration) error {
+	cacheDir := expandTilde(config.CommonOptions.CachePath)
+	for _, dir := range []string{"images", "files"} {
+		tmpFiles, err := filepath.Glob(filepath.Join(cacheDir, dir, "*.tmp"))
+		if err != nil {
+			return err
+		}
+		for _, tmpFile := range tmpFiles {
+			if info, err := os.Stat(tmpFile); err == nil && time.Since(info.ModTime()) > age {
+				if err := os.Remove(tmpFile); err != nil && !errors.Is(err, os.ErrNotExist) {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// touch records that a cache entry was used, so that Prune keeps recently used entries
+func touch(path string) {
+	now := time.Now()
+	_ = os.Chtimes(path, now, now)
+}
@@ -0,0 +1,40 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023-Present The UDS Authors
+
+// Package runner provides functions for running tasks in a run.yaml
+package runner
+
+import (
+	"path/filepath"
+	"runtime"
+	"time"
+
+	"github.com/defenseunicorns/zarf/src/pkg/message"
+	zarfUtils "github.com/defenseunicorns/zarf/src/pkg/utils"
+
+	"github.com/defenseunicorns/uds-cli/src/config"
+)
+
+// timestampFormat is the (file name safe) ISO 8601 format of ${TIMESTAMP}
+const timestampFormat = "20060102T150405Z"
+
+// builtinVariables returns the variables that are available without being declared, computed when the run starts
+func builtinVariables() map[string]*zarfUtils.TextTemplate {
+	builtins := map[string]string{
+		"UDS_ARCH":  config.GetArch(),
+		"OS":        runtime.GOOS,
+		"TIMESTAMP": time.Now().UTC().Format(timestampFormat),
+	}
+	// ${CWD} is the base directory of the run, which is where tasks without a dir run
+	if cwd, err := filepath.Abs(baseDir()); err == nil {
+		builtins["CWD"] = cwd
+	} else {
+		message.Debugf("unable to determine the current directory for ${CWD}: %s", err)
+	}
+
+	templates := make(map[string]*zarfUtils.TextTemplate, len(builtins))
+	for name, value := range builtins {
+		templates["${"+name+"}"] = &zarfUtils.TextTemplate{Value: value}
+	}
+	return templates
+}
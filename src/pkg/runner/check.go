@@ -0,0 +1,367 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023-Present The UDS Authors
+
+// Package runner provides functions for running tasks in a run.yaml
+package runner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	zarfUtils "github.com/defenseunicorns/zarf/src/pkg/utils"
+	"github.com/subosito/gotenv"
+
+	"github.com/defenseunicorns/uds-cli/src/config"
+	"github.com/defenseunicorns/uds-cli/src/types"
+)
+
+// variableReference matches the ${VAR} references that are checked, lowercase names are left alone as they're
+// usually shell variables
+var variableReference = regexp.MustCompile(`\$\{([A-Z0-9_]+)\}`)
+
+// CheckError lists every problem found in a tasks file
+type CheckError struct {
+	Problems []string
+}
+
+func (e *CheckError) Error() string {
+	return fmt.Sprintf("found %d problem(s) in the tasks file:\n- %s", len(e.Problems), strings.Join(e.Problems, "\n- "))
+}
+
+// Check statically validates every task in a tasks file (and the files it includes) without running anything,
+// returning a *CheckError listing all of the problems found
+func Check(tasksFile types.TasksFile, setVariables map[string]string) error {
+	runner := Runner{
+		TemplateMap: map[string]*zarfUtils.TextTemplate{},
+		TasksFile:   tasksFile,
+		TaskNameMap: map[string]bool{},
+
+		setVariables:   setVariables,
+		includes:       map[string]string{},
+		dependencyRuns: map[string]*dependencyRun{},
+		checking:       true,
+	}
+	runner.populateTemplateMap(tasksFile.Variables, setVariables)
+
+	c := checker{runner: &runner, known: map[string]bool{}}
+	for _, err := range runner.variableErrors(tasksFile.Variables) {
+		c.problem("%s", err.Error())
+	}
+	for _, task := range tasksFile.Tasks {
+		if requiresIncludes(task) {
+			if err := runner.importTasks(tasksFile.Includes, []string{filepath.Clean(config.TaskFileLocation)}); err != nil {
+				c.problem("%s", err.Error())
+			}
+			break
+		}
+	}
+
+	c.collectKnownVariables()
+	c.checkDefaultTask()
+	c.checkDuplicateTasks()
+	c.checkCycles()
+	for _, task := range append(hookTasks(runner.TasksFile), runner.TasksFile.Tasks...) {
+		c.checkTask(task)
+	}
+
+	if len(c.problems) > 0 {
+		return &CheckError{Problems: c.problems}
+	}
+	return nil
+}
+
+// checker gathers the problems found in a tasks file
+type checker struct {
+	runner   *Runner
+	known    map[string]bool
+	problems []string
+}
+
+func (c *checker) problem(format string, a ...any) {
+	problem := fmt.Sprintf(format, a...)
+	if !slices.Contains(c.problems, problem) {
+		c.problems = append(c.problems, problem)
+	}
+}
+
+// collectKnownVariables gathers the names of every variable that can be set during a run: declared and built-in
+// variables, --set values, env files, variables set by actions and the environment of the CLI and of actions
+func (c *checker) collectKnownVariables() {
+	for key := range c.runner.TemplateMap {
+		c.known[strings.TrimSuffix(strings.TrimPrefix(key, "${"), "}")] = true
+	}
+	for _, env := range os.Environ() {
+		name, _, _ := strings.Cut(env, "=")
+		c.known[name] = true
+	}
+	c.collectEnvFile(c.runner.TasksFile.EnvFile)
+	if len(c.runner.TasksFile.AfterAll) > 0 {
+		c.known["TASK_STATUS"] = true
+	}
+
+	for _, task := range append(hookTasks(c.runner.TasksFile), c.runner.TasksFile.Tasks...) {
+		c.collectEnvFile(task.EnvFile)
+		for name := range task.Env {
+			c.known[name] = true
+		}
+		for _, action := range taskActions(task) {
+			if action.ForEach != "" {
+				c.known["ITEM"] = true
+				c.known["ITEM_INDEX"] = true
+			}
+			for _, v := range action.SetVariables {
+				c.known[v.Name] = true
+			}
+			if action.ZarfComponentAction != nil {
+				for _, env := range action.Env {
+					name, _, _ := strings.Cut(env, "=")
+					c.known[name] = true
+				}
+			}
+		}
+	}
+}
+
+// collectEnvFile adds the variables of an env file, reporting env files that can't be read
+func (c *checker) collectEnvFile(envFile string) {
+	if envFile == "" {
+		return
+	}
+	path := c.runner.templateString(context.Background(), envFile)
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(filepath.Dir(config.TaskFileLocation), path)
+	}
+	env, err := gotenv.Read(path)
+	if err != nil {
+		c.problem("unable to read env file %s: %s", path, err.Error())
+		return
+	}
+	for name := range env {
+		c.known[name] = true
+	}
+}
+
+func (c *checker) checkDefaultTask() {
+	if name := c.runner.TasksFile.Default; name != "" {
+		if _, err := c.runner.getTask(name); err != nil {
+			c.problem("default task %s not found", name)
+		}
+	}
+}
+
+func (c *checker) checkDuplicateTasks() {
+	seen := map[string]bool{}
+	for _, task := range c.runner.TasksFile.Tasks {
+		if seen[task.Name] {
+			c.problem("task %s is defined more than once", task.Name)
+		}
+		seen[task.Name] = true
+	}
+}
+
+// checkCycles reports each dependency (or task reference) cycle once, no matter which of its tasks it's found from
+func (c *checker) checkCycles() {
+	reported := map[string]bool{}
+	for _, task := range c.runner.TasksFile.Tasks {
+		var cycleErr *dependencyCycleError
+		if err := c.runner.checkForDependencyCycles(task); !errors.As(err, &cycleErr) {
+			continue
+		}
+		members := slices.Clone(cycleErr.cycle[:len(cycleErr.cycle)-1])
+		slices.Sort(members)
+		key := strings.Join(members, ",")
+		if !reported[key] {
+			reported[key] = true
+			c.problem("%s", cycleErr.Error())
+		}
+	}
+}
+
+func (c *checker) checkTask(task types.Task) {
+	for _, dep := range task.DependsOn {
+		if _, err := c.runner.getTask(dep); err != nil {
+			c.problem("task %s: dependsOn task %s not found", task.Name, dep)
+		}
+	}
+
+	c.checkVariables(task.Name, task.Dir, task.EnvFile)
+	for _, value := range task.Env {
+		c.checkVariables(task.Name, value)
+	}
+	for _, file := range task.Files {
+		c.checkVariables(task.Name, file.Source, file.Target, file.Content)
+		switch {
+		case file.Source == "" && file.Content == "":
+			c.problem("task %s: file %s must have a source or content", task.Name, file.Target)
+		case file.Source != "" && file.Content != "":
+			c.problem("task %s: file %s can't have both a source and content", task.Name, file.Target)
+		case file.Content != "" && file.ExtractPath != "":
+			c.problem("task %s: file %s can't have both content and an extractPath", task.Name, file.Target)
+		case isSourceGlob(file.Source) && (file.Shasum != "" || file.ExtractPath != "" || len(file.Symlinks) > 0):
+			c.problem("task %s: file %s with a source glob can't have a shasum, extractPath or symlinks", task.Name, file.Target)
+		case file.AllowEmpty && !isSourceGlob(file.Source):
+			c.problem("task %s: file %s can only set allowEmpty with a source glob", task.Name, file.Target)
+		case file.Retries < 0:
+			c.problem("task %s: file %s has negative retries %d", task.Name, file.Target, file.Retries)
+		case file.ArchiveFormat != "" && file.ExtractPath == "":
+			c.problem("task %s: file %s can only set archiveFormat with an extractPath", task.Name, file.Target)
+		}
+		if err := validateArchiveFormat(file.ArchiveFormat); err != nil {
+			c.problem("task %s: file %s has an %s", task.Name, file.Target, err)
+		}
+		if _, err := parseFileMode(file.Mode); err != nil {
+			c.problem("task %s: file %s has an %s", task.Name, file.Target, err)
+		}
+		if isSourceGlob(file.Source) {
+			if _, err := filepath.Match(file.Source, ""); err != nil {
+				c.problem("task %s: invalid source glob %q", task.Name, file.Source)
+			}
+		}
+		c.checkVariables(task.Name, file.Symlinks...)
+		if isGlob(file.ExtractPath) {
+			if _, err := path.Match(file.ExtractPath, ""); err != nil {
+				c.problem("task %s: invalid extractPath glob %q", task.Name, file.ExtractPath)
+			}
+		}
+	}
+
+	for i, action := range task.Actions {
+		c.checkTaskAction(task.Name, fmt.Sprintf("task %s: action %d", task.Name, i+1), action)
+	}
+	for i, action := range task.Finally {
+		c.checkTaskAction(task.Name, fmt.Sprintf("task %s: finally action %d", task.Name, i+1), action)
+	}
+}
+
+// checkTaskAction checks an action of a task and the variables it references
+func (c *checker) checkTaskAction(taskName, name string, action types.Action) {
+	c.checkAction(name, action)
+	c.checkVariables(taskName, action.If, action.Unless, action.ForEach)
+	if action.ZarfComponentAction != nil {
+		c.checkVariables(taskName, action.Cmd)
+		c.checkVariables(taskName, action.Env...)
+		if action.Dir != nil {
+			c.checkVariables(taskName, *action.Dir)
+		}
+	}
+	if action.Wait != nil && action.Wait.File != nil {
+		c.checkVariables(taskName, action.Wait.File.Path)
+	}
+	if action.Wait != nil && action.Wait.Command != nil {
+		c.checkVariables(taskName, action.Wait.Command.Cmd)
+	}
+	if action.Wait != nil && action.Wait.Cluster != nil {
+		cluster := action.Wait.Cluster
+		c.checkVariables(taskName, cluster.Kind, cluster.Identifier, cluster.Namespace, cluster.Condition)
+	}
+	if action.Wait != nil && action.Wait.Network != nil {
+		c.checkVariables(taskName, action.Wait.Network.Protocol, action.Wait.Network.Address)
+	}
+	if action.HTTP != nil {
+		c.checkVariables(taskName, action.HTTP.Method, action.HTTP.URL, action.HTTP.Body)
+		for _, value := range action.HTTP.Headers {
+			c.checkVariables(taskName, value)
+		}
+	}
+}
+
+// checkAction checks that an action has exactly one of cmd, task, wait or http and that its fields are valid
+func (c *checker) checkAction(name string, action types.Action) {
+	var kinds []string
+	hasCmd := action.ZarfComponentAction != nil && action.Cmd != ""
+	if hasCmd {
+		kinds = append(kinds, "cmd")
+	}
+	if action.TaskReference != "" {
+		kinds = append(kinds, "task")
+		if _, err := c.runner.getTask(action.TaskReference); err != nil {
+			c.problem("%s: task %s not found", name, action.TaskReference)
+		}
+	}
+	if action.Wait != nil {
+		kinds = append(kinds, "wait")
+		wait := action.Wait
+		if wait.Cluster == nil && wait.Network == nil && wait.File == nil && wait.Command == nil {
+			c.problem("%s: wait is missing a cluster, network, file or command", name)
+		}
+		if wait.File != nil && wait.File.Condition != "" && wait.File.Condition != types.WaitFileExists && wait.File.Condition != types.WaitFileDeleted {
+			c.problem("%s: invalid file wait condition %q, must be %s or %s", name, wait.File.Condition, types.WaitFileExists, types.WaitFileDeleted)
+		}
+	}
+	if action.HTTP != nil {
+		kinds = append(kinds, "http")
+		if action.HTTP.URL == "" {
+			c.problem("%s: http is missing a url", name)
+		}
+		if code := action.HTTP.StatusCode; code != 0 && (code < 100 || code > 599) {
+			c.problem("%s: invalid http status code %d", name, code)
+		}
+	}
+	switch len(kinds) {
+	case 0:
+		c.problem("%s: must have one of cmd, task, wait or http", name)
+	case 1:
+	default:
+		c.problem("%s: cmd, task, wait and http are mutually exclusive but it has %s", name, strings.Join(kinds, " and "))
+	}
+
+	if action.Isolate && action.TaskReference == "" {
+		c.problem("%s: isolate can only be used with task", name)
+	}
+	if action.AlwaysRun && action.TaskReference != "" {
+		c.problem("%s: alwaysRun can only be used with cmd, wait or http", name)
+	}
+	if action.OutputFile != "" && !hasCmd {
+		c.problem("%s: outputFile can only be used with cmd", name)
+	}
+	if (action.MaxOutputBytes != 0 || action.OnMaxOutput != "") && !hasCmd {
+		c.problem("%s: maxOutputBytes and onMaxOutput can only be used with cmd", name)
+	}
+	if _, err := newOutputLimit(action); err != nil {
+		c.problem("%s: %s", name, err)
+	}
+	if len(action.SetVariables) > 0 && !hasCmd && action.HTTP == nil {
+		c.problem("%s: setVariables can only be used with cmd or http", name)
+	}
+	for _, v := range action.SetVariables {
+		if v.Name == "" {
+			c.problem("%s: setVariables entry is missing a name", name)
+		}
+		if v.Pattern != "" {
+			if _, err := regexp.Compile(v.Pattern); err != nil {
+				c.problem("%s: invalid pattern %q for variable %s", name, v.Pattern, v.Name)
+			}
+		}
+		if v.Stream != "" {
+			if action.HTTP != nil {
+				c.problem("%s: stream of variable %s can only be used with cmd", name, v.Name)
+			} else if _, err := (commandOutput{}).stream(v.Stream); err != nil {
+				c.problem("%s: %s of variable %s", name, err, v.Name)
+			}
+		}
+	}
+	if _, err := newRetryPolicy(action); err != nil {
+		c.problem("%s: %s", name, err.Error())
+	}
+	if _, err := parseAttemptTimeout(action); err != nil {
+		c.problem("%s: %s", name, err.Error())
+	}
+}
+
+// checkVariables reports the ${VAR} references in values that can never be set
+func (c *checker) checkVariables(taskName string, values ...string) {
+	for _, value := range values {
+		for _, match := range variableReference.FindAllStringSubmatch(value, -1) {
+			if !c.known[match[1]] {
+				c.problem("task %s: variable %s is not declared", taskName, match[1])
+			}
+		}
+	}
+}
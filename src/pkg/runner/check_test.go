@@ -0,0 +1,122 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023-Present The UDS Authors
+
+package runner
+
+import (
+	"errors"
+	"testing"
+
+	zarfTypes "github.com/defenseunicorns/zarf/src/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/defenseunicorns/uds-cli/src/types"
+)
+
+func Test_Check(t *testing.T) {
+	cmd := func(cmd string) types.Action {
+		return types.Action{ZarfComponentAction: &zarfTypes.ZarfComponentAction{Cmd: cmd}}
+	}
+	setVariable := func(command, name string) types.Action {
+		action := cmd(command)
+		action.SetVariables = []types.SetVariable{{ZarfComponentActionSetVariable: zarfTypes.ZarfComponentActionSetVariable{Name: name}}}
+		return action
+	}
+
+	tests := []struct {
+		name         string
+		tasksFile    types.TasksFile
+		setVariables map[string]string
+		wantProblems []string
+	}{
+		{
+			name: "Valid",
+			tasksFile: types.TasksFile{
+				Variables: []types.Variable{{ZarfPackageVariable: zarfTypes.ZarfPackageVariable{Name: "FOO"}}},
+				Tasks: []types.Task{
+					{Name: "a", DependsOn: []string{"b"}, Actions: []types.Action{
+						setVariable("echo hi", "OUTPUT"),
+						cmd("echo ${FOO} ${OUTPUT} ${BAR} ${UDS_ARCH} ${lowercase}"),
+						{TaskReference: "b", ForEach: "x,y"},
+					}},
+					{Name: "b", Actions: []types.Action{cmd("echo ${ITEM}")}},
+				},
+			},
+			setVariables: map[string]string{"BAR": "bar"},
+		},
+		{
+			name: "ReportsEveryProblem",
+			tasksFile: types.TasksFile{
+				Default: "missing-default",
+				Tasks: []types.Task{
+					{Name: "a", DependsOn: []string{"missing-dep"}, Actions: []types.Action{
+						{TaskReference: "missing-task"},
+						cmd("echo ${UNDECLARED}"),
+						{},
+					}},
+					{Name: "b", Actions: []types.Action{{TaskReference: "c"}}},
+					{Name: "c", Actions: []types.Action{{TaskReference: "b"}}},
+					{Name: "c"},
+				},
+			},
+			wantProblems: []string{
+				"default task missing-default not found",
+				"task c is defined more than once",
+				"task dependency cycle detected: b -> c -> b",
+				"task a: dependsOn task missing-dep not found",
+				"task a: action 1: task missing-task not found",
+				"task a: variable UNDECLARED is not declared",
+				"task a: action 3: must have one of cmd, task, wait or http",
+			},
+		},
+		{
+			name: "InvalidActions",
+			tasksFile: types.TasksFile{
+				Tasks: []types.Task{
+					{Name: "a", Files: []types.File{
+						{ZarfFile: zarfTypes.ZarfFile{Source: "tools.tar.gz", ExtractPath: "bin/["}},
+						{ZarfFile: zarfTypes.ZarfFile{Source: "config.yaml", Target: "app.yaml"}, Content: "inline"},
+						{ZarfFile: zarfTypes.ZarfFile{Source: "tool", Target: "tool", ExtractPath: "bin/tool"}, ArchiveFormat: "7z"},
+					}, Actions: []types.Action{
+						func() types.Action {
+							action := cmd("echo hi")
+							action.TaskReference = "a"
+							action.RetryDelay = "soon"
+							return action
+						}(),
+						{Wait: &types.Wait{}, SetVariables: []types.SetVariable{{}}},
+						{ZarfComponentAction: &zarfTypes.ZarfComponentAction{Cmd: "echo"}, SetVariables: []types.SetVariable{
+							{ZarfComponentActionSetVariable: zarfTypes.ZarfComponentActionSetVariable{Name: "OUT"}, Stream: "both"},
+						}},
+					}, Finally: []types.Action{{}}},
+				},
+			},
+			wantProblems: []string{
+				"task dependency cycle detected: a -> a",
+				"task a: invalid extractPath glob \"bin/[\"",
+				"task a: file app.yaml can't have both a source and content",
+				"task a: file tool has an invalid archiveFormat \"7z\", must be one of tar, tar.bz2, tar.gz, tar.xz, tar.zst, zip",
+				"task a: action 1: cmd, task, wait and http are mutually exclusive but it has cmd and task",
+				"task a: action 1: invalid retryDelay \"soon\", must be a positive duration such as 500ms or 2s",
+				"task a: action 2: wait is missing a cluster, network, file or command",
+				"task a: action 2: setVariables can only be used with cmd or http",
+				"task a: action 2: setVariables entry is missing a name",
+				"task a: action 3: invalid stream \"both\", must be stdout, stderr or combined of variable OUT",
+				"task a: finally action 1: must have one of cmd, task, wait or http",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Check(tt.tasksFile, tt.setVariables)
+			if tt.wantProblems == nil {
+				require.NoError(t, err)
+				return
+			}
+			var checkErr *CheckError
+			require.True(t, errors.As(err, &checkErr), err)
+			require.Equal(t, tt.wantProblems, checkErr.Problems)
+		})
+	}
+}
@@ -0,0 +1,91 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023-Present The UDS Authors
+
+// Package runner provides functions for running tasks in a run.yaml
+package runner
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+
+	"github.com/defenseunicorns/zarf/src/pkg/message"
+	zarfUtils "github.com/defenseunicorns/zarf/src/pkg/utils"
+	"github.com/defenseunicorns/zarf/src/pkg/utils/exec"
+	zarfTypes "github.com/defenseunicorns/zarf/src/types"
+
+	"github.com/defenseunicorns/uds-cli/src/config"
+	"github.com/defenseunicorns/uds-cli/src/types"
+)
+
+// RunCommand runs an ad-hoc command with the variables, env and working directory that the actions of a task (or
+// of the default task when taskName is empty) would have, passing its output through as is
+func RunCommand(tasksFile types.TasksFile, taskName string, setVariables map[string]string, command []string) error {
+	if len(command) == 0 {
+		return errors.New("no command given")
+	}
+	if taskName == "" {
+		if taskName = DefaultTaskName(tasksFile); taskName == "" {
+			return ErrNoDefaultTask
+		}
+	}
+
+	runner := Runner{
+		TemplateMap: map[string]*zarfUtils.TextTemplate{},
+		TasksFile:   tasksFile,
+		TaskNameMap: map[string]bool{},
+
+		setVariables:   setVariables,
+		includes:       map[string]string{},
+		dependencyRuns: map[string]*dependencyRun{},
+	}
+
+	runner.populateTemplateMap(tasksFile.Variables, setVariables)
+
+	if err := runner.loadEnvFile(context.Background(), tasksFile.EnvFile); err != nil {
+		return err
+	}
+
+	if err := runner.promptVariables(tasksFile.Variables); err != nil {
+		return err
+	}
+
+	task, err := runner.getTask(taskName)
+	if err != nil {
+		return err
+	}
+
+	if err := runner.loadEnvFile(context.Background(), task.EnvFile); err != nil {
+		return err
+	}
+
+	// run the command like a cmd action of the task
+	action := types.Action{ZarfComponentAction: &zarfTypes.ZarfComponentAction{Cmd: strings.Join(command, " ")}}
+	action = runner.withWorkingDir(context.Background(), []types.Action{withTaskEnv(action, task.Env)}, runner.taskDir(context.Background(), task))[0]
+
+	var cfg zarfTypes.ZarfComponentActionDefaults
+	runner.readTemplateMap(func(templateMap map[string]*zarfUtils.TextTemplate) {
+		cfg = actionGetCfg(zarfTypes.ZarfComponentActionDefaults{}, *action.ZarfComponentAction, templateMap)
+	})
+
+	shell, shellArgs, err := shellCommand(runner.actionShell(action.Shell))
+	if err != nil {
+		return err
+	}
+
+	cmd := runner.templateString(context.Background(), action.Cmd)
+	message.Debugf("Running command in %s: %s", shell, runner.mask(cmd))
+
+	ctx, cancel := runContext(config.RunTimeout)
+	defer cancel()
+
+	execCfg := exec.Config{
+		Env:    append(runner.templateEnv(ctx, action.Env), "UDS_ARCH="+config.GetArch()),
+		Dir:    cfg.Dir,
+		Stdout: os.Stdout,
+		Stderr: os.Stderr,
+	}
+	_, _, err = runCommand(ctx, execCfg, outputLimit{maxBytes: defaultMaxOutputBytes}, false, shell, append(shellArgs, cmd)...)
+	return err
+}
@@ -0,0 +1,34 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023-Present The UDS Authors
+
+package runner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	zarfTypes "github.com/defenseunicorns/zarf/src/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/defenseunicorns/uds-cli/src/types"
+)
+
+func Test_RunCommand(t *testing.T) {
+	dir := t.TempDir()
+	tasksFile := types.TasksFile{
+		Variables: []types.Variable{{ZarfPackageVariable: zarfTypes.ZarfPackageVariable{Name: "GREETING", Default: "hello"}}},
+		Tasks: []types.Task{
+			{Name: "build", Dir: dir, Env: map[string]string{"TOKEN": "${GREETING}-token"}},
+		},
+	}
+
+	err := RunCommand(tasksFile, "build", map[string]string{"GREETING": "hi"}, []string{"echo", "${GREETING} $TOKEN", ">", "out.txt"})
+	require.NoError(t, err)
+	out, err := os.ReadFile(filepath.Join(dir, "out.txt"))
+	require.NoError(t, err)
+	require.Equal(t, "hi hi-token\n", string(out))
+
+	require.ErrorContains(t, RunCommand(tasksFile, "missing", nil, []string{"true"}), "task name missing not found")
+	require.ErrorContains(t, RunCommand(tasksFile, "build", nil, []string{"exit 3"}), "exit status 3")
+}
@@ -0,0 +1,59 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023-Present The UDS Authors
+
+// Package runner provides functions for running tasks in a run.yaml
+package runner
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/defenseunicorns/uds-cli/src/types"
+)
+
+// unresolvedVariable matches a ${...} variable reference that was left in a string after templating
+var unresolvedVariable = regexp.MustCompile(`\$\{.*?\}`)
+
+// shouldRun templates and evaluates an action's if and unless conditions
+func (r *Runner) shouldRun(ctx context.Context, action types.Action) bool {
+	if action.If != "" && !evaluateCondition(r.templateString(ctx, action.If)) {
+		return false
+	}
+	if action.Unless != "" && evaluateCondition(r.templateString(ctx, action.Unless)) {
+		return false
+	}
+	return true
+}
+
+// evaluateCondition evaluates a (templated) condition, supporting ==, != and the truthiness of a single value
+func evaluateCondition(condition string) bool {
+	if left, right, ok := strings.Cut(condition, "!="); ok {
+		return conditionOperand(left) != conditionOperand(right)
+	}
+	if left, right, ok := strings.Cut(condition, "=="); ok {
+		return conditionOperand(left) == conditionOperand(right)
+	}
+	return isTruthy(conditionOperand(condition))
+}
+
+// conditionOperand trims whitespace and surrounding quotes from one side of a condition
+func conditionOperand(s string) string {
+	s = strings.TrimSpace(s)
+	if len(s) >= 2 && (s[0] == '"' || s[0] == '\'') && s[len(s)-1] == s[0] {
+		s = s[1 : len(s)-1]
+	}
+	return s
+}
+
+// isTruthy returns false for empty, unset or explicitly false values and true for everything else
+func isTruthy(value string) bool {
+	if unresolvedVariable.MatchString(value) {
+		return false
+	}
+	switch strings.ToLower(value) {
+	case "", "false", "0", "no", "off":
+		return false
+	}
+	return true
+}
@@ -0,0 +1,37 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023-Present The UDS Authors
+
+package runner
+
+import (
+	"testing"
+)
+
+func Test_evaluateCondition(t *testing.T) {
+	tests := []struct {
+		condition string
+		want      bool
+	}{
+		{condition: "true", want: true},
+		{condition: "yes", want: true},
+		{condition: "some-value", want: true},
+		{condition: "", want: false},
+		{condition: "false", want: false},
+		{condition: "FALSE", want: false},
+		{condition: "0", want: false},
+		{condition: "${UNSET}", want: false},
+		{condition: "prod == prod", want: true},
+		{condition: "'prod' == \"prod\"", want: true},
+		{condition: "prod == dev", want: false},
+		{condition: "prod != dev", want: true},
+		{condition: " prod != prod ", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.condition, func(t *testing.T) {
+			if got := evaluateCondition(tt.condition); got != tt.want {
+				t.Errorf("evaluateCondition(%q) = %v, want %v", tt.condition, got, tt.want)
+			}
+		})
+	}
+}
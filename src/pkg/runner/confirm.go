@@ -0,0 +1,47 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023-Present The UDS Authors
+
+// Package runner provides functions for running tasks in a run.yaml
+package runner
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/pterm/pterm"
+
+	"github.com/defenseunicorns/uds-cli/src/types"
+)
+
+// confirmAction asks the user to confirm an action marked with requireConfirmation before it runs, describing it
+// with its description. When the runner can't prompt, the action only runs if the run was given --confirm
+func (r *Runner) confirmAction(ctx context.Context, action types.Action) error {
+	if !action.RequireConfirmation || r.confirm || r.dryRun {
+		return nil
+	}
+
+	name := r.mask(r.templateString(ctx, actionName(action)))
+	if !isInteractive() {
+		return fmt.Errorf("action \"%s\" requires confirmation, run with --confirm to run it without a prompt", name)
+	}
+
+	what := fmt.Sprintf("run \"%s\"", name)
+	if action.Description != "" {
+		what = r.mask(r.templateString(ctx, action.Description))
+	}
+
+	// keep the output of parallel actions from interleaving with the prompt
+	r.outputMu.Lock()
+	defer r.outputMu.Unlock()
+	pterm.Println()
+	confirmed := false
+	prompt := &survey.Confirm{Message: fmt.Sprintf("This will %s. Continue?", what)}
+	if err := survey.AskOne(prompt, &confirmed); err != nil {
+		return fmt.Errorf("unable to confirm action \"%s\": %w", name, err)
+	}
+	if !confirmed {
+		return fmt.Errorf("action \"%s\" was not confirmed", name)
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023-Present The UDS Authors
+
+package runner
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	zarfTypes "github.com/defenseunicorns/zarf/src/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/defenseunicorns/uds-cli/src/types"
+)
+
+func Test_confirmAction(t *testing.T) {
+	isInteractive = func() bool { return false }
+
+	for _, confirm := range []bool{false, true} {
+		dir := t.TempDir()
+		r := newTestRunner(nil)
+		r.confirm = confirm
+		task := types.Task{Name: "destroy", Dir: dir, Actions: []types.Action{{
+			ZarfComponentAction: &zarfTypes.ZarfComponentAction{Cmd: "touch destroyed", Description: "delete the cluster"},
+			RequireConfirmation: true,
+		}}}
+
+		err := r.executeTask(context.Background(), task, false)
+		if confirm {
+			require.NoError(t, err)
+			require.FileExists(t, filepath.Join(dir, "destroyed"))
+		} else {
+			require.EqualError(t, err, "action \"delete the cluster\" requires confirmation, run with --confirm to run it without a prompt")
+			_, err := os.Stat(filepath.Join(dir, "destroyed"))
+			require.True(t, os.IsNotExist(err))
+		}
+	}
+}
@@ -0,0 +1,114 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023-Present The UDS Authors
+
+// Package runner provides functions for running tasks in a run.yaml
+package runner
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/defenseunicorns/uds-cli/src/types"
+)
+
+// dependencyRun tracks the result of running a task listed in a dependsOn
+type dependencyRun struct {
+	once sync.Once
+	err  error
+}
+
+// executeDependencies runs the tasks that a task depends on, running independent dependencies concurrently
+func (r *Runner) executeDependencies(ctx context.Context, task types.Task, buffered bool) error {
+	switch len(task.DependsOn) {
+	case 0:
+		return nil
+	case 1:
+		return r.executeDependency(ctx, task.DependsOn[0], buffered)
+	}
+
+	g := errgroup.Group{}
+	for _, name := range task.DependsOn {
+		name := name
+		g.Go(func() error {
+			return r.executeDependency(ctx, name, true)
+		})
+	}
+	return g.Wait()
+}
+
+// executeDependency runs a dependency (and its own dependencies) at most once per run
+func (r *Runner) executeDependency(ctx context.Context, name string, buffered bool) error {
+	task, err := r.getTask(name)
+	if err != nil {
+		return err
+	}
+	return r.executeTaskOnce(ctx, task, buffered)
+}
+
+// executeTaskOnce runs a task unless it already ran (as a dependency or a selected task) in this run, returning the
+// result of its first run
+func (r *Runner) executeTaskOnce(ctx context.Context, task types.Task, buffered bool) error {
+	r.dependencyRunsMu.Lock()
+	run, ok := r.dependencyRuns[task.Name]
+	if !ok {
+		run = &dependencyRun{}
+		r.dependencyRuns[task.Name] = run
+	}
+	r.dependencyRunsMu.Unlock()
+
+	run.once.Do(func() {
+		run.err = r.executeTask(ctx, task, buffered)
+	})
+	return run.err
+}
+
+// dependencyCycleError is returned when a task eventually depends on (or references) itself
+type dependencyCycleError struct {
+	cycle []string
+}
+
+func (e *dependencyCycleError) Error() string {
+	return fmt.Sprintf("task dependency cycle detected: %s", strings.Join(e.cycle, " -> "))
+}
+
+// Is makes a dependency cycle match ErrTaskLoop
+func (e *dependencyCycleError) Is(target error) bool {
+	return target == ErrTaskLoop
+}
+
+// checkForDependencyCycles returns an error naming the cycle if a task eventually depends on itself
+func (r *Runner) checkForDependencyCycles(task types.Task) error {
+	return r.walkDependencies(task, nil)
+}
+
+// walkDependencies walks the tasks a task depends on or references depth first, tracking the path taken to reach it
+func (r *Runner) walkDependencies(task types.Task, path []string) error {
+	if i := slices.Index(path, task.Name); i != -1 {
+		return &dependencyCycleError{cycle: append(slices.Clone(path[i:]), task.Name)}
+	}
+	path = append(path, task.Name)
+
+	next := slices.Clone(task.DependsOn)
+	for _, action := range taskActions(task) {
+		if action.TaskReference != "" {
+			next = append(next, action.TaskReference)
+		}
+	}
+
+	for _, name := range next {
+		nextTask, err := r.getTask(name)
+		if err != nil {
+			// missing tasks are reported when they are run
+			continue
+		}
+		if err := r.walkDependencies(nextTask, path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
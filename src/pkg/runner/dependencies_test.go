@@ -0,0 +1,77 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023-Present The UDS Authors
+
+package runner
+
+import (
+	"testing"
+
+	"github.com/defenseunicorns/uds-cli/src/types"
+)
+
+func Test_checkForDependencyCycles(t *testing.T) {
+	tests := []struct {
+		name    string
+		tasks   []types.Task
+		wantErr string
+	}{
+		{
+			name: "NoCycle",
+			tasks: []types.Task{
+				{Name: "a", DependsOn: []string{"b", "c"}},
+				{Name: "b", DependsOn: []string{"c"}},
+				{Name: "c"},
+			},
+		},
+		{
+			name: "DirectCycle",
+			tasks: []types.Task{
+				{Name: "a", DependsOn: []string{"a"}},
+			},
+			wantErr: "task dependency cycle detected: a -> a",
+		},
+		{
+			name: "IndirectCycle",
+			tasks: []types.Task{
+				{Name: "a", DependsOn: []string{"b"}},
+				{Name: "b", DependsOn: []string{"c"}},
+				{Name: "c", DependsOn: []string{"a"}},
+			},
+			wantErr: "task dependency cycle detected: a -> b -> c -> a",
+		},
+		{
+			name: "CycleThroughTaskReference",
+			tasks: []types.Task{
+				{Name: "a", DependsOn: []string{"b"}},
+				{Name: "b", Actions: []types.Action{{TaskReference: "a"}}},
+			},
+			wantErr: "task dependency cycle detected: a -> b -> a",
+		},
+		{
+			name: "RepeatedTaskReference",
+			tasks: []types.Task{
+				{Name: "a", DependsOn: []string{"b"}, Actions: []types.Action{{TaskReference: "b"}, {TaskReference: "b"}}},
+				{Name: "b"},
+			},
+		},
+		{
+			name: "MissingDependencyIgnored",
+			tasks: []types.Task{
+				{Name: "a", DependsOn: []string{"missing"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := Runner{TasksFile: types.TasksFile{Tasks: tt.tasks}}
+			err := r.checkForDependencyCycles(tt.tasks[0])
+			if tt.wantErr == "" && err != nil {
+				t.Errorf("checkForDependencyCycles() unexpected error = %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || err.Error() != tt.wantErr) {
+				t.Errorf("checkForDependencyCycles() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,144 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023-Present The UDS Authors
+
+// Package runner provides functions for running tasks in a run.yaml
+package runner
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/defenseunicorns/zarf/src/pkg/message"
+	zarfUtils "github.com/defenseunicorns/zarf/src/pkg/utils"
+)
+
+// downloadRetryDelay is the delay before the first retry of a failed download, doubling with each retry
+var downloadRetryDelay = time.Second
+
+// httpStatusError is a download that failed with an unexpected HTTP status
+type httpStatusError struct {
+	status string
+	code   int
+}
+
+func (e *httpStatusError) Error() string {
+	return fmt.Sprintf("bad HTTP status: %s", e.status)
+}
+
+// downloadFile downloads src to dest, retrying up to retries times with exponential backoff. Downloads over HTTP(S)
+// that fail part way through resume from the partial dest when the server supports range requests, a corrupt resume is
+// caught by the file's shasum. Client errors (4xx other than 416 and 429) aren't retried
+func downloadFile(src, dest string, retries int) error {
+	resumable := isResumableURL(src)
+	delay := downloadRetryDelay
+	for retry := 0; ; retry++ {
+		var err error
+		if resumable {
+			err = downloadHTTP(src, dest, retry > 0)
+		} else {
+			err = zarfUtils.DownloadToFile(src, dest, "")
+		}
+		if err == nil || retry >= retries || !isTransientDownloadError(err) {
+			if err != nil && retry > 0 {
+				return fmt.Errorf("download failed after %d retries: %w", retry, err)
+			}
+			return err
+		}
+		message.Warnf("Downloading %s failed, retrying in %s (%d/%d): %s", src, delay, retry+1, retries, err)
+		time.Sleep(delay)
+		delay *= 2
+	}
+}
+
+// isResumableURL returns whether src is an HTTP(S) URL that downloadHTTP can download, URLs ending with Zarf's
+// @<shasum> suffix (or using another scheme such as sget) are downloaded by Zarf
+func isResumableURL(src string) bool {
+	parsed, err := url.Parse(src)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+		return false
+	}
+	return parsed.User == nil && !strings.Contains(src, "@")
+}
+
+// isTransientDownloadError returns true if a download failed with an error that may not happen again
+func isTransientDownloadError(err error) bool {
+	var statusErr *httpStatusError
+	if errors.As(err, &statusErr) {
+		switch statusErr.code {
+		case http.StatusTooManyRequests, http.StatusPartialContent, http.StatusRequestedRangeNotSatisfiable:
+			return true
+		}
+		return statusErr.code >= http.StatusInternalServerError
+	}
+	return true
+}
+
+// downloadHTTP downloads src to dest, resuming from the bytes already in dest when resume is set and the server
+// supports range requests
+func downloadHTTP(src, dest string, resume bool) error {
+	var offset int64
+	if resume {
+		if info, err := os.Stat(dest); err == nil && info.Mode().IsRegular() {
+			offset = info.Size()
+		}
+	}
+
+	req, err := http.NewRequest(http.MethodGet, src, nil)
+	if err != nil {
+		return err
+	}
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("unable to download the file %s: %w", src, err)
+	}
+	defer resp.Body.Close()
+
+	flags := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+	switch {
+	case resp.StatusCode == http.StatusPartialContent && offset > 0 &&
+		strings.HasPrefix(resp.Header.Get("Content-Range"), fmt.Sprintf("bytes %d-", offset)):
+		message.Debugf("Resuming the download of %s after %d bytes", src, offset)
+		flags = os.O_WRONLY | os.O_APPEND
+	case resp.StatusCode == http.StatusOK:
+		// the server sent the whole file, either because nothing was downloaded yet or because it ignored the range
+		offset = 0
+	case resp.StatusCode == http.StatusPartialContent || resp.StatusCode == http.StatusRequestedRangeNotSatisfiable:
+		// the partial file can't be resumed (e.g. the file on the server changed), start over on the next retry
+		_ = os.Remove(dest)
+		return &httpStatusError{status: resp.Status, code: resp.StatusCode}
+	default:
+		return &httpStatusError{status: resp.Status, code: resp.StatusCode}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(dest), 0700); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(dest, flags, 0600)
+	if err != nil {
+		return fmt.Errorf("unable to write file %s: %w", dest, err)
+	}
+	defer file.Close()
+
+	size := resp.ContentLength
+	if size >= 0 {
+		size += offset
+	}
+	progressBar := message.NewProgressBar(size, fmt.Sprintf("Downloading %s", filepath.Base(src)))
+	progressBar.Add(int(offset))
+	if _, err := io.Copy(file, io.TeeReader(resp.Body, progressBar)); err != nil {
+		progressBar.Stop()
+		return fmt.Errorf("unable to download the file %s: %w", src, err)
+	}
+	progressBar.Successf("Downloaded %s", src)
+	return nil
+}
@@ -0,0 +1,92 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023-Present The UDS Authors
+
+package runner
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_downloadFile(t *testing.T) {
+	downloadRetryDelay = 0
+	defer func() { downloadRetryDelay = time.Second }()
+
+	content := bytes.Repeat([]byte("0123456789"), 1000)
+	var mu sync.Mutex
+	var ranges []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		ranges = append(ranges, r.Header.Get("Range"))
+		attempt := len(ranges)
+		mu.Unlock()
+
+		switch r.URL.Path {
+		case "/flaky":
+			// drop the connection half way through the first download
+			if attempt == 1 {
+				w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+				_, _ = w.Write(content[:len(content)/2])
+				w.(http.Flusher).Flush()
+				conn, _, _ := w.(http.Hijacker).Hijack()
+				conn.Close()
+				return
+			}
+			http.ServeContent(w, r, "file", time.Time{}, bytes.NewReader(content))
+		case "/no-ranges":
+			if attempt == 1 {
+				w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+				_, _ = w.Write(content[:len(content)/2])
+				w.(http.Flusher).Flush()
+				conn, _, _ := w.(http.Hijacker).Hijack()
+				conn.Close()
+				return
+			}
+			_, _ = w.Write(content)
+		case "/unavailable":
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name       string
+		path       string
+		retries    int
+		wantErr    string
+		wantRanges []string
+	}{
+		{name: "resumes a dropped download", path: "/flaky", retries: 1, wantRanges: []string{"", "bytes=5000-"}},
+		{name: "restarts when the server ignores ranges", path: "/no-ranges", retries: 1, wantRanges: []string{"", "bytes=5000-"}},
+		{name: "fails without retries", path: "/flaky", wantErr: "unable to download", wantRanges: []string{""}},
+		{name: "retries server errors", path: "/unavailable", retries: 2, wantErr: "after 2 retries", wantRanges: []string{"", "", ""}},
+		{name: "doesn't retry client errors", path: "/missing", retries: 2, wantErr: "404", wantRanges: []string{""}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ranges = nil
+			dest := filepath.Join(t.TempDir(), "file")
+			err := downloadFile(server.URL+tt.path, dest, tt.retries)
+			require.Equal(t, tt.wantRanges, ranges)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			downloaded, err := os.ReadFile(dest)
+			require.NoError(t, err)
+			require.Equal(t, content, downloaded)
+		})
+	}
+}
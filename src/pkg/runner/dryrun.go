@@ -0,0 +1,122 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023-Present The UDS Authors
+
+// Package runner provides functions for running tasks in a run.yaml
+package runner
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/defenseunicorns/zarf/src/pkg/message"
+	zarfUtils "github.com/defenseunicorns/zarf/src/pkg/utils"
+	"github.com/defenseunicorns/zarf/src/pkg/utils/helpers"
+
+	"github.com/defenseunicorns/uds-cli/src/types"
+)
+
+// planStep records a step of a dry run
+func (r *Runner) planStep(format string, a ...any) {
+	step := r.mask(fmt.Sprintf(format, a...))
+
+	r.outputMu.Lock()
+	defer r.outputMu.Unlock()
+	r.plan = append(r.plan, step)
+}
+
+// planZarfAction records the resolved command or wait of an action instead of running it, setting its
+// variables to placeholder values
+func (r *Runner) planZarfAction(ctx context.Context, action types.Action) error {
+	var step string
+	switch {
+	case action.Wait != nil && action.Wait.File != nil:
+		condition := action.Wait.File.Condition
+		if condition == "" {
+			condition = types.WaitFileExists
+		}
+		step = fmt.Sprintf("wait for file %s (%s)", r.templateString(ctx, action.Wait.File.Path), condition)
+	case action.Wait != nil && action.Wait.Command != nil:
+		step = fmt.Sprintf("wait for command to succeed: %s", r.templateString(ctx, action.Wait.Command.Cmd))
+	case action.Wait != nil:
+		timeout := 300
+		if action.MaxTotalSeconds != nil {
+			timeout = *action.MaxTotalSeconds
+		}
+		cmd, err := convertWaitToCmd(r.templateWait(ctx, action.Wait.ZarfComponentActionWait), &timeout)
+		if err != nil {
+			return err
+		}
+		step = fmt.Sprintf("wait: %s", cmd)
+	case action.HTTP != nil:
+		request := r.templateHTTPRequest(ctx, *action.HTTP)
+		step = fmt.Sprintf("http: %s", httpRequestName(request))
+		if request.StatusCode != 0 {
+			step = fmt.Sprintf("%s (expecting status %d)", step, request.StatusCode)
+		}
+	default:
+		step = fmt.Sprintf("run: %s", strings.TrimSpace(r.templateString(ctx, action.Cmd)))
+	}
+
+	if action.Dir != nil && *action.Dir != "" && *action.Dir != "." {
+		step = fmt.Sprintf("%s (in %s)", step, *action.Dir)
+	}
+	if len(action.Env) > 0 {
+		step = fmt.Sprintf("%s with env %s", step, strings.Join(r.templateEnv(ctx, action.Env), " "))
+	}
+	if action.OutputFile != "" && action.Wait == nil && action.HTTP == nil {
+		step = fmt.Sprintf("%s with output to %s", step, r.templateString(ctx, action.OutputFile))
+	}
+	r.planStep("%s", step)
+
+	for _, v := range action.SetVariables {
+		r.setVariable("${"+v.Name+"}", &zarfUtils.TextTemplate{Value: fmt.Sprintf("<%s>", v.Name)})
+		stream := "output"
+		if v.Stream != "" {
+			stream = v.Stream
+		}
+		r.planStep("set variable %s from the %s of the command", v.Name, stream)
+	}
+	return nil
+}
+
+// planFiles records the file operations of a task instead of performing them
+func (r *Runner) planFiles(ctx context.Context, files []types.File, dir string) {
+	if dir == "" {
+		dir = "."
+	}
+	for _, file := range files {
+		src := r.templateString(ctx, file.Source)
+		target := r.templateString(ctx, file.Target)
+		if file.Content != "" {
+			r.planStep("write %d byte(s) of content to %s (in %s)", len(file.Content), target, dir)
+		} else if helpers.IsURL(src) {
+			r.planStep("download %s to %s (in %s)", src, target, dir)
+		} else {
+			r.planStep("copy %s to %s (in %s)", src, target, dir)
+		}
+		if file.ExtractPath != "" && file.ArchiveFormat != "" {
+			r.planStep("extract %s from %s (as %s)", file.ExtractPath, target, file.ArchiveFormat)
+		} else if file.ExtractPath != "" {
+			r.planStep("extract %s from %s", file.ExtractPath, target)
+		}
+		if file.Mode != "" {
+			r.planStep("set the mode of %s to %s", target, file.Mode)
+		}
+		for _, link := range file.Symlinks {
+			r.planStep("symlink %s to %s", r.templateString(ctx, link), target)
+		}
+	}
+}
+
+// printPlan prints the steps of a dry run in the order they would run
+func (r *Runner) printPlan(taskName string) {
+	message.HeaderInfof("Dry run of task %s", taskName)
+	if len(r.plan) == 0 {
+		message.Info("Nothing to do")
+		return
+	}
+	for i, step := range r.plan {
+		message.Infof("%d. %s", i+1, step)
+	}
+}
@@ -0,0 +1,57 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023-Present The UDS Authors
+
+// Package runner provides functions for running tasks in a run.yaml
+package runner
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	zarfTypes "github.com/defenseunicorns/zarf/src/types"
+	"golang.org/x/exp/maps"
+
+	"github.com/defenseunicorns/uds-cli/src/types"
+)
+
+// withTaskEnv returns a copy of a cmd or wait action whose env starts with the task's env; the action's own env comes
+// after it so that it takes precedence
+func withTaskEnv(action types.Action, env map[string]string) types.Action {
+	if len(env) == 0 || (action.ZarfComponentAction == nil && action.Wait == nil) {
+		return action
+	}
+
+	// copy the Zarf action so the task's actions aren't modified
+	var zarfAction zarfTypes.ZarfComponentAction
+	if action.ZarfComponentAction != nil {
+		zarfAction = *action.ZarfComponentAction
+	}
+
+	names := maps.Keys(env)
+	slices.Sort(names)
+	taskEnv := make([]string, 0, len(env)+len(zarfAction.Env))
+	for _, name := range names {
+		taskEnv = append(taskEnv, fmt.Sprintf("%s=%s", name, env[name]))
+	}
+	zarfAction.Env = append(taskEnv, zarfAction.Env...)
+
+	action.ZarfComponentAction = &zarfAction
+	return action
+}
+
+// templateEnv returns a copy of KEY=VALUE env entries with the variables in their values templated, it's done when
+// the action runs so that the env can use the variables set by earlier actions
+func (r *Runner) templateEnv(ctx context.Context, env []string) []string {
+	templated := make([]string, 0, len(env))
+	for _, entry := range env {
+		name, value, found := strings.Cut(entry, "=")
+		if !found {
+			templated = append(templated, entry)
+			continue
+		}
+		templated = append(templated, fmt.Sprintf("%s=%s", name, r.templateString(ctx, value)))
+	}
+	return templated
+}
@@ -0,0 +1,45 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023-Present The UDS Authors
+
+package runner
+
+import (
+	"context"
+	"testing"
+
+	zarfUtils "github.com/defenseunicorns/zarf/src/pkg/utils"
+	zarfTypes "github.com/defenseunicorns/zarf/src/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/defenseunicorns/uds-cli/src/types"
+)
+
+func Test_withTaskEnv(t *testing.T) {
+	env := map[string]string{"TOKEN": "${API_TOKEN}", "REGION": "us-east-1"}
+
+	action := types.Action{ZarfComponentAction: &zarfTypes.ZarfComponentAction{Cmd: "deploy", Env: []string{"REGION=us-west-2"}}}
+	withEnv := withTaskEnv(action, env)
+	require.Equal(t, []string{"REGION=us-east-1", "TOKEN=${API_TOKEN}", "REGION=us-west-2"}, withEnv.Env)
+	require.Equal(t, []string{"REGION=us-west-2"}, action.Env, "the task's action must not be modified")
+
+	wait := types.Action{Wait: &types.Wait{Command: &types.WaitCommand{Cmd: "true"}}}
+	require.Equal(t, []string{"REGION=us-east-1", "TOKEN=${API_TOKEN}"}, withTaskEnv(wait, env).Env)
+
+	reference := types.Action{TaskReference: "other"}
+	require.Equal(t, reference, withTaskEnv(reference, env))
+}
+
+func Test_templateEnv(t *testing.T) {
+	r := newTestRunner(map[string]*zarfUtils.TextTemplate{
+		"${API_TOKEN}": {Value: "secret", Sensitive: true},
+		"${REGION}":    {Value: "us-east-1"},
+	})
+
+	env := []string{"TOKEN=${API_TOKEN}", "URL=https://${REGION}.example.com?a=b", "UNSET=${UNSET}", "NO_VALUE"}
+	require.Equal(t, []string{"TOKEN=secret", "URL=https://us-east-1.example.com?a=b", "UNSET=${UNSET}", "NO_VALUE"}, r.templateEnv(context.Background(), env))
+
+	// sensitive values in the env are masked in the plan of a dry run
+	action := types.Action{ZarfComponentAction: &zarfTypes.ZarfComponentAction{Cmd: "deploy", Env: env[:1]}}
+	require.NoError(t, r.planZarfAction(context.Background(), action))
+	require.Equal(t, []string{"run: deploy with env TOKEN=****"}, r.plan)
+}
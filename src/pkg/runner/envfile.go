@@ -0,0 +1,50 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023-Present The UDS Authors
+
+// Package runner provides functions for running tasks in a run.yaml
+package runner
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	zarfUtils "github.com/defenseunicorns/zarf/src/pkg/utils"
+	"github.com/subosito/gotenv"
+
+	"github.com/defenseunicorns/uds-cli/src/config"
+)
+
+// loadEnvFile merges the KEY=VALUE pairs of a dotenv file into the template map
+//
+// env file values override variable defaults but not the variables set with --set
+func (r *Runner) loadEnvFile(ctx context.Context, envFile string) error {
+	if envFile == "" {
+		return nil
+	}
+
+	path := r.templateString(ctx, envFile)
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(filepath.Dir(config.TaskFileLocation), path)
+	}
+
+	env, err := gotenv.Read(path)
+	if err != nil {
+		return fmt.Errorf("unable to read env file %s: %w", path, err)
+	}
+
+	for name, value := range env {
+		if _, ok := r.setVariables[name]; ok {
+			continue
+		}
+		// keep how an existing variable is templated (e.g. whether it is sensitive) and only replace its value
+		key := fmt.Sprintf("${%s}", name)
+		template := zarfUtils.TextTemplate{}
+		if existing, ok := r.getVariable(key); ok {
+			template = *existing
+		}
+		template.Value = value
+		r.setVariable(key, &template)
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023-Present The UDS Authors
+
+// Package runner provides functions for running tasks in a run.yaml
+package runner
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrTaskNotFound matches (with errors.Is) the errors for a task name that isn't in the tasks file
+	ErrTaskNotFound = errors.New("task not found")
+	// ErrTaskLoop is returned when a task eventually references itself, and matches dependency cycles
+	ErrTaskLoop = errors.New("task loop detected")
+	// ErrActionTimeout matches (with errors.Is) the errors for an action that ran out of its maxTotalSeconds
+	ErrActionTimeout = errors.New("action timed out")
+	// ErrActionFailed matches (with errors.Is) the errors for an action that failed all of its attempts
+	ErrActionFailed = errors.New("action failed")
+)
+
+// TaskNotFoundError is returned when a task name isn't in the tasks file
+type TaskNotFoundError struct {
+	Name string
+}
+
+func (e *TaskNotFoundError) Error() string {
+	return fmt.Sprintf("task name %s not found", e.Name)
+}
+
+// Is makes a *TaskNotFoundError match ErrTaskNotFound
+func (e *TaskNotFoundError) Is(target error) bool {
+	return target == ErrTaskNotFound
+}
+
+// ActionError is returned when an action times out or exhausts its retries, wrapping the error of its last attempt
+type ActionError struct {
+	// Action is the description of the action (or its command)
+	Action string
+	// Attempts is the number of times the action was tried
+	Attempts int
+	// Retries is the maxRetries of the action
+	Retries int
+	// TimedOut is set when the action ran out of its maxTotalSeconds rather than its retries
+	TimedOut bool
+	// MaxTotalSeconds is the timeout of the action
+	MaxTotalSeconds int
+	// Err is the error of the last attempt, if any
+	Err error
+}
+
+func (e *ActionError) Error() string {
+	msg := fmt.Sprintf("command \"%s\" failed after %d retries", e.Action, e.Retries)
+	if e.TimedOut {
+		msg = fmt.Sprintf("command \"%s\" timed out after %d seconds", e.Action, e.MaxTotalSeconds)
+	}
+	if e.Err != nil {
+		msg = fmt.Sprintf("%s: %s", msg, e.Err.Error())
+	}
+	return msg
+}
+
+// Unwrap returns the error of the action's last attempt
+func (e *ActionError) Unwrap() error {
+	return e.Err
+}
+
+// Is makes an *ActionError match ErrActionTimeout or ErrActionFailed
+func (e *ActionError) Is(target error) bool {
+	if e.TimedOut {
+		return target == ErrActionTimeout
+	}
+	return target == ErrActionFailed
+}
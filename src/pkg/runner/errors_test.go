@@ -0,0 +1,52 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023-Present The UDS Authors
+
+package runner
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	zarfTypes "github.com/defenseunicorns/zarf/src/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/defenseunicorns/uds-cli/src/types"
+)
+
+func Test_runnerErrors(t *testing.T) {
+	r := newTestRunner(nil)
+	r.TasksFile = types.TasksFile{Tasks: []types.Task{
+		{Name: "a", Actions: []types.Action{{TaskReference: "b"}}},
+		{Name: "b", Actions: []types.Action{{TaskReference: "a"}}},
+	}}
+
+	_, err := r.getTask("missing")
+	require.ErrorIs(t, err, ErrTaskNotFound)
+	require.EqualError(t, err, "task name missing not found")
+
+	require.ErrorIs(t, r.checkForTaskLoops(r.TasksFile.Tasks[0]), ErrTaskLoop)
+	require.ErrorIs(t, r.checkForDependencyCycles(r.TasksFile.Tasks[0]), ErrTaskLoop)
+
+	maxRetries := 2
+	var retries int
+	err = r.runZarfAction(context.Background(), types.Action{ZarfComponentAction: &zarfTypes.ZarfComponentAction{
+		Cmd: "exit 3", MaxRetries: &maxRetries,
+	}}, false, &retries)
+	require.ErrorIs(t, err, ErrActionFailed)
+	require.NotErrorIs(t, err, ErrActionTimeout)
+	var actionErr *ActionError
+	require.True(t, errors.As(err, &actionErr))
+	require.Equal(t, "exit 3", actionErr.Action)
+	require.Equal(t, 3, actionErr.Attempts)
+	require.ErrorContains(t, err, "command \"exit 3\" failed after 2 retries: exit status 3")
+
+	maxTotalSeconds := 1
+	err = r.runZarfAction(context.Background(), types.Action{ZarfComponentAction: &zarfTypes.ZarfComponentAction{
+		Cmd: "sleep 5", MaxTotalSeconds: &maxTotalSeconds,
+	}}, false, &retries)
+	require.ErrorIs(t, err, ErrActionTimeout)
+	require.True(t, errors.As(err, &actionErr))
+	require.Equal(t, 1, actionErr.Attempts)
+	require.ErrorContains(t, err, "timed out after 1 seconds")
+}
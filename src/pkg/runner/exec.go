@@ -0,0 +1,193 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023-Present The UDS Authors
+
+// Package runner provides functions for running tasks in a run.yaml
+package runner
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	osExec "os/exec"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/defenseunicorns/zarf/src/pkg/utils/exec"
+
+	"github.com/defenseunicorns/uds-cli/src/types"
+)
+
+// cancelWaitDelay is how long a canceled command gets to release its output before it is abandoned
+const cancelWaitDelay = 5 * time.Second
+
+// defaultMaxOutputBytes is how much of a command's stdout (and of its stderr) is kept in memory by default
+const defaultMaxOutputBytes = 4 << 20
+
+// outputLimit bounds how much of a command's output runCommand keeps in memory, a maxBytes below 1 means no limit
+type outputLimit struct {
+	maxBytes int
+	// kill kills the command once its output exceeds maxBytes instead of letting it complete
+	kill bool
+}
+
+// newOutputLimit validates the output limit fields of an action
+func newOutputLimit(action types.Action) (outputLimit, error) {
+	limit := outputLimit{maxBytes: defaultMaxOutputBytes}
+	switch {
+	case action.MaxOutputBytes == -1:
+		limit.maxBytes = 0
+	case action.MaxOutputBytes > 0:
+		limit.maxBytes = action.MaxOutputBytes
+	case action.MaxOutputBytes < 0:
+		return limit, fmt.Errorf("invalid maxOutputBytes %d, must be a positive number of bytes or -1 for no limit", action.MaxOutputBytes)
+	}
+
+	switch action.OnMaxOutput {
+	case "", types.OnMaxOutputTruncate:
+	case types.OnMaxOutputKill:
+		limit.kill = true
+	default:
+		return limit, fmt.Errorf("invalid onMaxOutput %q, must be %s or %s", action.OnMaxOutput, types.OnMaxOutputTruncate, types.OnMaxOutputKill)
+	}
+	return limit, nil
+}
+
+// truncatedOutput marks where captured output that exceeded its limit was cut off
+func truncatedOutput(maxBytes int) string {
+	return fmt.Sprintf("\n[output truncated after %d bytes]", maxBytes)
+}
+
+// cappedBuffer keeps the first max bytes written to it (all of them when max is below 1), discarding the rest while
+// still reporting them as written so the command isn't interrupted
+type cappedBuffer struct {
+	buf       bytes.Buffer
+	max       int
+	truncated bool
+	// onTruncate is called the first time a write exceeds max
+	onTruncate func()
+}
+
+func (b *cappedBuffer) Write(p []byte) (int, error) {
+	if b.max < 1 || b.buf.Len()+len(p) <= b.max {
+		return b.buf.Write(p)
+	}
+	b.buf.Write(p[:b.max-b.buf.Len()])
+	if !b.truncated {
+		b.truncated = true
+		if b.onTruncate != nil {
+			b.onTruncate()
+		}
+	}
+	return len(p), nil
+}
+
+// String returns the kept output, marked as truncated if anything was discarded
+func (b *cappedBuffer) String() string {
+	if b.truncated {
+		return b.buf.String() + truncatedOutput(b.max)
+	}
+	return b.buf.String()
+}
+
+// commandOutput is the output of a command, keeping its stdout and stderr apart
+type commandOutput struct {
+	stdout string
+	stderr string
+	// combined interleaves stdout and stderr in the order they were read (writes made at nearly the same time to both
+	// streams can be swapped since they're read from separate pipes), it is only kept when asked for
+	combined string
+}
+
+// stream returns the output of one of the streams of the command (stdout when stream is empty)
+func (o commandOutput) stream(stream string) (string, error) {
+	switch stream {
+	case "", types.StreamStdout:
+		return o.stdout, nil
+	case types.StreamStderr:
+		return o.stderr, nil
+	case types.StreamCombined:
+		return o.combined, nil
+	}
+	return "", fmt.Errorf("invalid stream %q, must be %s, %s or %s", stream, types.StreamStdout, types.StreamStderr, types.StreamCombined)
+}
+
+// lockedWriter serializes the writes of the stdout and stderr of a command to the same writer
+type lockedWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (l *lockedWriter) Write(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.w.Write(p)
+}
+
+// runCommand runs a command like Zarf's exec.CmdWithContext, but when the context is done it kills the command's
+// whole process tree, so commands started by the shell don't keep the action (and the run) alive. Only the first
+// limit.maxBytes of its stdout and of its stderr are returned (and both interleaved when combined is set), with
+// truncated reporting whether any was discarded
+func runCommand(ctx context.Context, config exec.Config, limit outputLimit, combined bool, command string, args ...string) (output commandOutput, truncated bool, err error) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	cmd := osExec.CommandContext(ctx, command, args...)
+	cmd.Dir = config.Dir
+	cmd.Env = append(os.Environ(), config.Env...)
+	cmd.WaitDelay = cancelWaitDelay
+	killProcessTree(cmd)
+
+	errOutputLimit := fmt.Errorf("command output exceeded %d bytes", limit.maxBytes)
+	var onTruncate func()
+	if limit.kill {
+		onTruncate = func() { cancel(errOutputLimit) }
+	}
+	stdoutBuf := &cappedBuffer{max: limit.maxBytes, onTruncate: onTruncate}
+	stderrBuf := &cappedBuffer{max: limit.maxBytes, onTruncate: onTruncate}
+	stdout := []io.Writer{stdoutBuf}
+	stderr := []io.Writer{stderrBuf}
+	// the combined output holds what's kept of both streams, so it doesn't enforce the limit itself
+	combinedBuf := &cappedBuffer{max: 2 * limit.maxBytes}
+	if combined {
+		combinedWriter := &lockedWriter{w: combinedBuf}
+		stdout = append(stdout, combinedWriter)
+		stderr = append(stderr, combinedWriter)
+	}
+	if config.Stdout != nil {
+		stdout = append(stdout, config.Stdout)
+	}
+	if config.Stderr != nil {
+		stderr = append(stderr, config.Stderr)
+	}
+	cmd.Stdout = io.MultiWriter(stdout...)
+	cmd.Stderr = io.MultiWriter(stderr...)
+
+	err = cmd.Run()
+	if errors.Is(context.Cause(ctx), errOutputLimit) {
+		err = fmt.Errorf("%w and the command was killed", errOutputLimit)
+	}
+	output = commandOutput{stdout: stdoutBuf.String(), stderr: stderrBuf.String()}
+	if combined {
+		output.combined = combinedBuf.String()
+	}
+	return output, stdoutBuf.truncated || stderrBuf.truncated || combinedBuf.truncated, err
+}
+
+// createOutputFile creates (or truncates) the file an action streams its output to, relative to the action's dir
+func createOutputFile(path string, dir string) (*os.File, error) {
+	if !filepath.IsAbs(path) && dir != "" {
+		path = filepath.Join(dir, path)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, err
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create output file %s: %w", path, err)
+	}
+	return file, nil
+}
@@ -0,0 +1,20 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023-Present The UDS Authors
+
+//go:build !windows
+
+// Package runner provides functions for running tasks in a run.yaml
+package runner
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// killProcessTree starts the command in its own process group and kills the whole group when it is canceled
+func killProcessTree(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Cancel = func() error {
+		return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+	}
+}
@@ -0,0 +1,14 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023-Present The UDS Authors
+
+//go:build windows
+
+// Package runner provides functions for running tasks in a run.yaml
+package runner
+
+import (
+	"os/exec"
+)
+
+// killProcessTree is a no-op on Windows, where canceling a command only kills the command itself
+func killProcessTree(_ *exec.Cmd) {}
@@ -0,0 +1,238 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023-Present The UDS Authors
+
+// Package runner provides functions for running tasks in a run.yaml
+package runner
+
+import (
+	"archive/tar"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/defenseunicorns/zarf/src/config/lang"
+	"github.com/klauspost/compress/zip"
+	"github.com/mholt/archiver/v3"
+	"golang.org/x/exp/maps"
+)
+
+// archiveExtractor extracts (or walks) the entries of an archive of a given format
+type archiveExtractor interface {
+	archiver.Extractor
+	archiver.Walker
+}
+
+// archiveFormats returns an extractor for each archiveFormat a file can set
+var archiveFormats = map[string]func() archiveExtractor{
+	"tar":     func() archiveExtractor { return archiver.NewTar() },
+	"tar.gz":  func() archiveExtractor { return archiver.NewTarGz() },
+	"tar.zst": func() archiveExtractor { return archiver.NewTarZstd() },
+	"tar.bz2": func() archiveExtractor { return archiver.NewTarBz2() },
+	"tar.xz":  func() archiveExtractor { return archiver.NewTarXz() },
+	"zip":     func() archiveExtractor { return archiver.NewZip() },
+}
+
+// archiveExtensions maps the file extensions of the archive formats to their archiveFormat, longest first
+var archiveExtensions = []struct{ ext, format string }{
+	{".tar.gz", "tar.gz"}, {".tar.zst", "tar.zst"}, {".tar.bz2", "tar.bz2"}, {".tar.xz", "tar.xz"},
+	{".tgz", "tar.gz"}, {".tzst", "tar.zst"}, {".tbz2", "tar.bz2"}, {".txz", "tar.xz"},
+	{".tar", "tar"}, {".zip", "zip"},
+}
+
+// archiveSignatures are the magic bytes an archive of each format starts with (or, for tar, has at offset 257).
+// Compressed archives are assumed to be compressed tarballs
+var archiveSignatures = []struct {
+	format string
+	offset int
+	magic  []byte
+}{
+	{"zip", 0, []byte("PK\x03\x04")},
+	{"tar.gz", 0, []byte{0x1f, 0x8b}},
+	{"tar.zst", 0, []byte{0x28, 0xb5, 0x2f, 0xfd}},
+	{"tar.bz2", 0, []byte("BZh")},
+	{"tar.xz", 0, []byte{0xfd, '7', 'z', 'X', 'Z', 0x00}},
+	{"tar", 257, []byte("ustar")},
+}
+
+// archiveFormatNames returns the supported archiveFormats, sorted
+func archiveFormatNames() []string {
+	names := maps.Keys(archiveFormats)
+	slices.Sort(names)
+	return names
+}
+
+// validateArchiveFormat returns an error if format isn't empty or a supported archiveFormat
+func validateArchiveFormat(format string) error {
+	if _, ok := archiveFormats[format]; format != "" && !ok {
+		return fmt.Errorf("invalid archiveFormat %q, must be one of %s", format, strings.Join(archiveFormatNames(), ", "))
+	}
+	return nil
+}
+
+// detectArchiveFormat returns the format of an archive according to its content, or "" if it isn't recognized
+func detectArchiveFormat(archive string) string {
+	f, err := os.Open(archive)
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+	header := make([]byte, 262)
+	n, _ := io.ReadFull(f, header)
+	header = header[:n]
+	for _, sig := range archiveSignatures {
+		if len(header) >= sig.offset+len(sig.magic) && bytes.Equal(header[sig.offset:sig.offset+len(sig.magic)], sig.magic) {
+			return sig.format
+		}
+	}
+	return ""
+}
+
+// newArchiveExtractor returns the extractor for an archive in format, or in the format given by its extension or
+// detected from its content when format is empty, along with the name of the format ("" if it isn't an archiveFormat)
+func newArchiveExtractor(archive, format string) (archiveExtractor, string, error) {
+	if err := validateArchiveFormat(format); err != nil {
+		return nil, "", err
+	}
+	if format == "" {
+		name := strings.ToLower(filepath.Base(archive))
+		for _, e := range archiveExtensions {
+			if strings.HasSuffix(name, e.ext) {
+				format = e.format
+				break
+			}
+		}
+	}
+	if format != "" {
+		return archiveFormats[format](), format, nil
+	}
+
+	// other formats archiver supports (e.g. rar) are only recognized by their extension
+	if byExt, err := archiver.ByExtension(archive); err == nil {
+		if extractor, ok := byExt.(archiveExtractor); ok {
+			return extractor, "", nil
+		}
+	}
+	if format = detectArchiveFormat(archive); format != "" {
+		return archiveFormats[format](), format, nil
+	}
+	return nil, "", fmt.Errorf("unable to tell the format of archive %s from its name or content, set its archiveFormat to one of %s",
+		archive, strings.Join(archiveFormatNames(), ", "))
+}
+
+// archiveFormatError adds a hint to an error extracting an archive as format when its content says it has another
+func archiveFormatError(archive, format string, err error) error {
+	if detected := detectArchiveFormat(archive); detected != "" && format != "" && detected != format {
+		return fmt.Errorf("%w (it looks like a %s archive rather than %s, set its archiveFormat to %s)", err, detected, format, detected)
+	}
+	return err
+}
+
+// isGlob returns true if an extractPath is a glob rather than a path inside the archive
+func isGlob(extractPath string) bool {
+	return strings.ContainsAny(extractPath, "*?[")
+}
+
+// extractFiles extracts the entries of archive that match extractPath into destDir, returning the paths they were
+// extracted to. The archive is read as format, or as the format given by its name or content when format is empty
+//
+// extractPath is either a path inside the archive (a file, or a dir that is extracted with its contents) or a glob
+// matched against the paths of the files in the archive, in which case only the matching files are extracted; either
+// way the entries keep their path inside the archive, relative to destDir
+func extractFiles(archive, extractPath, destDir, format string) ([]string, error) {
+	extractor, format, err := newArchiveExtractor(archive, format)
+	if err != nil {
+		return nil, err
+	}
+
+	// archiver's zip Extract nests the entries it extracts in a directory named after the path, so a path is only
+	// extracted with it for the other formats and zip archives are walked like for a glob
+	if !isGlob(extractPath) && format != "zip" {
+		extracted := filepath.Join(destDir, filepath.FromSlash(extractPath))
+		_ = os.RemoveAll(extracted)
+		if err := extractor.Extract(archive, extractPath, destDir); err != nil {
+			return nil, archiveFormatError(archive, format, fmt.Errorf(lang.ErrFileExtract, extractPath, archive, err.Error()))
+		}
+		return []string{extracted}, nil
+	}
+
+	pattern := path.Clean(extractPath)
+	match := func(name string) bool {
+		return name == pattern || strings.HasPrefix(name, pattern+"/")
+	}
+	if isGlob(extractPath) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid extractPath glob %q: %w", extractPath, err)
+		}
+		match = func(name string) bool {
+			matched, _ := path.Match(pattern, name)
+			return matched
+		}
+	} else {
+		_ = os.RemoveAll(filepath.Join(destDir, filepath.FromSlash(pattern)))
+	}
+
+	var extracted []string
+	err = extractor.Walk(archive, func(f archiver.File) error {
+		if f.IsDir() {
+			return nil
+		}
+		name := path.Clean(strings.TrimPrefix(archiveEntryName(f), "./"))
+		if !match(name) {
+			return nil
+		}
+		target, err := extractFile(f, name, destDir)
+		if err != nil {
+			return err
+		}
+		extracted = append(extracted, target)
+		return nil
+	})
+	if err != nil {
+		return nil, archiveFormatError(archive, format, fmt.Errorf(lang.ErrFileExtract, extractPath, archive, err.Error()))
+	}
+	if len(extracted) == 0 {
+		return nil, fmt.Errorf("extractPath %q doesn't match any file in archive %s", extractPath, archive)
+	}
+	if !isGlob(extractPath) {
+		return []string{filepath.Join(destDir, filepath.FromSlash(pattern))}, nil
+	}
+	return extracted, nil
+}
+
+// archiveEntryName returns the path of an entry inside its archive, falling back to its base name for archive
+// formats that don't expose it
+func archiveEntryName(f archiver.File) string {
+	switch header := f.Header.(type) {
+	case *tar.Header:
+		return header.Name
+	case zip.FileHeader:
+		return header.Name
+	}
+	return f.Name()
+}
+
+// extractFile writes an archive entry to its path inside the archive, relative to destDir
+func extractFile(f archiver.File, name, destDir string) (string, error) {
+	target := filepath.Join(destDir, filepath.FromSlash(name))
+	if rel, err := filepath.Rel(destDir, target); err != nil || strings.HasPrefix(rel, "..") {
+		return "", fmt.Errorf("archive entry %s is outside of the extraction directory", name)
+	}
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return "", err
+	}
+
+	out, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, f.Mode().Perm())
+	if err != nil {
+		return "", err
+	}
+	defer out.Close()
+	if _, err := io.Copy(out, f); err != nil {
+		return "", fmt.Errorf("extracting file %s: %w", name, err)
+	}
+	return target, nil
+}
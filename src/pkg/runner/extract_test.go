@@ -0,0 +1,155 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023-Present The UDS Authors
+
+package runner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mholt/archiver/v3"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_extractFiles(t *testing.T) {
+	src := t.TempDir()
+	for name, content := range map[string]string{
+		"pkg/bin/tool":  "tool",
+		"pkg/bin/other": "other",
+		"pkg/README.md": "readme",
+	} {
+		path := filepath.Join(src, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+
+	for _, format := range archiveFormatNames() {
+		t.Run(format, func(t *testing.T) {
+			archive := filepath.Join(t.TempDir(), "pkg."+format)
+			require.NoError(t, archiver.Archive([]string{filepath.Join(src, "pkg")}, archive))
+
+			tests := []struct {
+				name        string
+				extractPath string
+				want        []string
+				wantErr     string
+			}{
+				{name: "Glob", extractPath: "pkg/bin/t*", want: []string{"pkg/bin/tool"}},
+				{name: "GlobMultiple", extractPath: "pkg/bin/*", want: []string{"pkg/bin/other", "pkg/bin/tool"}},
+				{name: "GlobNoMatch", extractPath: "pkg/*.txt", wantErr: "doesn't match any file"},
+				{name: "InvalidGlob", extractPath: "pkg/[", wantErr: "invalid extractPath glob"},
+			}
+			for _, tt := range tests {
+				t.Run(tt.name, func(t *testing.T) {
+					dest := t.TempDir()
+					extracted, err := extractFiles(archive, tt.extractPath, dest, "")
+					if tt.wantErr != "" {
+						require.ErrorContains(t, err, tt.wantErr)
+						return
+					}
+					require.NoError(t, err)
+
+					var want []string
+					for _, name := range tt.want {
+						want = append(want, filepath.Join(dest, name))
+					}
+					require.ElementsMatch(t, want, extracted)
+					for _, path := range extracted {
+						require.FileExists(t, path)
+					}
+					require.NoFileExists(t, filepath.Join(dest, "pkg/README.md"))
+				})
+			}
+		})
+	}
+
+	for _, format := range []string{"tar.gz", "zip"} {
+		t.Run("Path/"+format, func(t *testing.T) {
+			archive := filepath.Join(t.TempDir(), "pkg."+format)
+			require.NoError(t, archiver.Archive([]string{filepath.Join(src, "pkg")}, archive))
+
+			dest := t.TempDir()
+			extracted, err := extractFiles(archive, "pkg/bin/tool", dest, "")
+			require.NoError(t, err)
+			require.Equal(t, []string{filepath.Join(dest, "pkg/bin/tool")}, extracted)
+			require.FileExists(t, extracted[0])
+			require.NoFileExists(t, filepath.Join(dest, "pkg/bin/other"))
+		})
+	}
+}
+
+func Test_extractFilesFormat(t *testing.T) {
+	src := filepath.Join(t.TempDir(), "pkg")
+	require.NoError(t, os.MkdirAll(src, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(src, "tool"), []byte("tool"), 0644))
+
+	// archives named without an extension, or with the wrong one
+	archives := map[string]string{}
+	for _, format := range archiveFormatNames() {
+		archive := filepath.Join(t.TempDir(), "pkg."+format)
+		require.NoError(t, archiver.Archive([]string{src}, archive))
+		archives[format] = archive
+		renamed := filepath.Join(t.TempDir(), "artifact")
+		require.NoError(t, os.Rename(archive, renamed))
+		archives[format] = renamed
+	}
+	misnamed := filepath.Join(t.TempDir(), "artifact.zip")
+	content, err := os.ReadFile(archives["tar.gz"])
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(misnamed, content, 0644))
+	notArchive := filepath.Join(t.TempDir(), "artifact")
+	require.NoError(t, os.WriteFile(notArchive, []byte("not an archive"), 0644))
+
+	for format, archive := range archives {
+		t.Run(format, func(t *testing.T) {
+			for _, archiveFormat := range []string{"", format} {
+				dest := t.TempDir()
+				extracted, err := extractFiles(archive, "pkg/tool", dest, archiveFormat)
+				require.NoError(t, err)
+				require.Equal(t, []string{filepath.Join(dest, "pkg/tool")}, extracted)
+				require.FileExists(t, extracted[0])
+			}
+		})
+	}
+
+	tests := []struct {
+		name          string
+		archive       string
+		archiveFormat string
+		wantErr       string
+	}{
+		{
+			name:    "MisleadingExtension",
+			archive: misnamed,
+			wantErr: "it looks like a tar.gz archive rather than zip, set its archiveFormat to tar.gz",
+		},
+		{
+			name:          "WrongFormat",
+			archive:       archives["zip"],
+			archiveFormat: "tar.zst",
+			wantErr:       "it looks like a zip archive rather than tar.zst",
+		},
+		{
+			name:          "InvalidFormat",
+			archive:       archives["zip"],
+			archiveFormat: "rar",
+			wantErr:       `invalid archiveFormat "rar", must be one of tar, tar.bz2, tar.gz, tar.xz, tar.zst, zip`,
+		},
+		{
+			name:    "UnknownFormat",
+			archive: notArchive,
+			wantErr: "unable to tell the format of archive",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := extractFiles(tt.archive, "pkg/tool", t.TempDir(), tt.archiveFormat)
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+
+	// the misnamed archive extracts once its format is set
+	_, err = extractFiles(misnamed, "pkg/tool", t.TempDir(), "tar.gz")
+	require.NoError(t, err)
+}
@@ -0,0 +1,42 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023-Present The UDS Authors
+
+// Package runner provides functions for running tasks in a run.yaml
+package runner
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/defenseunicorns/zarf/src/pkg/message"
+
+	"github.com/defenseunicorns/uds-cli/src/types"
+)
+
+// taskActions returns the actions of a task followed by its finally actions
+func taskActions(task types.Task) []types.Action {
+	return append(append([]types.Action{}, task.Actions...), task.Finally...)
+}
+
+// executeFinally runs the finally actions of a task once its actions are done, whether or not they succeeded. Every
+// finally action runs even if an earlier one fails, and a failing finally action only becomes the task's error when
+// the task itself succeeded so that it doesn't mask the original error
+func (r *Runner) executeFinally(ctx context.Context, task types.Task, dir string, buffered bool, taskErr error) error {
+	// clean up even when the run was canceled or timed out
+	ctx = context.WithoutCancel(ctx)
+
+	var finallyErr error
+	for _, action := range r.withWorkingDir(ctx, task.Finally, dir) {
+		if err := r.performAction(ctx, task.Name, withTaskEnv(action, task.Env), buffered); err != nil {
+			message.WarnErrf(err, "Finally action of task %s failed: %s", task.Name, err.Error())
+			if finallyErr == nil {
+				finallyErr = fmt.Errorf("finally action of task %s failed: %w", task.Name, err)
+			}
+		}
+	}
+
+	if taskErr != nil {
+		return taskErr
+	}
+	return finallyErr
+}
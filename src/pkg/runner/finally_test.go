@@ -0,0 +1,77 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023-Present The UDS Authors
+
+package runner
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	zarfTypes "github.com/defenseunicorns/zarf/src/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/defenseunicorns/uds-cli/src/types"
+)
+
+func Test_executeFinally(t *testing.T) {
+	cmd := func(cmd string) types.Action {
+		return types.Action{ZarfComponentAction: &zarfTypes.ZarfComponentAction{Cmd: cmd}}
+	}
+
+	tests := []struct {
+		name    string
+		actions []types.Action
+		finally []types.Action
+		wantErr string
+		want    string
+	}{
+		{
+			name:    "RunsAfterActions",
+			actions: []types.Action{cmd("echo action >> log")},
+			finally: []types.Action{cmd("echo finally >> log")},
+			want:    "action\nfinally\n",
+		},
+		{
+			name:    "RunsWhenActionsFail",
+			actions: []types.Action{cmd("exit 3"), cmd("echo skipped >> log")},
+			finally: []types.Action{cmd("echo first >> log"), cmd("echo second >> log")},
+			wantErr: "exit status 3",
+			want:    "first\nsecond\n",
+		},
+		{
+			name:    "DoesNotMaskTheTaskError",
+			actions: []types.Action{cmd("exit 3")},
+			finally: []types.Action{cmd("exit 4"), cmd("echo cleanup >> log")},
+			wantErr: "exit status 3",
+			want:    "cleanup\n",
+		},
+		{
+			name:    "FailsTheTaskWhenActionsSucceed",
+			actions: []types.Action{cmd("echo action >> log")},
+			finally: []types.Action{cmd("exit 4")},
+			wantErr: "finally action of task test failed",
+			want:    "action\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			r := newTestRunner(nil)
+			task := types.Task{Name: "test", Dir: dir, Actions: tt.actions, Finally: tt.finally}
+
+			err := r.executeTask(context.Background(), task, false)
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tt.wantErr)
+			}
+
+			log, err := os.ReadFile(filepath.Join(dir, "log"))
+			require.NoError(t, err)
+			require.Equal(t, tt.want, string(log))
+		})
+	}
+}
@@ -0,0 +1,64 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023-Present The UDS Authors
+
+// Package runner provides functions for running tasks in a run.yaml
+package runner
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/defenseunicorns/zarf/src/pkg/message"
+	zarfUtils "github.com/defenseunicorns/zarf/src/pkg/utils"
+
+	"github.com/defenseunicorns/uds-cli/src/types"
+)
+
+const (
+	// itemVariable is the template variable holding the current forEach item
+	itemVariable = "${ITEM}"
+	// itemIndexVariable is the template variable holding the index of the current forEach item
+	itemIndexVariable = "${ITEM_INDEX}"
+)
+
+// performActionForEach runs an action once per item in its (templated) forEach list
+//
+// ITEM and ITEM_INDEX are scoped to the context of each iteration rather than set in the template map, so they are seen
+// by the action (and the tasks it references) but not by iterations of other actions running in parallel, nor by the
+// actions after the loop
+func (r *Runner) performActionForEach(ctx context.Context, taskName string, action types.Action, buffered bool) error {
+	items := splitList(r.templateString(ctx, action.ForEach))
+
+	var failed []string
+	for i, item := range items {
+		itemCtx := withScopedVariables(ctx, map[string]*zarfUtils.TextTemplate{
+			itemVariable:      {Value: item},
+			itemIndexVariable: {Value: strconv.Itoa(i)},
+		})
+		if err := r.performSingleAction(itemCtx, taskName, action, buffered); err != nil {
+			if !action.ContinueOnError || ctx.Err() != nil {
+				return fmt.Errorf("forEach item %q failed: %w", item, err)
+			}
+			message.WarnErrf(err, "forEach item %q failed, continuing: %s", item, err.Error())
+			failed = append(failed, item)
+		}
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("%d of %d forEach items failed: %s", len(failed), len(items), strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+// splitList splits a comma or newline separated list, dropping empty items
+func splitList(list string) []string {
+	var items []string
+	for _, item := range strings.FieldsFunc(list, func(c rune) bool { return c == ',' || c == '\n' }) {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,64 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023-Present The UDS Authors
+
+package runner
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	zarfUtils "github.com/defenseunicorns/zarf/src/pkg/utils"
+	zarfTypes "github.com/defenseunicorns/zarf/src/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/defenseunicorns/uds-cli/src/types"
+)
+
+func Test_forEachItems(t *testing.T) {
+	dir := t.TempDir()
+	cmd := func(cmd, forEach string) types.Action {
+		return types.Action{ZarfComponentAction: &zarfTypes.ZarfComponentAction{Cmd: cmd}, ForEach: forEach}
+	}
+	r := newTestRunner(nil)
+	r.TasksFile = types.TasksFile{Tasks: []types.Task{
+		{Name: "loops", Dir: dir, Parallel: true, Actions: []types.Action{
+			cmd("echo ${ITEM_INDEX}:${ITEM} >> letters", "a,b,c"),
+			cmd("echo ${ITEM_INDEX}:${ITEM} >> numbers", "1,2,3"),
+			{TaskReference: "child", ForEach: "x, \"y\", ${LIST}"},
+		}},
+		{Name: "child", Dir: dir, Actions: []types.Action{
+			{TaskReference: "grandchild"},
+		}},
+		{Name: "grandchild", Dir: dir, Actions: []types.Action{
+			cmd("echo '${ITEM}' >> referenced", ""),
+		}},
+	}}
+	r.setVariable("${LIST}", &zarfUtils.TextTemplate{Value: "${OTHER}"})
+	r.setVariable("${OTHER}", &zarfUtils.TextTemplate{Value: "other"})
+
+	task, err := r.getTask("loops")
+	require.NoError(t, err)
+	require.NoError(t, r.executeTask(context.Background(), task, false))
+
+	readLines := func(name string) []string {
+		content, err := os.ReadFile(filepath.Join(dir, name))
+		require.NoError(t, err)
+		lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+		sort.Strings(lines)
+		return lines
+	}
+
+	// the loops running in parallel each see their own items
+	require.Equal(t, []string{"0:a", "1:b", "2:c"}, readLines("letters"))
+	require.Equal(t, []string{"0:1", "1:2", "2:3"}, readLines("numbers"))
+	// tasks referenced from the loop see the item too, which isn't templated again
+	require.Equal(t, []string{"\"y\"", "${OTHER}", "x"}, readLines("referenced"))
+
+	// and the items are never set as variables
+	require.NotContains(t, r.TemplateMap, itemVariable)
+	require.NotContains(t, r.TemplateMap, itemIndexVariable)
+}
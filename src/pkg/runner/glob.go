@@ -0,0 +1,67 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023-Present The UDS Authors
+
+// Package runner provides functions for running tasks in a run.yaml
+package runner
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/defenseunicorns/zarf/src/pkg/utils/helpers"
+
+	"github.com/defenseunicorns/uds-cli/src/types"
+)
+
+// isSourceGlob returns true if a file's (templated) source is a local glob rather than a single file, dir or URL
+func isSourceGlob(source string) bool {
+	return source != "" && !helpers.IsURL(source) && isGlob(source)
+}
+
+// expandSourceGlobs replaces each file whose source is a glob with a file for every match, copied into the target
+// directory under its basename. Sources are resolved relative to dir, or to the current directory when dir is empty
+func (r *Runner) expandSourceGlobs(ctx context.Context, files []types.File, dir string) ([]types.File, error) {
+	expanded := make([]types.File, 0, len(files))
+	for _, file := range files {
+		source := r.templateString(ctx, file.Source)
+		if !isSourceGlob(source) {
+			expanded = append(expanded, file)
+			continue
+		}
+
+		pattern := source
+		if !filepath.IsAbs(pattern) {
+			base := dir
+			if base == "" {
+				var err error
+				if base, err = os.Getwd(); err != nil {
+					return nil, err
+				}
+			}
+			pattern = filepath.Join(base, source)
+		}
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid files source glob %q: %w", source, err)
+		}
+		if len(matches) == 0 && !file.AllowEmpty {
+			return nil, fmt.Errorf("files source glob %q matched no files (set allowEmpty to allow this)", source)
+		}
+
+		for _, match := range matches {
+			matchFile := file
+			matchFile.Source = match
+			if !filepath.IsAbs(source) && dir != "" {
+				// placeFiles resolves relative sources against dir again
+				if matchFile.Source, err = filepath.Rel(dir, match); err != nil {
+					return nil, err
+				}
+			}
+			matchFile.Target = filepath.Join(r.templateString(ctx, file.Target), filepath.Base(match))
+			expanded = append(expanded, matchFile)
+		}
+	}
+	return expanded, nil
+}
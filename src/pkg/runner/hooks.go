@@ -0,0 +1,76 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023-Present The UDS Authors
+
+// Package runner provides functions for running tasks in a run.yaml
+package runner
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	zarfUtils "github.com/defenseunicorns/zarf/src/pkg/utils"
+
+	"github.com/defenseunicorns/uds-cli/src/types"
+)
+
+const (
+	beforeAllTask = "beforeAll"
+	afterAllTask  = "afterAll"
+
+	// taskStatusVariable is set to the status of the run for the afterAll actions
+	taskStatusVariable = "${TASK_STATUS}"
+	taskStatusSuccess  = "success"
+	taskStatusFailure  = "failure"
+)
+
+// hookTasks returns the beforeAll and afterAll actions of a tasks file as tasks, the afterAll actions being finally
+// actions so that each of them runs even if an earlier one fails
+func hookTasks(tasksFile types.TasksFile) []types.Task {
+	var tasks []types.Task
+	if len(tasksFile.BeforeAll) > 0 {
+		tasks = append(tasks, types.Task{Name: beforeAllTask, Actions: tasksFile.BeforeAll})
+	}
+	if len(tasksFile.AfterAll) > 0 {
+		tasks = append(tasks, types.Task{Name: afterAllTask, Finally: tasksFile.AfterAll})
+	}
+	return tasks
+}
+
+// executeRun runs tasks (and their dependencies) in order between the beforeAll and afterAll actions of the tasks
+// file. The tasks don't run if a beforeAll action fails, while the afterAll actions always run
+func (r *Runner) executeRun(ctx context.Context, tasksFile types.TasksFile, tasks []types.Task) (err error) {
+	if len(tasksFile.AfterAll) > 0 {
+		defer func() { err = r.executeAfterAll(ctx, tasksFile, err) }()
+	}
+
+	if len(tasksFile.BeforeAll) > 0 {
+		if err := r.executeTask(ctx, types.Task{Name: beforeAllTask, Actions: tasksFile.BeforeAll}, false); err != nil {
+			return fmt.Errorf("beforeAll action failed: %w", err)
+		}
+	}
+
+	for _, task := range tasks {
+		if err := r.executeTaskOnce(ctx, task, false); err != nil {
+			return err
+		}
+	}
+	if len(r.nonFatalFailures) > 0 {
+		return fmt.Errorf("%d action(s) with continueOnError failed: %s",
+			len(r.nonFatalFailures), strings.Join(r.nonFatalFailures, ", "))
+	}
+	return nil
+}
+
+// executeAfterAll runs the afterAll actions with the status of the run in ${TASK_STATUS}. They run as finally
+// actions, so a failing afterAll action only fails a run that succeeded and doesn't mask the original error
+func (r *Runner) executeAfterAll(ctx context.Context, tasksFile types.TasksFile, runErr error) error {
+	status := taskStatusSuccess
+	if runErr != nil {
+		status = taskStatusFailure
+	}
+	r.setVariable(taskStatusVariable, &zarfUtils.TextTemplate{Value: status})
+
+	task := types.Task{Name: afterAllTask, Finally: tasksFile.AfterAll}
+	return r.executeFinally(ctx, task, r.taskDir(ctx, task), false, runErr)
+}
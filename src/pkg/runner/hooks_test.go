@@ -0,0 +1,83 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023-Present The UDS Authors
+
+package runner
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	zarfTypes "github.com/defenseunicorns/zarf/src/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/defenseunicorns/uds-cli/src/config"
+	"github.com/defenseunicorns/uds-cli/src/types"
+)
+
+func Test_executeRun(t *testing.T) {
+	cmd := func(cmd string) types.Action {
+		return types.Action{ZarfComponentAction: &zarfTypes.ZarfComponentAction{Cmd: cmd}}
+	}
+
+	tests := []struct {
+		name      string
+		beforeAll []types.Action
+		actions   []types.Action
+		afterAll  []types.Action
+		wantErr   string
+		want      string
+	}{
+		{
+			name:      "WrapsTask",
+			beforeAll: []types.Action{cmd("echo before >> log")},
+			actions:   []types.Action{cmd("echo task >> log")},
+			afterAll:  []types.Action{cmd("echo after ${TASK_STATUS} >> log")},
+			want:      "before\ntask\nafter success\n",
+		},
+		{
+			name:     "AfterAllRunsOnFailure",
+			actions:  []types.Action{cmd("exit 3")},
+			afterAll: []types.Action{cmd("echo after ${TASK_STATUS} >> log")},
+			wantErr:  "exit status 3",
+			want:     "after failure\n",
+		},
+		{
+			name:      "BeforeAllFailureSkipsTask",
+			beforeAll: []types.Action{cmd("exit 3")},
+			actions:   []types.Action{cmd("echo task >> log")},
+			afterAll:  []types.Action{cmd("echo after ${TASK_STATUS} >> log")},
+			wantErr:   "beforeAll action failed",
+			want:      "after failure\n",
+		},
+		{
+			name:     "AfterAllFailureFailsRun",
+			actions:  []types.Action{cmd("echo task >> log")},
+			afterAll: []types.Action{cmd("exit 4"), cmd("echo after >> log")},
+			wantErr:  "finally action of task afterAll failed",
+			want:     "task\nafter\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			config.RunDir = dir
+			defer func() { config.RunDir = "" }()
+			r := newTestRunner(nil)
+			tasksFile := types.TasksFile{BeforeAll: tt.beforeAll, AfterAll: tt.afterAll}
+
+			err := r.executeRun(context.Background(), tasksFile, []types.Task{{Name: "test", Actions: tt.actions}})
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tt.wantErr)
+			}
+
+			log, err := os.ReadFile(filepath.Join(dir, "log"))
+			require.NoError(t, err)
+			require.Equal(t, tt.want, string(log))
+		})
+	}
+}
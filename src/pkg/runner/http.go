@@ -0,0 +1,78 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023-Present The UDS Authors
+
+// Package runner provides functions for running tasks in a run.yaml
+package runner
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/defenseunicorns/zarf/src/pkg/message"
+
+	"github.com/defenseunicorns/uds-cli/src/types"
+)
+
+// templateHTTPRequest returns the request of an http action with its variables templated
+func (r *Runner) templateHTTPRequest(ctx context.Context, request types.HTTPRequest) types.HTTPRequest {
+	templated := types.HTTPRequest{
+		Method:     strings.ToUpper(r.templateString(ctx, request.Method)),
+		URL:        r.templateString(ctx, request.URL),
+		Body:       r.templateString(ctx, request.Body),
+		StatusCode: request.StatusCode,
+	}
+	if templated.Method == "" {
+		templated.Method = http.MethodGet
+	}
+	if len(request.Headers) > 0 {
+		templated.Headers = make(map[string]string, len(request.Headers))
+		for name, value := range request.Headers {
+			templated.Headers[name] = r.templateString(ctx, value)
+		}
+	}
+	return templated
+}
+
+// httpRequestName returns a short, human-readable name for an HTTP request
+func httpRequestName(request types.HTTPRequest) string {
+	method := request.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+	return fmt.Sprintf("%s %s", strings.ToUpper(method), request.URL)
+}
+
+// doHTTPRequest sends a (templated) HTTP request and returns the body of the response, failing if the response
+// doesn't have the expected status code (or a 2xx status code when none is expected)
+func doHTTPRequest(ctx context.Context, request types.HTTPRequest) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, request.Method, request.URL, strings.NewReader(request.Body))
+	if err != nil {
+		return "", err
+	}
+	for name, value := range request.Headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	message.Debugf("%s returned status %d", httpRequestName(request), resp.StatusCode)
+
+	if request.StatusCode != 0 && resp.StatusCode != request.StatusCode {
+		return string(body), fmt.Errorf("%s returned status %d, expected %d", httpRequestName(request), resp.StatusCode, request.StatusCode)
+	}
+	if request.StatusCode == 0 && (resp.StatusCode < 200 || resp.StatusCode > 299) {
+		return string(body), fmt.Errorf("%s returned status %d", httpRequestName(request), resp.StatusCode)
+	}
+	return string(body), nil
+}
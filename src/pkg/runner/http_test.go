@@ -0,0 +1,101 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023-Present The UDS Authors
+
+package runner
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	zarfUtils "github.com/defenseunicorns/zarf/src/pkg/utils"
+	zarfTypes "github.com/defenseunicorns/zarf/src/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/defenseunicorns/uds-cli/src/types"
+)
+
+func Test_performHTTPAction(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		switch r.URL.Path {
+		case "/ready":
+			// only ready from the second attempt
+			if attempts < 2 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			_, _ = w.Write([]byte(`{"status":"ready"}`))
+		case "/echo":
+			body, _ := io.ReadAll(r.Body)
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(r.Method + " " + r.Header.Get("X-Token") + " " + string(body)))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	retries := 1
+	tests := []struct {
+		name      string
+		request   types.HTTPRequest
+		setVar    types.SetVariable
+		want      string
+		wantErr   string
+		noRetries bool
+	}{
+		{
+			name:    "RetriesUntilSuccess",
+			request: types.HTTPRequest{URL: "${URL}/ready"},
+			setVar:  types.SetVariable{JSON: ".status"},
+			want:    "ready",
+		},
+		{
+			name:    "TemplatesEveryField",
+			request: types.HTTPRequest{Method: "post", URL: "${URL}/echo", Headers: map[string]string{"X-Token": "${TOKEN}"}, Body: "hello ${TOKEN}", StatusCode: http.StatusCreated},
+			want:    "POST secret hello secret",
+		},
+		{
+			name:      "UnexpectedStatus",
+			request:   types.HTTPRequest{URL: "${URL}/missing"},
+			wantErr:   "returned status 404",
+			noRetries: true,
+		},
+		{
+			name:      "StatusMismatch",
+			request:   types.HTTPRequest{Method: "POST", URL: "${URL}/echo", StatusCode: http.StatusOK},
+			wantErr:   "returned status 201, expected 200",
+			noRetries: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attempts = 0
+			r := newTestRunner(map[string]*zarfUtils.TextTemplate{
+				"${URL}":   {Value: server.URL},
+				"${TOKEN}": {Value: "secret"},
+			})
+			tt.setVar.Name = "OUT"
+			action := types.Action{
+				ZarfComponentAction: &zarfTypes.ZarfComponentAction{MaxRetries: &retries},
+				HTTP:                &tt.request,
+				SetVariables:        []types.SetVariable{tt.setVar},
+			}
+			if tt.noRetries {
+				action.MaxRetries = nil
+			}
+
+			err := r.performAction(context.Background(), "test", action, false)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, r.TemplateMap["${OUT}"].Value)
+		})
+	}
+}
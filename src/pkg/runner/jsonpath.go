@@ -0,0 +1,63 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023-Present The UDS Authors
+
+// Package runner provides functions for running tasks in a run.yaml
+package runner
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// selectJSON parses out as JSON and returns the value at a dotted path (e.g. .metadata.name or .items[0].status),
+// strings are returned as is and any other value is returned as JSON
+func selectJSON(out, path string) (string, error) {
+	var value any
+	if err := json.Unmarshal([]byte(out), &value); err != nil {
+		return "", fmt.Errorf("command output is not valid JSON: %w", err)
+	}
+
+	for _, key := range splitJSONPath(path) {
+		switch v := value.(type) {
+		case map[string]any:
+			field, ok := v[key]
+			if !ok {
+				return "", fmt.Errorf("json path %s not found in command output: no field %q", path, key)
+			}
+			value = field
+		case []any:
+			i, err := strconv.Atoi(key)
+			if err != nil || i < 0 || i >= len(v) {
+				return "", fmt.Errorf("json path %s not found in command output: invalid index %q for an array of length %d", path, key, len(v))
+			}
+			value = v[i]
+		default:
+			return "", fmt.Errorf("json path %s not found in command output: cannot select %q from %v", path, key, v)
+		}
+	}
+
+	if s, ok := value.(string); ok {
+		return s, nil
+	}
+	b, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// splitJSONPath splits a dotted path into its keys, treating [n] as a key of its own
+func splitJSONPath(path string) []string {
+	path = strings.ReplaceAll(path, "[", ".")
+	path = strings.ReplaceAll(path, "]", "")
+
+	keys := []string{}
+	for _, key := range strings.Split(path, ".") {
+		if key != "" {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
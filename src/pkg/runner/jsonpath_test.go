@@ -0,0 +1,44 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023-Present The UDS Authors
+
+package runner
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_selectJSON(t *testing.T) {
+	out := `{"metadata":{"name":"podinfo","labels":{"app":"podinfo"}},"items":[{"ready":true},{"ready":false}],"replicas":2}`
+
+	tests := []struct {
+		name    string
+		out     string
+		path    string
+		want    string
+		wantErr string
+	}{
+		{name: "String", out: out, path: ".metadata.name", want: "podinfo"},
+		{name: "NoLeadingDot", out: out, path: "metadata.name", want: "podinfo"},
+		{name: "Number", out: out, path: ".replicas", want: "2"},
+		{name: "ArrayIndex", out: out, path: ".items[1].ready", want: "false"},
+		{name: "Object", out: out, path: ".metadata.labels", want: `{"app":"podinfo"}`},
+		{name: "Root", out: `"value"`, path: ".", want: "value"},
+		{name: "MissingField", out: out, path: ".metadata.namespace", wantErr: `no field "namespace"`},
+		{name: "IndexOutOfRange", out: out, path: ".items[2]", wantErr: `invalid index "2"`},
+		{name: "SelectFromScalar", out: out, path: ".replicas.count", wantErr: `cannot select "count"`},
+		{name: "InvalidJSON", out: "not json", path: ".name", wantErr: "command output is not valid JSON"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := selectJSON(tt.out, tt.path)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
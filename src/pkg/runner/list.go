@@ -0,0 +1,55 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023-Present The UDS Authors
+
+// Package runner provides functions for running tasks in a run.yaml
+package runner
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pterm/pterm"
+
+	"github.com/defenseunicorns/uds-cli/src/types"
+)
+
+// TaskSummary is the name and description of a task as shown by uds run --list
+type TaskSummary struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+// ListTasks returns a summary of the tasks in a tasks file, skipping internal tasks unless includeInternal is set
+func ListTasks(tasksFile types.TasksFile, includeInternal bool) []TaskSummary {
+	summaries := []TaskSummary{}
+	for _, task := range tasksFile.Tasks {
+		if task.Internal && !includeInternal {
+			continue
+		}
+		summaries = append(summaries, TaskSummary{Name: task.Name, Description: task.Description})
+	}
+	return summaries
+}
+
+// PrintTasks prints the tasks in a tasks file as either a table or JSON
+func PrintTasks(tasksFile types.TasksFile, includeInternal bool, format string) error {
+	summaries := ListTasks(tasksFile, includeInternal)
+
+	switch format {
+	case "json":
+		output, err := json.MarshalIndent(summaries, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(output))
+		return nil
+	case "table", "":
+		data := pterm.TableData{{"Name", "Description"}}
+		for _, summary := range summaries {
+			data = append(data, []string{summary.Name, summary.Description})
+		}
+		return pterm.DefaultTable.WithHasHeader().WithData(data).Render()
+	default:
+		return fmt.Errorf("unsupported output format %q, must be one of table or json", format)
+	}
+}
@@ -0,0 +1,67 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023-Present The UDS Authors
+
+// Package runner provides functions for running tasks in a run.yaml
+package runner
+
+import (
+	"io"
+)
+
+// Option configures a Runner created with New
+type Option func(*Runner)
+
+// WithVariables sets the values of variables, which take precedence over their defaults, env files and prompts (like
+// uds run --set)
+func WithVariables(variables map[string]string) Option {
+	return func(r *Runner) {
+		r.setVariables = variables
+	}
+}
+
+// WithOutput streams the output of the commands run by the tasks' actions to w (with the values of sensitive variables
+// masked), in addition to reporting it as the CLI does
+func WithOutput(w io.Writer) Option {
+	return func(r *Runner) {
+		r.output = &lockedWriter{w: w}
+	}
+}
+
+// WithDryRun records the plan of a task and prints it instead of running it (like uds run --dry-run)
+func WithDryRun(dryRun bool) Option {
+	return func(r *Runner) {
+		r.dryRun = dryRun
+	}
+}
+
+// WithStrict fails commands that reference variables that aren't set (like uds run --strict)
+func WithStrict(strict bool) Option {
+	return func(r *Runner) {
+		r.strict = strict
+	}
+}
+
+// WithConfirm runs the actions that require confirmation without prompting (like uds run --confirm)
+func WithConfirm(confirm bool) Option {
+	return func(r *Runner) {
+		r.confirm = confirm
+	}
+}
+
+// WithLogFormat sets the format (LogFormatText or LogFormatJSON) the progress of a run is reported in, the JSON
+// records being written to w (like uds run --log-format)
+func WithLogFormat(format string, w io.Writer) Option {
+	return func(r *Runner) {
+		r.logFormat = format
+		r.logOutput = w
+	}
+}
+
+// WithStateFile records the completed actions of a run to the state file at path, skipping the ones recorded by the
+// previous (failed) run when resume is set (like uds run --state-file and --resume)
+func WithStateFile(path string, resume bool) Option {
+	return func(r *Runner) {
+		r.stateFile = path
+		r.resume = resume
+	}
+}
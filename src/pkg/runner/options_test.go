@@ -0,0 +1,62 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023-Present The UDS Authors
+
+package runner
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	zarfTypes "github.com/defenseunicorns/zarf/src/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/defenseunicorns/uds-cli/src/types"
+)
+
+func Test_New(t *testing.T) {
+	cmd := func(cmd string) types.Action {
+		return types.Action{ZarfComponentAction: &zarfTypes.ZarfComponentAction{Cmd: cmd}}
+	}
+	setGreeting := cmd("echo hello ${NAME}")
+	setGreeting.SetVariables = []types.SetVariable{{ZarfComponentActionSetVariable: zarfTypes.ZarfComponentActionSetVariable{Name: "GREETING"}}}
+	tasksFile := types.TasksFile{
+		Variables: []types.Variable{{ZarfPackageVariable: zarfTypes.ZarfPackageVariable{Name: "NAME", Default: "default"}}},
+		Tasks: []types.Task{
+			{Name: "default", Actions: []types.Action{setGreeting}},
+			{Name: "greet", Actions: []types.Action{cmd("echo ${GREETING}!")}},
+			{Name: "fail", Actions: []types.Action{cmd("exit 3")}},
+		},
+	}
+
+	var output bytes.Buffer
+	r, err := New(tasksFile, WithVariables(map[string]string{"NAME": "world"}), WithOutput(&output))
+	require.NoError(t, err)
+
+	// the default task runs when no name is given, and the variables it sets stay set for the next task
+	require.NoError(t, r.RunTask(context.Background(), ""))
+	require.NoError(t, r.RunTask(context.Background(), "greet"))
+	require.Equal(t, "hello world\nhello world!\n", output.String())
+
+	require.ErrorContains(t, r.RunTask(context.Background(), "fail"), "exit status 3")
+	require.ErrorContains(t, r.RunTask(context.Background(), "missing"), "task name missing not found")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.ErrorIs(t, r.RunTask(ctx, "greet"), context.Canceled)
+}
+
+func Test_NewDryRun(t *testing.T) {
+	tasksFile := types.TasksFile{
+		Tasks: []types.Task{
+			{Name: "default", Actions: []types.Action{{ZarfComponentAction: &zarfTypes.ZarfComponentAction{Cmd: "echo ${NAME}"}}}},
+		},
+	}
+
+	var output bytes.Buffer
+	r, err := New(tasksFile, WithDryRun(true), WithVariables(map[string]string{"NAME": "world"}), WithOutput(&output))
+	require.NoError(t, err)
+	require.NoError(t, r.RunTask(context.Background(), ""))
+	require.Equal(t, []string{"run: echo world"}, r.plan)
+	require.Empty(t, output.String())
+}
@@ -0,0 +1,116 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023-Present The UDS Authors
+
+// Package runner provides functions for running tasks in a run.yaml
+package runner
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/defenseunicorns/zarf/src/pkg/message"
+)
+
+// actionProgress reports the progress of a single action
+//
+// sequential actions report through a spinner, while parallel actions skip the (global) spinner and
+// print their output as a single block once they complete so it isn't interleaved with other actions
+type actionProgress struct {
+	spinner  *message.Spinner
+	outputMu *sync.Mutex
+	// quiet only logs the progress at the debug level
+	quiet bool
+}
+
+// newActionProgress starts reporting the progress of an action
+func (r *Runner) newActionProgress(buffered bool, format string, a ...any) actionProgress {
+	// with structured logging the action is reported by its record, so don't animate a spinner
+	if r.runLog.structured() {
+		message.Debugf(format, a...)
+		return actionProgress{outputMu: &r.outputMu, quiet: true}
+	}
+
+	if buffered {
+		r.outputMu.Lock()
+		defer r.outputMu.Unlock()
+		message.Infof(format, a...)
+		return actionProgress{outputMu: &r.outputMu}
+	}
+
+	spinner := message.NewProgressSpinner(format, a...)
+	// Persist the spinner output so it doesn't get overwritten by the command output.
+	spinner.EnablePreserveWrites()
+	return actionProgress{spinner: spinner}
+}
+
+// Updatef updates the progress text, parallel actions (and actions without progress) only log it at the debug level
+func (p actionProgress) Updatef(format string, a ...any) {
+	if p.spinner != nil {
+		p.spinner.Updatef(format, a...)
+		return
+	}
+	message.Debugf(format, a...)
+}
+
+// Stepf updates the progress text with a step of the action worth keeping in the logs (e.g. a retry), which is
+// logged as a line when the spinner doesn't show its updates (e.g. with plain output)
+func (p actionProgress) Stepf(format string, a ...any) {
+	if p.spinner != nil && message.NoProgress {
+		message.Infof(format, a...)
+		return
+	}
+	p.Updatef(format, a...)
+}
+
+// Successf reports that the action succeeded
+func (p actionProgress) Successf(format string, a ...any) {
+	if p.quiet {
+		message.Debugf(format, a...)
+		return
+	}
+	if p.spinner != nil {
+		p.spinner.Successf(format, a...)
+		return
+	}
+	p.outputMu.Lock()
+	defer p.outputMu.Unlock()
+	message.Successf(format, a...)
+}
+
+// Errorf reports an error without stopping the action
+func (p actionProgress) Errorf(err error, format string, a ...any) {
+	if p.quiet {
+		message.Debugf("%s: %s", fmt.Sprintf(format, a...), err.Error())
+		return
+	}
+	if p.spinner != nil {
+		p.spinner.Errorf(err, format, a...)
+		return
+	}
+	p.outputMu.Lock()
+	defer p.outputMu.Unlock()
+	message.WarnErrf(err, format, a...)
+}
+
+// Failf reports that the action failed, stopping its spinner
+func (p actionProgress) Failf(err error, format string, a ...any) {
+	p.Errorf(err, format, a...)
+	if p.spinner != nil {
+		p.spinner.Stop()
+	}
+}
+
+// Output prints the buffered output of a parallel action, prefixing each line with the action's name
+func (p actionProgress) Output(name, out string) {
+	if p.spinner != nil || strings.TrimSpace(out) == "" {
+		return
+	}
+	p.outputMu.Lock()
+	defer p.outputMu.Unlock()
+	scanner := bufio.NewScanner(strings.NewReader(out))
+	for scanner.Scan() {
+		message.Infof("[%s] %s", name, scanner.Text())
+	}
+}
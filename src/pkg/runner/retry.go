@@ -0,0 +1,71 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023-Present The UDS Authors
+
+// Package runner provides functions for running tasks in a run.yaml
+package runner
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/defenseunicorns/uds-cli/src/types"
+)
+
+// retryPolicy is how long to wait between the retries of an action
+type retryPolicy struct {
+	delay   time.Duration
+	backoff string
+	jitter  bool
+}
+
+// newRetryPolicy validates the retry fields of an action
+func newRetryPolicy(action types.Action) (retryPolicy, error) {
+	policy := retryPolicy{backoff: action.RetryBackoff, jitter: action.RetryJitter}
+
+	if action.RetryDelay != "" {
+		delay, err := time.ParseDuration(action.RetryDelay)
+		if err != nil || delay < 0 {
+			return policy, fmt.Errorf("invalid retryDelay %q, must be a positive duration such as 500ms or 2s", action.RetryDelay)
+		}
+		policy.delay = delay
+	}
+
+	switch policy.backoff {
+	case "", types.RetryBackoffConstant, types.RetryBackoffLinear, types.RetryBackoffExponential:
+	default:
+		return policy, fmt.Errorf("invalid retryBackoff %q, must be one of %s, %s or %s", policy.backoff,
+			types.RetryBackoffConstant, types.RetryBackoffLinear, types.RetryBackoffExponential)
+	}
+
+	return policy, nil
+}
+
+// wait returns how long to wait before the given retry (starting at 1)
+func (p retryPolicy) wait(retry int) time.Duration {
+	delay := p.delay
+	switch p.backoff {
+	case types.RetryBackoffLinear:
+		delay *= time.Duration(retry)
+	case types.RetryBackoffExponential:
+		delay *= time.Duration(1) << min(retry-1, 30)
+	}
+
+	// wait somewhere between half and all of the delay so retries of concurrent actions don't line up
+	if p.jitter && delay > 1 {
+		delay = delay/2 + time.Duration(rand.Int63n(int64(delay/2)))
+	}
+	return delay
+}
+
+// parseAttemptTimeout returns the timeout of a single attempt of an action (or 0 when unset)
+func parseAttemptTimeout(action types.Action) (time.Duration, error) {
+	if action.Timeout == "" {
+		return 0, nil
+	}
+	timeout, err := time.ParseDuration(action.Timeout)
+	if err != nil || timeout <= 0 {
+		return 0, fmt.Errorf("invalid timeout %q, must be a positive duration such as 30s or 5m", action.Timeout)
+	}
+	return timeout, nil
+}
@@ -0,0 +1,89 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023-Present The UDS Authors
+
+package runner
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/defenseunicorns/uds-cli/src/types"
+)
+
+func Test_retryPolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		action  types.Action
+		want    []time.Duration
+		wantErr string
+	}{
+		{
+			name:   "NoDelay",
+			action: types.Action{},
+			want:   []time.Duration{0, 0, 0},
+		},
+		{
+			name:   "Constant",
+			action: types.Action{RetryDelay: "1s"},
+			want:   []time.Duration{time.Second, time.Second, time.Second},
+		},
+		{
+			name:   "Linear",
+			action: types.Action{RetryDelay: "500ms", RetryBackoff: types.RetryBackoffLinear},
+			want:   []time.Duration{500 * time.Millisecond, time.Second, 1500 * time.Millisecond},
+		},
+		{
+			name:   "Exponential",
+			action: types.Action{RetryDelay: "1s", RetryBackoff: types.RetryBackoffExponential},
+			want:   []time.Duration{time.Second, 2 * time.Second, 4 * time.Second},
+		},
+		{
+			name:    "InvalidDelay",
+			action:  types.Action{RetryDelay: "soon"},
+			wantErr: "invalid retryDelay",
+		},
+		{
+			name:    "InvalidBackoff",
+			action:  types.Action{RetryDelay: "1s", RetryBackoff: "fibonacci"},
+			wantErr: "invalid retryBackoff",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy, err := newRetryPolicy(tt.action)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			for i, want := range tt.want {
+				require.Equal(t, want, policy.wait(i+1))
+			}
+		})
+	}
+
+	t.Run("Jitter", func(t *testing.T) {
+		policy, err := newRetryPolicy(types.Action{RetryDelay: "1s", RetryBackoff: types.RetryBackoffExponential, RetryJitter: true})
+		require.NoError(t, err)
+		for i := 0; i < 20; i++ {
+			wait := policy.wait(2)
+			require.GreaterOrEqual(t, wait, time.Second)
+			require.Less(t, wait, 2*time.Second)
+		}
+	})
+}
+
+func Test_parseAttemptTimeout(t *testing.T) {
+	timeout, err := parseAttemptTimeout(types.Action{})
+	require.NoError(t, err)
+	require.Zero(t, timeout)
+
+	timeout, err = parseAttemptTimeout(types.Action{Timeout: "30s"})
+	require.NoError(t, err)
+	require.Equal(t, 30*time.Second, timeout)
+
+	_, err = parseAttemptTimeout(types.Action{Timeout: "0s"})
+	require.ErrorContains(t, err, "invalid timeout")
+}
@@ -0,0 +1,255 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023-Present The UDS Authors
+
+// Package runner provides functions for running tasks in a run.yaml
+package runner
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/defenseunicorns/zarf/src/pkg/message"
+	"github.com/pterm/pterm"
+
+	"github.com/defenseunicorns/uds-cli/src/types"
+)
+
+const (
+	// LogFormatText reports the progress of a run with spinners and human-readable messages
+	LogFormatText = "text"
+	// LogFormatJSON reports each action of a run as an NDJSON record, followed by a summary of the run
+	LogFormatJSON = "json"
+)
+
+// action and task record statuses
+const (
+	actionSucceeded = "succeeded"
+	actionFailed    = "failed"
+	actionSkipped   = "skipped"
+)
+
+// errActionSkipped is logged for actions whose if/unless condition kept them from running
+var errActionSkipped = errors.New("action skipped")
+
+// actionRecord is the record of a single cmd or wait action
+type actionRecord struct {
+	Type            string    `json:"type"`
+	Task            string    `json:"task"`
+	Action          string    `json:"action"`
+	Start           time.Time `json:"start"`
+	End             time.Time `json:"end"`
+	DurationSeconds float64   `json:"durationSeconds"`
+	Status          string    `json:"status"`
+	ExitCode        int       `json:"exitCode"`
+	Retries         int       `json:"retries"`
+	Error           string    `json:"error,omitempty"`
+}
+
+// taskRecord is the record of a task, including its dependencies and referenced tasks
+type taskRecord struct {
+	Type            string    `json:"type"`
+	Task            string    `json:"task"`
+	Start           time.Time `json:"start"`
+	End             time.Time `json:"end"`
+	DurationSeconds float64   `json:"durationSeconds"`
+	Status          string    `json:"status"`
+	Error           string    `json:"error,omitempty"`
+}
+
+// runSummary is the record of a whole run, written once it completes
+type runSummary struct {
+	Type            string    `json:"type"`
+	Task            string    `json:"task"`
+	Start           time.Time `json:"start"`
+	End             time.Time `json:"end"`
+	DurationSeconds float64   `json:"durationSeconds"`
+	Status          string    `json:"status"`
+	Actions         int       `json:"actions"`
+	Succeeded       int       `json:"succeeded"`
+	Failed          int       `json:"failed"`
+	Skipped         int       `json:"skipped"`
+	Error           string    `json:"error,omitempty"`
+}
+
+// runLog collects the records of the actions and tasks of a run, writing them as NDJSON as they complete when jsonOut
+// is set (--log-format json) or printing a summary table once the run completes otherwise
+type runLog struct {
+	mu      sync.Mutex
+	jsonOut io.Writer
+	actions []actionRecord
+	tasks   []taskRecord
+}
+
+func newRunLog(format string, jsonOut io.Writer) *runLog {
+	if format != LogFormatJSON {
+		jsonOut = nil
+	}
+	return &runLog{jsonOut: jsonOut}
+}
+
+// structured returns true if the records are written as NDJSON rather than printed as a table
+func (l *runLog) structured() bool {
+	return l != nil && l.jsonOut != nil
+}
+
+// logAction records an action that started at start, err is errActionSkipped if it didn't run
+func (r *Runner) logAction(taskName string, action types.Action, start time.Time, retries int, err error) {
+	if r.runLog == nil || r.dryRun {
+		return
+	}
+
+	end := time.Now()
+	record := actionRecord{
+		Type:            "action",
+		Task:            taskName,
+		Action:          r.mask(actionName(action)),
+		Start:           start,
+		End:             end,
+		DurationSeconds: end.Sub(start).Seconds(),
+		Status:          actionSucceeded,
+		Retries:         retries,
+	}
+	switch {
+	case errors.Is(err, errActionSkipped):
+		record.Status = actionSkipped
+	case err != nil:
+		record.Status = actionFailed
+		record.ExitCode = exitCode(err)
+		record.Error = r.mask(err.Error())
+	}
+
+	r.runLog.mu.Lock()
+	defer r.runLog.mu.Unlock()
+	r.runLog.actions = append(r.runLog.actions, record)
+	r.runLog.write(record)
+}
+
+// logTask records a task that started at start
+func (r *Runner) logTask(taskName string, start time.Time, err error) {
+	if r.runLog == nil || r.dryRun {
+		return
+	}
+
+	end := time.Now()
+	record := taskRecord{
+		Type:            "task",
+		Task:            taskName,
+		Start:           start,
+		End:             end,
+		DurationSeconds: end.Sub(start).Seconds(),
+		Status:          actionSucceeded,
+	}
+	if err != nil {
+		record.Status = actionFailed
+		record.Error = r.mask(err.Error())
+	}
+
+	r.runLog.mu.Lock()
+	defer r.runLog.mu.Unlock()
+	r.runLog.tasks = append(r.runLog.tasks, record)
+	r.runLog.write(record)
+}
+
+// summary writes the summary of a run of taskName that started at start as a single JSON record, or prints a table
+// of the recorded actions and tasks with the total duration of the run; it's a no-op on a nil log
+func (l *runLog) summary(taskName string, start time.Time, err error) {
+	if l == nil {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	end := time.Now()
+	summary := runSummary{
+		Type:            "summary",
+		Task:            taskName,
+		Start:           start,
+		End:             end,
+		DurationSeconds: end.Sub(start).Seconds(),
+		Status:          actionSucceeded,
+		Actions:         len(l.actions),
+	}
+	for _, action := range l.actions {
+		switch action.Status {
+		case actionSucceeded:
+			summary.Succeeded++
+		case actionFailed:
+			summary.Failed++
+		case actionSkipped:
+			summary.Skipped++
+		}
+	}
+	if err != nil {
+		summary.Status = actionFailed
+		summary.Error = err.Error()
+	}
+
+	if l.structured() {
+		l.write(summary)
+		return
+	}
+	l.printSummary(summary)
+}
+
+// printSummary prints a table of the recorded actions and tasks, there's nothing to print if no action ran
+func (l *runLog) printSummary(summary runSummary) {
+	if len(l.actions) == 0 {
+		return
+	}
+
+	message.HorizontalRule()
+	actions := pterm.TableData{{"Task", "Action", "Status", "Duration", "Retries"}}
+	for _, action := range l.actions {
+		actions = append(actions, []string{action.Task, action.Action, action.Status, formatSeconds(action.DurationSeconds), strconv.Itoa(action.Retries)})
+	}
+	if err := pterm.DefaultTable.WithHasHeader().WithData(actions).Render(); err != nil {
+		message.Debugf("unable to print the action summary: %s", err.Error())
+	}
+
+	tasks := pterm.TableData{{"Task", "Status", "Duration"}}
+	for _, task := range l.tasks {
+		tasks = append(tasks, []string{task.Task, task.Status, formatSeconds(task.DurationSeconds)})
+	}
+	if err := pterm.DefaultTable.WithHasHeader().WithData(tasks).Render(); err != nil {
+		message.Debugf("unable to print the task summary: %s", err.Error())
+	}
+
+	message.Infof("Ran %d action(s) in %s: %d succeeded, %d failed, %d skipped", summary.Actions,
+		formatSeconds(summary.DurationSeconds), summary.Succeeded, summary.Failed, summary.Skipped)
+}
+
+// write writes v as a single line when the records are structured, the caller must hold mu
+func (l *runLog) write(v any) {
+	if !l.structured() {
+		return
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		message.Debugf("unable to marshal log record: %s", err.Error())
+		return
+	}
+	if _, err := l.jsonOut.Write(append(b, '\n')); err != nil {
+		message.Debugf("unable to write log record: %s", err.Error())
+	}
+}
+
+// formatSeconds formats a duration in seconds to the millisecond, eg. 1.5s
+func formatSeconds(seconds float64) string {
+	return fmt.Sprint(time.Duration(seconds * float64(time.Second)).Round(time.Millisecond))
+}
+
+// exitCode returns the exit code of the command that failed with err, or -1 if it didn't exit (eg. it timed out)
+func exitCode(err error) int {
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode()
+	}
+	return -1
+}
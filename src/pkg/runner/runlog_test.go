@@ -0,0 +1,93 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023-Present The UDS Authors
+
+package runner
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	// links the Zarf fns that the runner pulls in with go:linkname
+	_ "github.com/defenseunicorns/zarf/src/pkg/packager"
+	zarfTypes "github.com/defenseunicorns/zarf/src/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/defenseunicorns/uds-cli/src/types"
+)
+
+func Test_runLog(t *testing.T) {
+	var out bytes.Buffer
+	r := newTestRunner(nil)
+	r.runLog = newRunLog(LogFormatJSON, &out)
+	retries := 1
+	task := types.Task{
+		Name: "build",
+		Actions: []types.Action{
+			{ZarfComponentAction: &zarfTypes.ZarfComponentAction{Cmd: "true", Description: "ok"}},
+			{ZarfComponentAction: &zarfTypes.ZarfComponentAction{Cmd: "true", Description: "skip"}, If: "false"},
+			{ZarfComponentAction: &zarfTypes.ZarfComponentAction{Cmd: "exit 3", Description: "fail", MaxRetries: &retries}, ContinueOnError: true},
+		},
+	}
+
+	start := time.Now()
+	require.NoError(t, r.executeTask(context.Background(), task, false))
+	r.runLog.summary("build", start, nil)
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 5)
+	require.Len(t, r.runLog.actions, 3)
+
+	var records []actionRecord
+	for _, line := range lines[:3] {
+		var record actionRecord
+		require.NoError(t, json.Unmarshal([]byte(line), &record))
+		require.Equal(t, "action", record.Type)
+		require.Equal(t, "build", record.Task)
+		require.False(t, record.End.Before(record.Start))
+		record.DurationSeconds = 0
+		records = append(records, record)
+	}
+	require.Equal(t, actionRecord{Action: "ok", Status: actionSucceeded}, stripTimes(records[0]))
+	require.Equal(t, actionRecord{Action: "skip", Status: actionSkipped}, stripTimes(records[1]))
+	require.Equal(t, "fail", records[2].Action)
+	require.Equal(t, actionFailed, records[2].Status)
+	require.Equal(t, 3, records[2].ExitCode)
+	require.Equal(t, 1, records[2].Retries)
+	require.Contains(t, records[2].Error, "exit status 3")
+
+	var taskRun taskRecord
+	require.NoError(t, json.Unmarshal([]byte(lines[3]), &taskRun))
+	require.Equal(t, "task", taskRun.Type)
+	require.Equal(t, "build", taskRun.Task)
+	require.Equal(t, actionSucceeded, taskRun.Status)
+
+	var summary runSummary
+	require.NoError(t, json.Unmarshal([]byte(lines[4]), &summary))
+	require.Equal(t, "summary", summary.Type)
+	require.Equal(t, actionSucceeded, summary.Status)
+	require.Equal(t, 3, summary.Actions)
+	require.Equal(t, 1, summary.Succeeded)
+	require.Equal(t, 1, summary.Failed)
+	require.Equal(t, 1, summary.Skipped)
+}
+
+func Test_newRunLog(t *testing.T) {
+	var out bytes.Buffer
+	require.True(t, newRunLog(LogFormatJSON, &out).structured())
+	require.False(t, newRunLog(LogFormatText, &out).structured())
+
+	var l *runLog
+	require.False(t, l.structured())
+	l.summary("build", time.Now(), nil)
+}
+
+// stripTimes clears the fields of a record that vary from run to run
+func stripTimes(record actionRecord) actionRecord {
+	record.Type, record.Task = "", ""
+	record.Start, record.End = time.Time{}, time.Time{}
+	return record
+}
@@ -5,11 +5,16 @@ package runner
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	// used for compile time directives to pull functions from Zarf
@@ -18,11 +23,14 @@ import (
 	"github.com/defenseunicorns/zarf/src/config/lang"
 	"github.com/defenseunicorns/zarf/src/pkg/message"
 	zarfUtils "github.com/defenseunicorns/zarf/src/pkg/utils"
+	"github.com/defenseunicorns/zarf/src/pkg/utils/exec"
 	"github.com/defenseunicorns/zarf/src/pkg/utils/helpers"
 	zarfTypes "github.com/defenseunicorns/zarf/src/types"
-	"github.com/mholt/archiver/v3"
+	"go.opentelemetry.io/otel/attribute"
+	"golang.org/x/sync/errgroup"
 
 	"github.com/defenseunicorns/uds-cli/src/config"
+	"github.com/defenseunicorns/uds-cli/src/pkg/cache"
 	"github.com/defenseunicorns/uds-cli/src/types"
 )
 
@@ -31,43 +39,242 @@ type Runner struct {
 	TemplateMap map[string]*zarfUtils.TextTemplate
 	TasksFile   types.TasksFile
 	TaskNameMap map[string]bool
+
+	// setVariables holds the variables set with --set, which take precedence over all other values
+	setVariables map[string]string
+
+	// includes maps the key of each imported include to the file (or URL) it was imported from
+	includes map[string]string
+	// dependencyRuns tracks the dependsOn tasks that have run so each one only runs once
+	dependencyRuns   map[string]*dependencyRun
+	dependencyRunsMu sync.Mutex
+	// templateMapMu guards TemplateMap while actions run in parallel
+	templateMapMu sync.RWMutex
+	// outputMu keeps the output of parallel actions from interleaving
+	outputMu sync.Mutex
+
+	// nonFatalFailures holds the names of the actions that failed with continueOnError, guarded by outputMu
+	nonFatalFailures []string
+
+	// dryRun records the plan of the task instead of running it
+	dryRun bool
+	plan   []string
+
+	// strict fails commands that reference variables that aren't set
+	strict bool
+
+	// checking is set when the tasks file is only being validated, so variables aren't prompted for
+	checking bool
+
+	// confirm runs actions that require confirmation without prompting
+	confirm bool
+
+	// output receives the output of the commands the actions run, if set
+	output io.Writer
+
+	// runLog records the status and duration of each action and task that runs, in logFormat (with the JSON records
+	// written to logOutput)
+	runLog    *runLog
+	logFormat string
+	logOutput io.Writer
+
+	// state records the completed actions of the run to stateFile, if set, skipping the ones recorded by the previous
+	// run when resume is set
+	state     *stateFile
+	stateFile string
+	resume    bool
 }
 
-// Run runs a task from tasks file
+// ErrNoDefaultTask is returned by Run when no task name is given and the tasks file has no default task
+var ErrNoDefaultTask = errors.New("no task name given and the tasks file has no default task")
+
+// defaultTaskName is the name of the task run when no task name is given and the tasks file doesn't set a default
+const defaultTaskName = "default"
+
+// DefaultTaskName returns the task to run when no task name is given, or "" if the tasks file has none
+func DefaultTaskName(tasksFile types.TasksFile) string {
+	if tasksFile.Default != "" {
+		return tasksFile.Default
+	}
+	for _, task := range tasksFile.Tasks {
+		if task.Name == defaultTaskName {
+			return defaultTaskName
+		}
+	}
+	return ""
+}
+
+// Run runs a task from tasks file, or its default task when taskName is empty
 func Run(tasksFile types.TasksFile, taskName string, setVariables map[string]string) error {
-	runner := Runner{
+	if taskName == "" {
+		if taskName = DefaultTaskName(tasksFile); taskName == "" {
+			return ErrNoDefaultTask
+		}
+	}
+	return RunTasks(tasksFile, []string{taskName}, setVariables)
+}
+
+// RunTasks runs tasks from a tasks file one after the other in the given order, a task that already ran as a
+// dependency of an earlier task isn't run again
+func RunTasks(tasksFile types.TasksFile, taskNames []string, setVariables map[string]string) error {
+	runner, err := New(tasksFile,
+		WithVariables(setVariables),
+		WithDryRun(config.DryRun),
+		WithStrict(config.Strict),
+		WithConfirm(config.CommonOptions.Confirm),
+		WithLogFormat(config.LogFormat, os.Stderr),
+		WithStateFile(config.StateFile, config.Resume),
+	)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := runContext(config.RunTimeout)
+	defer cancel()
+	return runner.RunTasks(ctx, taskNames)
+}
+
+// New returns a Runner for the tasks of a tasks file, whose variables are set from their defaults, the tasks file's
+// env file and the given options (prompting for the ones that have a prompt and aren't set yet)
+func New(tasksFile types.TasksFile, opts ...Option) (*Runner, error) {
+	runner := &Runner{
 		TemplateMap: map[string]*zarfUtils.TextTemplate{},
 		TasksFile:   tasksFile,
 		TaskNameMap: map[string]bool{},
+
+		includes:       map[string]string{},
+		dependencyRuns: map[string]*dependencyRun{},
+		logFormat:      LogFormatText,
+	}
+	for _, opt := range opts {
+		opt(runner)
 	}
 
-	runner.populateTemplateMap(tasksFile.Variables, setVariables)
+	runner.populateTemplateMap(tasksFile.Variables, runner.setVariables)
 
-	task, err := runner.getTask(taskName)
-	if err != nil {
-		return err
+	if err := runner.loadEnvFile(context.Background(), tasksFile.EnvFile); err != nil {
+		return nil, err
 	}
 
-	// only process includes if the task requires them
-	for _, a := range task.Actions {
-		if strings.Contains(a.TaskReference, ":") {
-			err = runner.importTasks(tasksFile.Includes)
-			if err != nil {
-				return err
-			}
-			break
+	if err := runner.promptVariables(tasksFile.Variables); err != nil {
+		return nil, err
+	}
+
+	if err := runner.validateVariables(tasksFile.Variables); err != nil {
+		return nil, err
+	}
+	return runner, nil
+}
+
+// RunTask runs a task (and its dependencies) between the beforeAll and afterAll actions of the tasks file, or the
+// default task when name is empty. The run stops when ctx is done
+//
+// The variables set by the actions of a task stay set for the tasks run after it by the same Runner
+func (r *Runner) RunTask(ctx context.Context, name string) error {
+	if name == "" {
+		if name = DefaultTaskName(r.TasksFile); name == "" {
+			return ErrNoDefaultTask
 		}
 	}
+	return r.RunTasks(ctx, []string{name})
+}
 
-	if err = runner.checkForTaskLoops(task); err != nil {
-		return err
+// RunTasks runs tasks one after the other in the given order, a task that already ran as a dependency of an earlier
+// task isn't run again. The run stops when ctx is done
+func (r *Runner) RunTasks(ctx context.Context, taskNames []string) (err error) {
+	// every run starts with its own record of dependencies, failures and plan
+	r.dependencyRuns = map[string]*dependencyRun{}
+	r.nonFatalFailures = nil
+	r.plan = nil
+	r.runLog = nil
+	r.state = nil
+	if !r.dryRun {
+		r.runLog = newRunLog(r.logFormat, r.logOutput)
 	}
 
-	err = runner.executeTask(task)
+	var tasks []types.Task
+	for _, taskName := range taskNames {
+		task, err := r.getTask(taskName)
+		if err != nil {
+			return err
+		}
+		tasks = append(tasks, task)
+	}
+
+	// only process includes if the tasks (or the beforeAll and afterAll actions) require them
+	if slices.ContainsFunc(tasks, requiresIncludes) || slices.ContainsFunc(hookTasks(r.TasksFile), requiresIncludes) {
+		err = r.importTasks(r.TasksFile.Includes, []string{filepath.Clean(config.TaskFileLocation)})
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, task := range tasks {
+		if err = r.checkForTaskLoops(task); err != nil {
+			return err
+		}
+
+		if err = r.checkForDependencyCycles(task); err != nil {
+			return err
+		}
+	}
+
+	if r.stateFile != "" && !r.dryRun {
+		if r.state, err = newStateFile(r.stateFile, taskNames, r.resume); err != nil {
+			return err
+		}
+	}
+
+	name := strings.Join(taskNames, ", ")
+	ctx, span := startSpan(ctx, "run "+name, attribute.String("uds.task.name", name),
+		attribute.String("uds.tasks_file", config.TaskFileLocation), attribute.Bool("uds.dry_run", r.dryRun))
+	defer func() { r.endSpan(span, err) }()
+
+	start := time.Now()
+	err = r.executeRun(ctx, r.TasksFile, tasks)
+	if err == nil && r.state != nil {
+		err = r.state.remove()
+	}
+	if err == nil && r.dryRun {
+		r.printPlan(name)
+	}
+	r.runLog.summary(name, start, err)
 	return err
 }
 
-func (r *Runner) importTasks(includes []map[string]string) error {
+// runContext returns the context of a run, which is canceled when the CLI is interrupted or once its timeout (if any)
+// is exceeded. Actions without a timeout of their own still stop when the run is canceled
+func runContext(timeout time.Duration) (context.Context, context.CancelFunc) {
+	ctx, stop := interruptContext()
+	if timeout <= 0 {
+		return ctx, stop
+	}
+	ctx, cancel := context.WithTimeoutCause(ctx, timeout, fmt.Errorf("run timed out after %s", timeout))
+	return ctx, func() {
+		cancel()
+		stop()
+	}
+}
+
+// requiresIncludes returns true if a task references or depends on a task from an included file
+func requiresIncludes(task types.Task) bool {
+	for _, a := range taskActions(task) {
+		if strings.Contains(a.TaskReference, ":") {
+			return true
+		}
+	}
+	for _, dep := range task.DependsOn {
+		if strings.Contains(dep, ":") {
+			return true
+		}
+	}
+	return false
+}
+
+// importTasks merges the tasks of included files into the runner's tasks, prefixing them with their include key
+//
+// chain holds the files that led to these includes and is used to reject cyclic includes
+func (r *Runner) importTasks(includes []map[string]string, chain []string) error {
 	// iterate through includes, open the file, and unmarshal it into a Task
 	var includeFilenameKey string
 	var includeFilename string
@@ -82,7 +289,23 @@ func (r *Runner) importTasks(includes []map[string]string) error {
 			break
 		}
 
-		includeFilename = r.templateString(includeFilename)
+		includeFilename = r.templateString(context.Background(), includeFilename)
+
+		includeLocation := includeFilename
+		if !helpers.IsURL(includeFilename) {
+			includeLocation = filepath.Join(filepath.Dir(config.TaskFileLocation), includeFilename)
+		}
+		if slices.Contains(chain, includeLocation) {
+			return fmt.Errorf("include cycle detected: %s -> %s", strings.Join(chain, " -> "), includeLocation)
+		}
+		if existing, ok := r.includes[includeFilenameKey]; ok {
+			if existing == includeLocation {
+				// the same file was already included under this key
+				continue
+			}
+			return fmt.Errorf("include key %s refers to both %s and %s", includeFilenameKey, existing, includeLocation)
+		}
+		r.includes[includeFilenameKey] = includeLocation
 
 		var tasksFile types.TasksFile
 		var includePath string
@@ -99,7 +322,7 @@ func (r *Runner) importTasks(includes []map[string]string) error {
 				return fmt.Errorf(lang.ErrDownloading, includeFilename, err.Error())
 			}
 		} else {
-			includePath = filepath.Join(filepath.Dir(config.TaskFileLocation), includeFilename)
+			includePath = includeLocation
 		}
 
 		if err := zarfUtils.ReadYaml(includePath, &tasksFile); err != nil {
@@ -109,29 +332,45 @@ func (r *Runner) importTasks(includes []map[string]string) error {
 		// prefix task names and actions with the includes key
 		for i, t := range tasksFile.Tasks {
 			tasksFile.Tasks[i].Name = includeFilenameKey + ":" + t.Name
-			if len(tasksFile.Tasks[i].Actions) > 0 {
-				for j, a := range tasksFile.Tasks[i].Actions {
+			for _, actions := range [][]types.Action{tasksFile.Tasks[i].Actions, tasksFile.Tasks[i].Finally} {
+				for j, a := range actions {
 					if a.TaskReference != "" && !strings.Contains(a.TaskReference, ":") {
-						tasksFile.Tasks[i].Actions[j].TaskReference = includeFilenameKey + ":" + a.TaskReference
+						actions[j].TaskReference = includeFilenameKey + ":" + a.TaskReference
 					}
 				}
 			}
+			for j, dep := range tasksFile.Tasks[i].DependsOn {
+				if !strings.Contains(dep, ":") {
+					tasksFile.Tasks[i].DependsOn[j] = includeFilenameKey + ":" + dep
+				}
+			}
+		}
+		for _, t := range tasksFile.Tasks {
+			if _, err := r.getTask(t.Name); err == nil {
+				return fmt.Errorf("task %s included from %s conflicts with an existing task of the same name", t.Name, includeLocation)
+			}
 		}
 		r.TasksFile.Tasks = append(r.TasksFile.Tasks, tasksFile.Tasks...)
 
 		// grab variables from included file
 		for _, v := range tasksFile.Variables {
-			r.TemplateMap["${"+v.Name+"}"] = &zarfUtils.TextTemplate{
+			r.setVariable("${"+v.Name+"}", &zarfUtils.TextTemplate{
 				Sensitive:  v.Sensitive,
 				AutoIndent: v.AutoIndent,
 				Type:       v.Type,
 				Value:      v.Default,
-			}
+			})
+		}
+		if err := r.promptVariables(tasksFile.Variables); err != nil {
+			return err
+		}
+		if err := r.validateVariables(tasksFile.Variables); err != nil {
+			return err
 		}
 
 		// recursively import tasks from included files
 		if tasksFile.Includes != nil {
-			if err := r.importTasks(tasksFile.Includes); err != nil {
+			if err := r.importTasks(tasksFile.Includes, append(slices.Clip(chain), includeLocation)); err != nil {
 				return err
 			}
 		}
@@ -145,90 +384,210 @@ func (r *Runner) getTask(taskName string) (types.Task, error) {
 			return task, nil
 		}
 	}
-	return types.Task{}, fmt.Errorf("task name %s not found", taskName)
+	return types.Task{}, &TaskNotFoundError{Name: taskName}
 }
 
-func (r *Runner) executeTask(task types.Task) error {
+// executeTask places a task's files and performs its actions, buffered is set when the task is run from a parallel action
+func (r *Runner) executeTask(ctx context.Context, task types.Task, buffered bool) (err error) {
+	start := time.Now()
+	defer func() { r.logTask(task.Name, start, err) }()
+	ctx, span := startSpan(ctx, "task "+task.Name, attribute.String("uds.task.name", task.Name),
+		attribute.Bool("uds.task.parallel", task.Parallel))
+	defer func() { r.endSpan(span, err) }()
+
+	if err := r.executeDependencies(ctx, task, buffered); err != nil {
+		return err
+	}
+
+	// describe the task once its dependencies (which describe themselves) have run
+	if task.Description != "" {
+		message.Title(task.Name, task.Description)
+	}
+
+	if err := r.loadEnvFile(ctx, task.EnvFile); err != nil {
+		return err
+	}
+
+	dir := r.taskDir(ctx, task)
+
+	if len(task.Finally) > 0 {
+		defer func() { err = r.executeFinally(ctx, task, dir, buffered, err) }()
+	}
+
 	if len(task.Files) > 0 {
-		if err := r.placeFiles(task.Files); err != nil {
+		if r.dryRun {
+			r.planFiles(ctx, task.Files, dir)
+		} else if err := r.placeFiles(ctx, task.Files, dir); err != nil {
 			return err
 		}
 	}
 
-	for _, action := range task.Actions {
-		if err := r.performAction(action); err != nil {
+	task.Actions = r.withWorkingDir(ctx, task.Actions, dir)
+
+	if task.Parallel {
+		return r.executeActionsInParallel(ctx, task)
+	}
+
+	for i, action := range task.Actions {
+		if err := ctx.Err(); err != nil {
+			return context.Cause(ctx)
+		}
+		if err := r.performTaskAction(ctx, task, i, withTaskEnv(action, task.Env), buffered); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
-func (r *Runner) populateTemplateMap(zarfVariables []zarfTypes.ZarfPackageVariable, setVariables map[string]string) {
-	for _, variable := range zarfVariables {
-		r.TemplateMap[fmt.Sprintf("${%s}", variable.Name)] = &zarfUtils.TextTemplate{
+// executeActionsInParallel performs a task's actions concurrently, returning the first error once in-flight actions settle
+func (r *Runner) executeActionsInParallel(ctx context.Context, task types.Task) error {
+	limit := task.MaxConcurrency
+	if limit < 1 {
+		limit = len(task.Actions)
+	}
+
+	g, groupCtx := errgroup.WithContext(ctx)
+	g.SetLimit(limit)
+	for i, action := range task.Actions {
+		i, action := i, action
+		g.Go(func() error {
+			// don't start any more actions once one has failed
+			if groupCtx.Err() != nil {
+				return nil
+			}
+			return r.performTaskAction(ctx, task, i, withTaskEnv(action, task.Env), true)
+		})
+	}
+	return g.Wait()
+}
+
+func (r *Runner) populateTemplateMap(variables []types.Variable, setVariables map[string]string) {
+	// built-in variables come first so declared variables of the same name win
+	for name, value := range builtinVariables() {
+		r.setVariable(name, value)
+	}
+
+	for _, variable := range variables {
+		r.setVariable(fmt.Sprintf("${%s}", variable.Name), &zarfUtils.TextTemplate{
 			Sensitive:  variable.Sensitive,
 			AutoIndent: variable.AutoIndent,
 			Type:       variable.Type,
 			Value:      variable.Default,
-		}
+		})
 	}
 
-	setVariablesTemplateMap := make(map[string]*zarfUtils.TextTemplate)
+	// keep how a declared variable is templated (e.g. whether it is sensitive) and only replace its value
 	for name, value := range setVariables {
-		setVariablesTemplateMap[fmt.Sprintf("${%s}", name)] = &zarfUtils.TextTemplate{
-			Value: value,
+		key := fmt.Sprintf("${%s}", name)
+		template := zarfUtils.TextTemplate{}
+		if existing, ok := r.getVariable(key); ok {
+			template = *existing
 		}
+		template.Value = value
+		r.setVariable(key, &template)
 	}
-
-	r.TemplateMap = helpers.MergeMap[*zarfUtils.TextTemplate](r.TemplateMap, setVariablesTemplateMap)
 }
 
-func (r *Runner) placeFiles(files []zarfTypes.ZarfFile) error {
+// placeFiles places a task's files relative to dir, or to the current directory when dir is empty
+func (r *Runner) placeFiles(ctx context.Context, files []types.File, dir string) error {
+	files, err := r.expandSourceGlobs(ctx, files, dir)
+	if err != nil {
+		return err
+	}
 	for _, file := range files {
-		// template file.Source and file.Target
-		srcFile := r.templateString(file.Source)
-		targetFile := r.templateString(file.Target)
-
-		// get current directory
-		workingDir, err := os.Getwd()
+		mode, err := parseFileMode(file.Mode)
 		if err != nil {
 			return err
 		}
-		dest := filepath.Join(workingDir, targetFile)
+
+		// template file.Source and file.Target
+		srcFile := r.templateString(ctx, file.Source)
+		targetFile := r.templateString(ctx, file.Target)
+
+		// get the working directory
+		workingDir := dir
+		if workingDir == "" {
+			var err error
+			if workingDir, err = os.Getwd(); err != nil {
+				return err
+			}
+		} else if !helpers.IsURL(srcFile) && !filepath.IsAbs(srcFile) {
+			srcFile = filepath.Join(workingDir, srcFile)
+		}
+		dest := targetFile
+		if !filepath.IsAbs(dest) {
+			dest = filepath.Join(workingDir, targetFile)
+		}
 		destDir := filepath.Dir(dest)
 
-		if helpers.IsURL(srcFile) {
+		// downloads with a shasum (that isn't of an extracted file) are cached by their shasum
+		cacheable := helpers.IsURL(srcFile) && file.Shasum != "" && file.ExtractPath == ""
+		cached := false
+
+		if cacheable && cache.FileExists(file.Shasum) {
+			// If the file is cached (and still matches its shasum) use it instead of downloading it
+			if err := cache.UseFile(file.Shasum, dest); err == nil && zarfUtils.SHAsMatch(dest, file.Shasum) == nil {
+				cached = true
+				message.Debugf("Using cached copy of %s", srcFile)
+			} else {
+				message.Debugf("Ignoring invalid cached copy of %s", srcFile)
+			}
+		}
 
+		switch {
+		case cached:
+			// The file was already placed from the cache
+		case file.Content != "":
+			// If file has inline content write it, it is templated below like any other text file
+			if err := os.MkdirAll(destDir, 0700); err != nil {
+				return err
+			}
+			if err := os.WriteFile(dest, []byte(file.Content), 0600); err != nil {
+				return fmt.Errorf("unable to write file %s: %w", dest, err)
+			}
+		case helpers.IsURL(srcFile):
 			// If file is a url download it
-			if err := zarfUtils.DownloadToFile(srcFile, dest, ""); err != nil {
+			retries := config.DownloadRetries
+			if file.Retries > 0 {
+				retries = file.Retries
+			}
+			if err := downloadFile(srcFile, dest, retries); err != nil {
 				return fmt.Errorf(lang.ErrDownloading, srcFile, err.Error())
 			}
-		} else {
+		default:
 			// If file is not a url copy it
 			if err := zarfUtils.CreatePathAndCopy(srcFile, dest); err != nil {
 				return fmt.Errorf("unable to copy file %s: %w", srcFile, err)
 			}
-
 		}
-		// If file has extract path extract it
+		// If file has extract path extract the matching entries
+		var extracted []string
 		if file.ExtractPath != "" {
-			_ = os.RemoveAll(file.ExtractPath)
-			err = archiver.Extract(dest, file.ExtractPath, destDir)
-			if err != nil {
-				return fmt.Errorf(lang.ErrFileExtract, file.ExtractPath, srcFile, err.Error())
+			var err error
+			if extracted, err = extractFiles(dest, file.ExtractPath, destDir, file.ArchiveFormat); err != nil {
+				return err
 			}
 		}
 
-		// if shasum is specified check it
+		// if shasum is specified check it, against the extracted file if there is one
 		if file.Shasum != "" {
 			if file.ExtractPath != "" {
-				if err := zarfUtils.SHAsMatch(file.ExtractPath, file.Shasum); err != nil {
+				if len(extracted) != 1 {
+					return fmt.Errorf("extractPath %q matched %d files in %s but a shasum can only be checked against a single file",
+						file.ExtractPath, len(extracted), srcFile)
+				}
+				if err := zarfUtils.SHAsMatch(extracted[0], file.Shasum); err != nil {
 					return err
 				}
 			} else {
 				if err := zarfUtils.SHAsMatch(dest, file.Shasum); err != nil {
 					return err
 				}
+				if cacheable && !cached {
+					if err := cache.AddFile(dest, file.Shasum); err != nil {
+						message.Debugf("Unable to cache %s: %s", srcFile, err.Error())
+					}
+				}
 			}
 		}
 
@@ -249,46 +608,110 @@ func (r *Runner) placeFiles(files []zarfTypes.ZarfFile) error {
 
 			// If the file is a text file, template it
 			if isText {
-				if err := zarfUtils.ReplaceTextTemplate(subFile, r.TemplateMap, nil, `\$\{[A-Z0-9_]+\}`); err != nil {
+				var err error
+				r.readScopedTemplateMap(ctx, func(templateMap map[string]*zarfUtils.TextTemplate) {
+					err = zarfUtils.ReplaceTextTemplate(subFile, templateMap, nil, `\$\{[A-Z0-9_]+\}`)
+				})
+				if err != nil {
 					return fmt.Errorf("unable to template file %s: %w", subFile, err)
 				}
 			}
 		}
 
-		// if executable make file executable
-		if file.Executable || zarfUtils.IsDir(dest) {
+		// apply the file's mode, or make it executable (or only readable by the user) when it has none
+		switch {
+		case file.Mode != "":
+			if err := os.Chmod(dest, mode); err != nil {
+				return fmt.Errorf("unable to set the mode of %s to %s: %w", dest, file.Mode, err)
+			}
+		case file.Executable || zarfUtils.IsDir(dest):
 			_ = os.Chmod(dest, 0700)
-		} else {
+		default:
 			_ = os.Chmod(dest, 0600)
 		}
 
 		// if symlinks create them
-		for _, link := range file.Symlinks {
-			// Try to remove the filepath if it exists
-			_ = os.RemoveAll(link)
-			// Make sure the parent directory exists
-			_ = zarfUtils.CreateFilePath(link)
-			// Create the symlink
-			err := os.Symlink(targetFile, link)
-			if err != nil {
-				return fmt.Errorf("unable to create symlink %s->%s: %w", link, targetFile, err)
-			}
+		if err := r.placeSymlinks(ctx, file, workingDir, dest); err != nil {
+			return err
 		}
 	}
 	return nil
 }
 
-func (r *Runner) performAction(action types.Action) error {
+// parseFileMode parses the octal mode (e.g. "0644") of a file, an empty mode is 0
+func parseFileMode(mode string) (os.FileMode, error) {
+	if mode == "" {
+		return 0, nil
+	}
+	perm, err := strconv.ParseUint(mode, 8, 32)
+	if err != nil || perm > 0777 {
+		return 0, fmt.Errorf("invalid mode %q, must be an octal permission such as 0644 or 0755", mode)
+	}
+	return os.FileMode(perm), nil
+}
+
+// performAction performs an action of the task named taskName
+func (r *Runner) performAction(ctx context.Context, taskName string, action types.Action, buffered bool) error {
+	return r.settleAction(ctx, action, r.attemptAction(ctx, taskName, action, buffered))
+}
+
+// attemptAction performs an action of the task named taskName, returning errActionSkipped if its condition kept it
+// from running and the error of an action that continues on error as is
+func (r *Runner) attemptAction(ctx context.Context, taskName string, action types.Action, buffered bool) error {
+	if !r.shouldRun(ctx, action) {
+		name := actionName(action)
+		progress := r.newActionProgress(buffered, "Checking condition for \"%s\"", name)
+		progress.Successf("Skipped \"%s\" (condition false)", name)
+		r.logAction(taskName, action, time.Now(), 0, errActionSkipped)
+		return errActionSkipped
+	}
+
+	// confirm once, before any iteration of a forEach runs
+	if err := r.confirmAction(ctx, action); err != nil {
+		return err
+	}
+	if action.ForEach != "" {
+		return r.performActionForEach(ctx, taskName, action, buffered)
+	}
+	return r.performSingleAction(ctx, taskName, action, buffered)
+}
+
+// settleAction returns the error that the result of attemptAction fails the task with
+func (r *Runner) settleAction(ctx context.Context, action types.Action, err error) error {
+	if errors.Is(err, errActionSkipped) {
+		return nil
+	}
+	// a canceled run aborts even actions that continue on error
+	if err != nil && action.ContinueOnError && ctx.Err() == nil {
+		r.recordNonFatalFailure(actionName(action), err)
+		return nil
+	}
+	return err
+}
+
+// recordNonFatalFailure warns about an action that failed with continueOnError so the run can fail once it completes
+func (r *Runner) recordNonFatalFailure(name string, err error) {
+	r.outputMu.Lock()
+	defer r.outputMu.Unlock()
+	message.WarnErrf(err, "\"%s\" failed, continuing: %s", name, err.Error())
+	r.nonFatalFailures = append(r.nonFatalFailures, name)
+}
+
+// performSingleAction runs a referenced task or a Zarf action once
+func (r *Runner) performSingleAction(ctx context.Context, taskName string, action types.Action, buffered bool) error {
 	if action.TaskReference != "" {
 		referencedTask, err := r.getTask(action.TaskReference)
 		if err != nil {
 			return err
 		}
-		if err := r.executeTask(referencedTask); err != nil {
+		if action.Isolate {
+			return r.executeIsolatedTask(ctx, referencedTask, buffered)
+		}
+		if err := r.executeTask(ctx, referencedTask, buffered); err != nil {
 			return err
 		}
 	} else {
-		err := r.performZarfAction(action.ZarfComponentAction)
+		err := r.performZarfAction(ctx, taskName, action, buffered)
 		if err != nil {
 			return err
 		}
@@ -296,14 +719,28 @@ func (r *Runner) performAction(action types.Action) error {
 	return nil
 }
 
+// actionName returns a short, human-readable name for an action
+func actionName(action types.Action) string {
+	if action.TaskReference != "" {
+		return action.TaskReference
+	}
+	if action.Description != "" {
+		return action.Description
+	}
+	if action.HTTP != nil {
+		return message.Truncate(httpRequestName(*action.HTTP), 60, false)
+	}
+	return message.Truncate(action.Cmd, 60, false)
+}
+
 func (r *Runner) checkForTaskLoops(task types.Task) error {
 	// Filtering unique task actions allows for rerunning tasks in the same execution
-	uniqueTaskActions := getUniqueTaskActions(task.Actions)
+	uniqueTaskActions := getUniqueTaskActions(taskActions(task))
 	for _, action := range uniqueTaskActions {
 		if action.TaskReference != "" {
 			exists := r.TaskNameMap[action.TaskReference]
 			if exists {
-				return fmt.Errorf("task loop detected")
+				return ErrTaskLoop
 			}
 			r.TaskNameMap[action.TaskReference] = true
 			newTask, err := r.getTask(action.TaskReference)
@@ -333,13 +770,35 @@ func getUniqueTaskActions(actions []types.Action) []types.Action {
 	return uniqueArray
 }
 
-func (r *Runner) performZarfAction(action *zarfTypes.ZarfComponentAction) error {
+// performZarfAction runs a cmd, wait or http action, logging a record of it when structured logging is enabled
+func (r *Runner) performZarfAction(ctx context.Context, taskName string, action types.Action, buffered bool) error {
+	if r.dryRun {
+		return r.planZarfAction(ctx, action)
+	}
+
+	ctx, span := startSpan(ctx, "action "+r.mask(actionName(action)), attribute.String("uds.task.name", taskName),
+		attribute.String("uds.action.description", r.mask(actionName(action))))
+	start := time.Now()
+	retries := 0
+	err := r.runZarfAction(ctx, action, buffered, &retries)
+	r.logAction(taskName, action, start, retries, err)
+	span.SetAttributes(attribute.Int("uds.action.retries", retries))
+	if err != nil {
+		span.SetAttributes(attribute.Int("uds.action.exit_code", exitCode(err)))
+	}
+	r.endSpan(span, err)
+	return err
+}
+
+// runZarfAction runs a cmd, wait or http action, counting its retries in retries
+func (r *Runner) runZarfAction(ctx context.Context, action types.Action, buffered bool, retries *int) (err error) {
 	var (
-		ctx        context.Context
+		attemptCtx context.Context
 		cancel     context.CancelFunc
 		cmdEscaped string
 		out        string
-		err        error
+		output     commandOutput
+		attempts   int
 
 		cmd = action.Cmd
 	)
@@ -352,8 +811,13 @@ func (r *Runner) performZarfAction(action *zarfTypes.ZarfComponentAction) error
 			action.MaxTotalSeconds = &fiveMin
 		}
 
+		// File and command waits are handled natively.
+		if action.Wait.File != nil || action.Wait.Command != nil {
+			return r.performWait(ctx, action, buffered)
+		}
+
 		// Convert the wait to a command.
-		if cmd, err = convertWaitToCmd(*action.Wait, action.MaxTotalSeconds); err != nil {
+		if cmd, err = convertWaitToCmd(r.templateWait(ctx, action.Wait.ZarfComponentActionWait), action.MaxTotalSeconds); err != nil {
 			return err
 		}
 
@@ -369,21 +833,34 @@ func (r *Runner) performZarfAction(action *zarfTypes.ZarfComponentAction) error
 		d := ""
 		action.Dir = &d
 		action.Env = []string{}
-		action.SetVariables = []zarfTypes.ZarfComponentActionSetVariable{}
+		action.SetVariables = []types.SetVariable{}
 	}
 
-	// Add the uds/zarf arch to the environment.
-	action.Env = append(action.Env, "UDS_ARCH="+config.GetArch())
+	// Template the env and add the uds/zarf arch to it, copying the Zarf action so the task's action isn't modified.
+	zarfAction := *action.ZarfComponentAction
+	zarfAction.Env = append(r.templateEnv(ctx, zarfAction.Env), "UDS_ARCH="+config.GetArch())
+	action.ZarfComponentAction = &zarfAction
+
+	// HTTP requests are sent natively, templated once for every attempt
+	var request types.HTTPRequest
+	if action.HTTP != nil {
+		request = r.templateHTTPRequest(ctx, *action.HTTP)
+	}
 
 	if action.Description != "" {
-		cmdEscaped = action.Description
+		cmdEscaped = r.mask(action.Description)
+	} else if action.HTTP != nil {
+		cmdEscaped = message.Truncate(r.mask(httpRequestName(request)), 60, false)
 	} else {
-		cmdEscaped = message.Truncate(cmd, 60, false)
+		cmdEscaped = message.Truncate(r.mask(cmd), 60, false)
 	}
 
-	spinner := message.NewProgressSpinner("Running \"%s\"", cmdEscaped)
-	// Persist the spinner output so it doesn't get overwritten by the command output.
-	spinner.EnablePreserveWrites()
+	progress := r.newActionProgress(buffered, "Running \"%s\"", cmdEscaped)
+	defer func() {
+		if err != nil {
+			progress.Failf(err, "Failed \"%s\"", cmdEscaped)
+		}
+	}()
 
 	// If the value template is not nil, get the variables for the action.
 	// No special variables or deprecations will be used in the action.
@@ -392,25 +869,124 @@ func (r *Runner) performZarfAction(action *zarfTypes.ZarfComponentAction) error
 	// 	vars, _ = valueTemplate.GetVariables(zarfTypes.ZarfComponent{})
 	// }
 
-	cfg := actionGetCfg(zarfTypes.ZarfComponentActionDefaults{}, *action, r.TemplateMap)
+	var cfg zarfTypes.ZarfComponentActionDefaults
+	r.readScopedTemplateMap(ctx, func(templateMap map[string]*zarfUtils.TextTemplate) {
+		cfg = actionGetCfg(zarfTypes.ZarfComponentActionDefaults{}, *action.ZarfComponentAction, templateMap)
+	})
+	cfg.Shell = r.actionShell(action.Shell)
+
+	// Fail before the first attempt rather than retrying a command that can't run
+	if action.HTTP == nil {
+		if _, _, err := shellCommand(cfg.Shell); err != nil {
+			return err
+		}
+	}
+
+	// Parallel actions run muted and print their output once they complete.
+	printOutput := buffered && !cfg.Mute
+	streamOutput := r.output != nil && !cfg.Mute
+	if buffered {
+		cfg.Mute = true
+	}
 
 	if cmd, err = actionCmdMutation(cmd); err != nil {
-		spinner.Errorf(err, "Error mutating command: %s", cmdEscaped)
+		progress.Errorf(err, "Error mutating command: %s", cmdEscaped)
 	}
 
 	// template cmd string
-	cmd = r.templateString(cmd)
+	cmd = r.templateString(ctx, cmd)
+
+	if r.strict {
+		templated := cmd
+		if action.HTTP != nil {
+			templated = request.URL + request.Body
+			for _, value := range request.Headers {
+				templated += value
+			}
+		}
+		if names := unresolvedVariables(templated, cfg.Env); len(names) > 0 {
+			return fmt.Errorf("unresolved variable %s in command \"%s\"", strings.Join(names, ", "), cmdEscaped)
+		}
+	}
+
+	retry, err := newRetryPolicy(action)
+	if err != nil {
+		return err
+	}
+	limit, err := newOutputLimit(action)
+	if err != nil {
+		return err
+	}
+	combined := slices.ContainsFunc(action.SetVariables, func(v types.SetVariable) bool { return v.Stream == types.StreamCombined })
+
+	// Stream the output of every attempt to the action's output file (and the runner's output).
+	var outputFile io.Writer
+	if action.OutputFile != "" && action.HTTP == nil {
+		file, err := createOutputFile(r.templateString(ctx, action.OutputFile), cfg.Dir)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		outputFile = file
+	}
+	if streamOutput && action.HTTP == nil {
+		if outputFile != nil {
+			outputFile = io.MultiWriter(outputFile, r.output)
+		} else {
+			outputFile = r.output
+		}
+	}
+
+	attemptTimeout, err := parseAttemptTimeout(action)
+	if err != nil {
+		return err
+	}
 
 	duration := time.Duration(cfg.MaxTotalSeconds) * time.Second
 	timeout := time.After(duration)
+	deadline := time.Now().Add(duration)
 
 	// Keep trying until the max retries is reached.
 	for remaining := cfg.MaxRetries + 1; remaining > 0; remaining-- {
 
+		attempt := cfg.MaxRetries + 1 - remaining
+		*retries = attempt
+
+		// Wait before retrying, without waiting past the timeout.
+		if attempt > 0 && retry.delay > 0 {
+			wait := retry.wait(attempt)
+			progress.Stepf("Retry %d/%d of \"%s\" in %s", attempt, cfg.MaxRetries, cmdEscaped, wait)
+			if cfg.MaxTotalSeconds < 1 {
+				select {
+				case <-ctx.Done():
+					return fmt.Errorf("command \"%s\" was canceled: %w", cmdEscaped, context.Cause(ctx))
+				case <-time.After(wait):
+				}
+			} else {
+				select {
+				case <-timeout:
+					return &ActionError{Action: cmdEscaped, Attempts: attempts, Retries: cfg.MaxRetries, TimedOut: true, MaxTotalSeconds: cfg.MaxTotalSeconds, Err: err}
+				case <-ctx.Done():
+					return fmt.Errorf("command \"%s\" was canceled: %w", cmdEscaped, context.Cause(ctx))
+				case <-time.After(wait):
+				}
+			}
+		}
+
 		// Perform the action run.
 		tryCmd := func(ctx context.Context) error {
 			// Try running the command and continue the retry loop if it fails.
-			if out, err = actionRun(ctx, cfg, cmd, cfg.Shell, spinner); err != nil {
+			attempts++
+			if action.HTTP != nil {
+				out, err = doHTTPRequest(ctx, request)
+			} else {
+				output, err = r.actionRun(ctx, cfg, cmd, cfg.Shell, progress.spinner, outputFile, limit, combined)
+				out = output.stdout
+			}
+			if printOutput {
+				progress.Output(cmdEscaped, r.mask(out))
+			}
+			if err != nil {
 				return err
 			}
 
@@ -418,25 +994,38 @@ func (r *Runner) performZarfAction(action *zarfTypes.ZarfComponentAction) error
 
 			// If an output variable is defined, set it.
 			for _, v := range action.SetVariables {
+				value := out
+				if v.Stream != "" && action.HTTP == nil {
+					if value, err = output.stream(v.Stream); err != nil {
+						return fmt.Errorf("unable to set variable %s: %w", v.Name, err)
+					}
+					value = strings.TrimSpace(value)
+				}
+				if v.JSON != "" {
+					if value, err = selectJSON(out, v.JSON); err != nil {
+						return fmt.Errorf("unable to set variable %s: %w", v.Name, err)
+					}
+				}
+
+				if err := validateVariablePattern(v, value); err != nil {
+					return err
+				}
+
 				// include ${...} syntax in template map for uniformity and to satisfy zarfUtils.ReplaceTextTemplate
 				nameInTemplatemap := "${" + v.Name + "}"
-				r.TemplateMap[nameInTemplatemap] = &zarfUtils.TextTemplate{
+				r.setVariable(nameInTemplatemap, &zarfUtils.TextTemplate{
 					Sensitive:  v.Sensitive,
 					AutoIndent: v.AutoIndent,
 					Type:       v.Type,
-					Value:      out,
-				}
-				if regexp.MustCompile(v.Pattern).MatchString(r.TemplateMap[nameInTemplatemap].Value); err != nil {
-					message.WarnErr(err, err.Error())
-					return err
-				}
+					Value:      value,
+				})
 			}
 
 			// If the action has a wait, change the spinner message to reflect that on success.
 			if action.Wait != nil {
-				spinner.Successf("Wait for \"%s\" succeeded", cmdEscaped)
+				progress.Successf("Wait for \"%s\" succeeded", cmdEscaped)
 			} else {
-				spinner.Successf("Completed \"%s\"", cmdEscaped)
+				progress.Successf("Completed \"%s\"", cmdEscaped)
 			}
 
 			// If the command ran successfully, continue to the next action.
@@ -445,8 +1034,18 @@ func (r *Runner) performZarfAction(action *zarfTypes.ZarfComponentAction) error
 
 		// If no timeout is set, run the command and return or continue retrying.
 		if cfg.MaxTotalSeconds < 1 {
-			spinner.Updatef("Waiting for \"%s\" (no timeout)", cmdEscaped)
-			if err := tryCmd(context.TODO()); err != nil {
+			attemptCtx = ctx
+			if attemptTimeout > 0 {
+				progress.Updatef("Waiting for \"%s\" (attempt timeout: %s)", cmdEscaped, attemptTimeout)
+				attemptCtx, cancel = context.WithTimeout(ctx, attemptTimeout)
+				defer cancel()
+			} else {
+				progress.Updatef("Waiting for \"%s\" (no timeout)", cmdEscaped)
+			}
+			if err = tryCmd(attemptCtx); err != nil {
+				if ctx.Err() != nil {
+					return fmt.Errorf("command \"%s\" was canceled: %w", cmdEscaped, context.Cause(ctx))
+				}
 				continue
 			}
 
@@ -454,7 +1053,7 @@ func (r *Runner) performZarfAction(action *zarfTypes.ZarfComponentAction) error
 		}
 
 		// Run the command on repeat until success or timeout.
-		spinner.Updatef("Waiting for \"%s\" (timeout: %ds)", cmdEscaped, cfg.MaxTotalSeconds)
+		progress.Updatef("Waiting for \"%s\" (timeout: %ds)", cmdEscaped, cfg.MaxTotalSeconds)
 		select {
 		// On timeout break the loop to abort.
 		case <-timeout:
@@ -462,9 +1061,17 @@ func (r *Runner) performZarfAction(action *zarfTypes.ZarfComponentAction) error
 
 		// Otherwise, try running the command.
 		default:
-			ctx, cancel = context.WithTimeout(context.Background(), duration)
+			// Each attempt gets the whole timeout unless it has a timeout of its own.
+			attemptDuration := duration
+			if attemptTimeout > 0 {
+				attemptDuration = min(attemptTimeout, time.Until(deadline))
+			}
+			attemptCtx, cancel = context.WithTimeout(ctx, attemptDuration)
 			defer cancel()
-			if err := tryCmd(ctx); err != nil {
+			if err = tryCmd(attemptCtx); err != nil {
+				if ctx.Err() != nil {
+					return fmt.Errorf("command \"%s\" was canceled: %w", cmdEscaped, context.Cause(ctx))
+				}
 				continue
 			}
 
@@ -472,24 +1079,27 @@ func (r *Runner) performZarfAction(action *zarfTypes.ZarfComponentAction) error
 		}
 	}
 
-	select {
-	case <-timeout:
-		// If we reached this point, the timeout was reached.
-		return fmt.Errorf("command \"%s\" timed out after %d seconds", cmdEscaped, cfg.MaxTotalSeconds)
-
-	default:
-		// If we reached this point, the retry limit was reached.
-		return fmt.Errorf("command \"%s\" failed after %d retries", cmdEscaped, cfg.MaxRetries)
+	// Without a timeout (whose channel fires immediately) the retry limit was reached.
+	if cfg.MaxTotalSeconds > 0 {
+		select {
+		case <-timeout:
+			// If we reached this point, the timeout was reached.
+			return &ActionError{Action: cmdEscaped, Attempts: attempts, Retries: cfg.MaxRetries, TimedOut: true, MaxTotalSeconds: cfg.MaxTotalSeconds, Err: err}
+		default:
+		}
 	}
+
+	// If we reached this point, the retry limit was reached.
+	return &ActionError{Action: cmdEscaped, Attempts: attempts, Retries: cfg.MaxRetries, Err: err}
 }
 
-func (r *Runner) templateString(s string) string {
+func (r *Runner) templateString(ctx context.Context, s string) string {
 	// Create a regular expression to match ${...}
 	re := regexp.MustCompile(`\${(.*?)}`)
 
 	// template string using values from the template map
 	result := re.ReplaceAllStringFunc(s, func(matched string) string {
-		if value, ok := r.TemplateMap[matched]; ok {
+		if value, ok := r.lookupVariable(ctx, matched); ok {
 			return value.Value
 		}
 		return matched // If the key is not found, keep the original substring
@@ -543,11 +1153,50 @@ func convertWaitToCmd(wait zarfTypes.ZarfComponentActionWait, timeout *int) (str
 			network.Protocol, network.Address, network.Code, timeoutString), nil
 	}
 
-	return "", fmt.Errorf("wait action is missing a cluster or network")
+	return "", fmt.Errorf("wait action is missing a cluster, network, file or command")
 }
 
 //go:linkname actionGetCfg github.com/defenseunicorns/zarf/src/pkg/packager.actionGetCfg
 func actionGetCfg(cfg zarfTypes.ZarfComponentActionDefaults, a zarfTypes.ZarfComponentAction, vars map[string]*zarfUtils.TextTemplate) zarfTypes.ZarfComponentActionDefaults
 
-//go:linkname actionRun github.com/defenseunicorns/zarf/src/pkg/packager.actionRun
-func actionRun(ctx context.Context, cfg zarfTypes.ZarfComponentActionDefaults, cmd string, shellPref zarfTypes.ZarfComponentActionShell, spinner *message.Spinner) (string, error)
+// actionRun runs a command like Zarf's actionRun, but masks the values of sensitive variables in its output and logs,
+// also streaming its (masked) output to output when it isn't nil and keeping no more of its output than limit allows.
+// The stdout and stderr of the command are returned apart, and interleaved too when combined is set
+func (r *Runner) actionRun(ctx context.Context, cfg zarfTypes.ZarfComponentActionDefaults, cmd string, shellPref zarfTypes.ZarfComponentActionShell, spinner *message.Spinner, output io.Writer, limit outputLimit, combined bool) (commandOutput, error) {
+	shell, shellArgs, err := shellCommand(shellPref)
+	if err != nil {
+		return commandOutput{}, err
+	}
+
+	message.Debugf("Running command in %s: %s", shell, r.mask(cmd))
+
+	execCfg := exec.Config{
+		Env: append(slices.Clip(cfg.Env), traceEnv(ctx)...),
+		Dir: cfg.Dir,
+	}
+
+	var targets []io.Writer
+	if !cfg.Mute && spinner != nil {
+		targets = append(targets, spinner)
+	}
+	if output != nil {
+		targets = append(targets, output)
+	}
+	if len(targets) > 0 {
+		writer := &maskWriter{w: io.MultiWriter(targets...), mask: r.mask}
+		defer writer.Flush()
+		execCfg.Stdout = writer
+		execCfg.Stderr = writer
+	}
+
+	out, truncated, err := runCommand(ctx, execCfg, limit, combined, shell, append(shellArgs, cmd)...)
+	if truncated && !limit.kill {
+		message.Warnf("The output of \"%s\" exceeded %d bytes, only its first %d bytes were kept", r.mask(cmd), limit.maxBytes, limit.maxBytes)
+	}
+	// Dump final complete output (respect mute to prevent sensitive values from hitting the logs).
+	if !cfg.Mute {
+		message.Debug(r.mask(cmd), r.mask(out.stdout), r.mask(out.stderr))
+	}
+
+	return out, err
+}
@@ -0,0 +1,271 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023-Present The UDS Authors
+
+package runner
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	zarfUtils "github.com/defenseunicorns/zarf/src/pkg/utils"
+	zarfTypes "github.com/defenseunicorns/zarf/src/types"
+	"github.com/pterm/pterm"
+	"github.com/stretchr/testify/require"
+
+	"github.com/defenseunicorns/uds-cli/src/types"
+)
+
+// newTestRunner returns a runner with the given variables and the empty maps RunCommand would otherwise set up,
+// so tests can execute tasks on it; tests set the TasksFile and any other fields they need
+func newTestRunner(variables map[string]*zarfUtils.TextTemplate) *Runner {
+	if variables == nil {
+		variables = map[string]*zarfUtils.TextTemplate{}
+	}
+	return &Runner{
+		TemplateMap:    variables,
+		TaskNameMap:    map[string]bool{},
+		dependencyRuns: map[string]*dependencyRun{},
+	}
+}
+
+func Test_runContextTimeout(t *testing.T) {
+	r := newTestRunner(nil)
+	retries := 5
+	task := types.Task{
+		Name: "slow",
+		Actions: []types.Action{
+			// continueOnError and retries must not outlive the run
+			{ZarfComponentAction: &zarfTypes.ZarfComponentAction{Cmd: "sleep 30", MaxRetries: &retries}, ContinueOnError: true},
+			{ZarfComponentAction: &zarfTypes.ZarfComponentAction{Cmd: "echo never"}},
+		},
+	}
+
+	ctx, cancel := runContext(200 * time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := r.executeTask(ctx, task, false)
+	require.ErrorContains(t, err, "run timed out after 200ms")
+	require.Less(t, time.Since(start), 10*time.Second)
+	require.Empty(t, r.nonFatalFailures)
+}
+
+func Test_runContextInterrupt(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("interrupts can't be sent to the current process on windows")
+	}
+	r := newTestRunner(nil)
+	// no maxTotalSeconds and no run timeout, so only the interrupt stops the command
+	task := types.Task{
+		Name:    "slow",
+		Actions: []types.Action{{ZarfComponentAction: &zarfTypes.ZarfComponentAction{Cmd: "sleep 30"}}},
+	}
+
+	ctx, cancel := runContext(0)
+	defer cancel()
+
+	go func() {
+		time.Sleep(200 * time.Millisecond)
+		process, _ := os.FindProcess(os.Getpid())
+		_ = process.Signal(os.Interrupt)
+	}()
+
+	start := time.Now()
+	err := r.executeTask(ctx, task, false)
+	require.ErrorContains(t, err, "run interrupted by interrupt")
+	require.Less(t, time.Since(start), 10*time.Second)
+}
+
+func Test_executeTaskDescription(t *testing.T) {
+	var out bytes.Buffer
+	pterm.SetDefaultOutput(&out)
+	defer pterm.SetDefaultOutput(os.Stdout)
+
+	r := newTestRunner(nil)
+	require.NoError(t, r.executeTask(context.Background(), types.Task{Name: "build", Description: "Build the app"}, false))
+	require.Contains(t, out.String(), "Build the app")
+
+	out.Reset()
+	require.NoError(t, r.executeTask(context.Background(), types.Task{Name: "silent"}, false))
+	require.Empty(t, out.String())
+}
+
+func Test_placeFilesContent(t *testing.T) {
+	dir := t.TempDir()
+	r := newTestRunner(map[string]*zarfUtils.TextTemplate{"${NAME}": {Value: "podinfo"}})
+	files := []types.File{{
+		ZarfFile: zarfTypes.ZarfFile{Target: "bin/hello.sh", Executable: true, Symlinks: []string{"hello"}},
+		Content:  "#!/bin/sh\necho hello ${NAME}\n",
+	}}
+	require.NoError(t, r.placeFiles(context.Background(), files, dir))
+
+	content, err := os.ReadFile(filepath.Join(dir, "bin", "hello.sh"))
+	require.NoError(t, err)
+	require.Equal(t, "#!/bin/sh\necho hello podinfo\n", string(content))
+	info, err := os.Stat(filepath.Join(dir, "bin", "hello.sh"))
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0700), info.Mode().Perm())
+	linked, err := os.ReadFile(filepath.Join(dir, "hello"))
+	require.NoError(t, err)
+	require.Equal(t, content, linked)
+}
+
+func Test_placeFilesGlob(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "configs"), 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "configs", "a.yaml"), []byte("name: ${NAME}\n"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "configs", "b.yaml"), []byte("b\n"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "configs", "c.txt"), []byte("c\n"), 0600))
+	r := newTestRunner(map[string]*zarfUtils.TextTemplate{"${NAME}": {Value: "podinfo"}})
+
+	files := []types.File{{ZarfFile: zarfTypes.ZarfFile{Source: "configs/*.yaml", Target: "staged"}}}
+	require.NoError(t, r.placeFiles(context.Background(), files, dir))
+	content, err := os.ReadFile(filepath.Join(dir, "staged", "a.yaml"))
+	require.NoError(t, err)
+	require.Equal(t, "name: podinfo\n", string(content))
+	require.FileExists(t, filepath.Join(dir, "staged", "b.yaml"))
+	require.NoFileExists(t, filepath.Join(dir, "staged", "c.txt"))
+
+	files = []types.File{{ZarfFile: zarfTypes.ZarfFile{Source: "configs/*.json", Target: "staged"}}}
+	require.ErrorContains(t, r.placeFiles(context.Background(), files, dir), "matched no files")
+	files[0].AllowEmpty = true
+	require.NoError(t, r.placeFiles(context.Background(), files, dir))
+}
+
+func Test_placeFilesMode(t *testing.T) {
+	dir := t.TempDir()
+	r := newTestRunner(nil)
+	files := []types.File{
+		{ZarfFile: zarfTypes.ZarfFile{Target: "config.yaml"}, Content: "a: b\n", Mode: "0644"},
+		{ZarfFile: zarfTypes.ZarfFile{Target: "run.sh", Executable: true}, Content: "#!/bin/sh\n", Mode: "0750"},
+		{ZarfFile: zarfTypes.ZarfFile{Target: "default.yaml"}, Content: "c: d\n"},
+	}
+	require.NoError(t, r.placeFiles(context.Background(), files, dir))
+	for name, want := range map[string]os.FileMode{"config.yaml": 0644, "run.sh": 0750, "default.yaml": 0600} {
+		info, err := os.Stat(filepath.Join(dir, name))
+		require.NoError(t, err)
+		require.Equal(t, want, info.Mode().Perm(), name)
+	}
+
+	for _, mode := range []string{"644x", "rw-r--r--", "01000", "-1"} {
+		files := []types.File{{ZarfFile: zarfTypes.ZarfFile{Target: "invalid.yaml"}, Content: "e: f\n", Mode: mode}}
+		require.ErrorContains(t, r.placeFiles(context.Background(), files, dir), "invalid mode", mode)
+	}
+	require.NoFileExists(t, filepath.Join(dir, "invalid.yaml"))
+}
+
+func Test_templateWait(t *testing.T) {
+	r := newTestRunner(map[string]*zarfUtils.TextTemplate{
+		"${POD}":  {Value: "podinfo-abc"},
+		"${NS}":   {Value: ""},
+		"${HOST}": {Value: "localhost:8080"},
+	})
+	timeout := 30
+
+	cluster := &zarfTypes.ZarfComponentActionWaitCluster{Kind: "pod", Identifier: "${POD}", Namespace: "${NS}", Condition: "Ready"}
+	cmd, err := convertWaitToCmd(r.templateWait(context.Background(), zarfTypes.ZarfComponentActionWait{Cluster: cluster}), &timeout)
+	require.NoError(t, err)
+	// an empty namespace doesn't leave a dangling -n
+	require.Equal(t, "./uds tools wait-for pod podinfo-abc Ready  --timeout 30s", cmd)
+	require.Equal(t, "${POD}", cluster.Identifier)
+
+	network := &zarfTypes.ZarfComponentActionWaitNetwork{Protocol: "HTTP", Address: "${HOST}/healthz"}
+	cmd, err = convertWaitToCmd(r.templateWait(context.Background(), zarfTypes.ZarfComponentActionWait{Network: network}), &timeout)
+	require.NoError(t, err)
+	require.Equal(t, "./uds tools wait-for http localhost:8080/healthz 200 --timeout 30s", cmd)
+	require.Equal(t, "HTTP", network.Protocol)
+	require.Zero(t, network.Code)
+}
+
+func Test_actionOutputFile(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the command redirects to stderr with sh syntax")
+	}
+	dir := t.TempDir()
+	r := newTestRunner(map[string]*zarfUtils.TextTemplate{
+		"${LOG}":    {Value: "build"},
+		"${SECRET}": {Value: "hunter2", Sensitive: true},
+	})
+	task := types.Task{Name: "build", Dir: dir, Actions: []types.Action{{
+		ZarfComponentAction: &zarfTypes.ZarfComponentAction{Cmd: "echo built ${SECRET}; echo warning >&2"},
+		OutputFile:          "logs/${LOG}.log",
+		SetVariables:        []types.SetVariable{{ZarfComponentActionSetVariable: zarfTypes.ZarfComponentActionSetVariable{Name: "RESULT"}}},
+	}}}
+	require.NoError(t, r.executeTask(context.Background(), task, false))
+
+	log, err := os.ReadFile(filepath.Join(dir, "logs", "build.log"))
+	require.NoError(t, err)
+	require.Contains(t, string(log), "built ****\n")
+	require.Contains(t, string(log), "warning\n")
+	require.Equal(t, "built hunter2", r.TemplateMap["${RESULT}"].Value)
+}
+
+func Test_actionSetVariableStream(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the command redirects to stderr with sh syntax")
+	}
+	setVariable := func(name, stream string) types.SetVariable {
+		return types.SetVariable{ZarfComponentActionSetVariable: zarfTypes.ZarfComponentActionSetVariable{Name: name}, Stream: stream}
+	}
+	r := newTestRunner(nil)
+	task := types.Task{Name: "print", Dir: t.TempDir(), Actions: []types.Action{{
+		ZarfComponentAction: &zarfTypes.ZarfComponentAction{Cmd: "echo out; sleep 0.1; echo err >&2; sleep 0.1; echo more"},
+		SetVariables: []types.SetVariable{
+			setVariable("DEFAULT", ""),
+			setVariable("STDOUT", types.StreamStdout),
+			setVariable("STDERR", types.StreamStderr),
+			setVariable("COMBINED", types.StreamCombined),
+		},
+	}}}
+	require.NoError(t, r.executeTask(context.Background(), task, false))
+	require.Equal(t, "out\nmore", r.TemplateMap["${DEFAULT}"].Value)
+	require.Equal(t, "out\nmore", r.TemplateMap["${STDOUT}"].Value)
+	require.Equal(t, "err", r.TemplateMap["${STDERR}"].Value)
+	require.Equal(t, "out\nerr\nmore", r.TemplateMap["${COMBINED}"].Value)
+
+	task.Actions[0].SetVariables = []types.SetVariable{setVariable("RESULT", "both")}
+	require.ErrorContains(t, r.executeTask(context.Background(), task, false), `unable to set variable RESULT: invalid stream "both"`)
+}
+
+func Test_actionMaxOutputBytes(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the command prints with sh syntax")
+	}
+	action := func(cmd string, maxOutputBytes int, onMaxOutput string) types.Action {
+		return types.Action{
+			ZarfComponentAction: &zarfTypes.ZarfComponentAction{Cmd: cmd},
+			MaxOutputBytes:      maxOutputBytes,
+			OnMaxOutput:         onMaxOutput,
+			SetVariables:        []types.SetVariable{{ZarfComponentActionSetVariable: zarfTypes.ZarfComponentActionSetVariable{Name: "RESULT"}}},
+		}
+	}
+	run := func(action types.Action) (*Runner, error) {
+		r := newTestRunner(nil)
+		task := types.Task{Name: "print", Dir: t.TempDir(), Actions: []types.Action{action}}
+		return r, r.executeTask(context.Background(), task, false)
+	}
+
+	// the command completes with its output truncated
+	r, err := run(action("printf 0123456789", 4, ""))
+	require.NoError(t, err)
+	require.Equal(t, "0123"+truncatedOutput(4), r.TemplateMap["${RESULT}"].Value)
+
+	// output within the limit is kept as is
+	r, err = run(action("printf 0123", 4, types.OnMaxOutputKill))
+	require.NoError(t, err)
+	require.Equal(t, "0123", r.TemplateMap["${RESULT}"].Value)
+
+	// the command is killed before it completes
+	start := time.Now()
+	_, err = run(action("printf 0123456789; sleep 10", 4, types.OnMaxOutputKill))
+	require.ErrorContains(t, err, "command output exceeded 4 bytes and the command was killed")
+	require.Less(t, time.Since(start), 5*time.Second)
+
+	_, err = run(action("printf 0123", 0, "ignore"))
+	require.ErrorContains(t, err, `invalid onMaxOutput "ignore"`)
+}
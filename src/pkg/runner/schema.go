@@ -0,0 +1,138 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023-Present The UDS Authors
+
+// Package runner provides functions for running tasks in a run.yaml
+package runner
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/alecthomas/jsonschema"
+	"github.com/goccy/go-yaml"
+	"github.com/goccy/go-yaml/ast"
+	"github.com/goccy/go-yaml/parser"
+	"github.com/xeipuuv/gojsonschema"
+
+	"github.com/defenseunicorns/uds-cli/src/types"
+)
+
+// SchemaError lists every field of a tasks file that doesn't match the tasks schema
+type SchemaError struct {
+	Problems []string
+}
+
+func (e *SchemaError) Error() string {
+	return fmt.Sprintf("found %d schema error(s) in the tasks file:\n- %s", len(e.Problems), strings.Join(e.Problems, "\n- "))
+}
+
+// TasksSchema returns the JSON schema of a tasks file, generated from the TasksFile type
+func TasksSchema() *jsonschema.Schema {
+	schema := jsonschema.Reflect(&types.TasksFile{})
+	// a file's source is optional since it can have inline content instead (required is inherited from Zarf's file)
+	if file, ok := schema.Definitions["File"]; ok {
+		file.Required = slices.DeleteFunc(file.Required, func(name string) bool { return name == "source" })
+	}
+	return schema
+}
+
+// ValidateSchema validates the tasks file at path against the tasks schema, returning a *SchemaError listing each
+// field that doesn't match it with its line number. Unlike Check, it doesn't look at what the tasks do
+func ValidateSchema(path string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	file, err := parser.ParseBytes(content, 0)
+	if err != nil {
+		return err
+	}
+	document, err := yaml.YAMLToJSON(content)
+	if err != nil {
+		return err
+	}
+	schema, err := json.Marshal(TasksSchema())
+	if err != nil {
+		return err
+	}
+
+	result, err := gojsonschema.Validate(gojsonschema.NewBytesLoader(schema), gojsonschema.NewBytesLoader(document))
+	if err != nil {
+		return err
+	}
+	if result.Valid() {
+		return nil
+	}
+
+	type problem struct {
+		line    int
+		message string
+	}
+	var problems []problem
+	for _, resultErr := range result.Errors() {
+		// the context is the path from the root of the document to the field, e.g. (root).tasks.0.actions
+		segments := strings.Split(resultErr.Context().String("\x00"), "\x00")[1:]
+		if property, ok := resultErr.Details()["property"].(string); ok && resultErr.Type() == "additional_property_not_allowed" {
+			segments = append(segments, property)
+		}
+		problems = append(problems, problem{
+			line:    yamlLine(file, segments),
+			message: fmt.Sprintf("%s: %s", resultErr.Field(), resultErr.Description()),
+		})
+	}
+	sort.SliceStable(problems, func(i, j int) bool { return problems[i].line < problems[j].line })
+
+	schemaErr := &SchemaError{}
+	for _, p := range problems {
+		schemaErr.Problems = append(schemaErr.Problems, fmt.Sprintf("line %d: %s", p.line, p.message))
+	}
+	return schemaErr
+}
+
+// yamlLine returns the line of the node at the given path of keys and indexes in a YAML file, or of its deepest
+// ancestor that exists
+func yamlLine(file *ast.File, segments []string) int {
+	if len(file.Docs) == 0 || file.Docs[0].Body == nil {
+		return 1
+	}
+	node := file.Docs[0].Body
+	line := node.GetToken().Position.Line
+	for _, segment := range segments {
+		node = yamlChild(node, segment)
+		if node == nil {
+			break
+		}
+		line = node.GetToken().Position.Line
+	}
+	return line
+}
+
+// yamlChild returns the value of a key of a mapping node or the item at an index of a sequence node
+func yamlChild(node ast.Node, segment string) ast.Node {
+	switch n := node.(type) {
+	case *ast.AnchorNode:
+		return yamlChild(n.Value, segment)
+	case *ast.TagNode:
+		return yamlChild(n.Value, segment)
+	case *ast.MappingValueNode:
+		if n.Key.GetToken().Value == segment {
+			return n.Value
+		}
+	case *ast.MappingNode:
+		for _, value := range n.Values {
+			if value.Key.GetToken().Value == segment {
+				return value.Value
+			}
+		}
+	case *ast.SequenceNode:
+		if idx, err := strconv.Atoi(segment); err == nil && idx >= 0 && idx < len(n.Values) {
+			return n.Values[idx]
+		}
+	}
+	return nil
+}
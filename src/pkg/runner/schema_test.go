@@ -0,0 +1,65 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023-Present The UDS Authors
+
+package runner
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateSchema(t *testing.T) {
+	tests := []struct {
+		name         string
+		content      string
+		wantProblems []string
+	}{
+		{
+			name: "valid",
+			content: `tasks:
+  - name: hello
+    actions:
+      - cmd: echo hello
+`,
+		},
+		{
+			name: "field errors",
+			content: `variables:
+  - name: FOO
+    default: bar
+tasks:
+  - name: hello
+    actions:
+      - cmd: echo hello
+        maxRetries: three
+  - description: no name
+    actions:
+      - cmd: echo hi
+        unknown: true
+`,
+			wantProblems: []string{
+				"line 8: tasks.0.actions.0.maxRetries: Invalid type. Expected: integer, given: string",
+				"line 9: tasks.1: name is required",
+				"line 12: tasks.1.actions.0: Additional property unknown is not allowed",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "tasks.yaml")
+			require.NoError(t, os.WriteFile(path, []byte(tt.content), 0600))
+			err := ValidateSchema(path)
+			if tt.wantProblems == nil {
+				require.NoError(t, err)
+				return
+			}
+			var schemaErr *SchemaError
+			require.True(t, errors.As(err, &schemaErr), err)
+			require.Equal(t, tt.wantProblems, schemaErr.Problems)
+		})
+	}
+}
@@ -0,0 +1,69 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023-Present The UDS Authors
+
+// Package runner provides functions for running tasks in a run.yaml
+package runner
+
+import (
+	"context"
+
+	zarfUtils "github.com/defenseunicorns/zarf/src/pkg/utils"
+
+	"github.com/defenseunicorns/uds-cli/src/types"
+)
+
+// Variable scoping of referenced tasks:
+//
+// A referenced task runs on the same Runner as its caller, so by default it sees the caller's variables and the
+// variables it sets (with setVariables or an env file) are visible to the caller's following actions. A task
+// reference with isolate: true runs the task with a copy of the variables instead, discarding its changes once it
+// completes.
+
+// executeIsolatedTask runs a referenced task with a copy of the variables, reverting the variables it set or changed
+// once it completes
+func (r *Runner) executeIsolatedTask(ctx context.Context, task types.Task, buffered bool) error {
+	snapshot := r.copyTemplateMap()
+	defer r.restoreTemplateMap(snapshot)
+	return r.executeTask(ctx, task, buffered)
+}
+
+// copyTemplateMap returns a deep copy of the template map
+func (r *Runner) copyTemplateMap() map[string]zarfUtils.TextTemplate {
+	r.templateMapMu.RLock()
+	defer r.templateMapMu.RUnlock()
+	snapshot := make(map[string]zarfUtils.TextTemplate, len(r.TemplateMap))
+	for key, template := range r.TemplateMap {
+		snapshot[key] = *template
+	}
+	return snapshot
+}
+
+// restoreTemplateMap reverts the variables that differ from a copy of the template map, replacing their templates
+// with fresh copies (so the templates held by getVariable callers are never changed) and leaving the others as they are
+func (r *Runner) restoreTemplateMap(snapshot map[string]zarfUtils.TextTemplate) {
+	restored := map[string]*zarfUtils.TextTemplate{}
+	removed := []string{}
+	r.readTemplateMap(func(templateMap map[string]*zarfUtils.TextTemplate) {
+		for key, template := range templateMap {
+			original, ok := snapshot[key]
+			if !ok {
+				removed = append(removed, key)
+			} else if *template != original {
+				restored[key] = &original
+			}
+		}
+		for key, original := range snapshot {
+			if _, ok := templateMap[key]; !ok {
+				original := original
+				restored[key] = &original
+			}
+		}
+	})
+
+	for _, key := range removed {
+		r.setVariable(key, nil)
+	}
+	for key, template := range restored {
+		r.setVariable(key, template)
+	}
+}
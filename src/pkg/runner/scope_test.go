@@ -0,0 +1,86 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023-Present The UDS Authors
+
+package runner
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	zarfUtils "github.com/defenseunicorns/zarf/src/pkg/utils"
+	zarfTypes "github.com/defenseunicorns/zarf/src/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/defenseunicorns/uds-cli/src/types"
+)
+
+func Test_referencedTaskScope(t *testing.T) {
+	setVariable := func(cmd, name string) types.Action {
+		return types.Action{
+			ZarfComponentAction: &zarfTypes.ZarfComponentAction{Cmd: cmd},
+			SetVariables:        []types.SetVariable{{ZarfComponentActionSetVariable: zarfTypes.ZarfComponentActionSetVariable{Name: name}}},
+		}
+	}
+
+	for _, isolate := range []bool{false, true} {
+		t.Run(fmt.Sprintf("isolate=%t", isolate), func(t *testing.T) {
+			seenFile := filepath.Join(t.TempDir(), "seen")
+			r := newTestRunner(map[string]*zarfUtils.TextTemplate{"${SHARED}": {Value: "caller"}})
+			r.TasksFile = types.TasksFile{Tasks: []types.Task{
+				{Name: "caller", Actions: []types.Action{
+					setVariable("echo outer", "OUTER"),
+					{TaskReference: "child", Isolate: isolate},
+					setVariable("echo ${SHARED}", "AFTER"),
+				}},
+				{Name: "child", Actions: []types.Action{
+					{ZarfComponentAction: &zarfTypes.ZarfComponentAction{Cmd: "echo ${OUTER} > " + seenFile}},
+					setVariable("echo ${OUTER}-inner", "INNER"),
+					setVariable("echo child", "SHARED"),
+				}},
+			}}
+
+			task, err := r.getTask("caller")
+			require.NoError(t, err)
+			require.NoError(t, r.executeTask(context.Background(), task, false))
+
+			// the referenced task sees the caller's variables either way
+			seen, err := os.ReadFile(seenFile)
+			require.NoError(t, err)
+			require.Equal(t, "outer", strings.TrimSpace(string(seen)))
+
+			if isolate {
+				require.NotContains(t, r.TemplateMap, "${INNER}")
+				require.Equal(t, "caller", r.TemplateMap["${SHARED}"].Value)
+				require.Equal(t, "caller", r.TemplateMap["${AFTER}"].Value)
+			} else {
+				require.Equal(t, "outer-inner", r.TemplateMap["${INNER}"].Value)
+				require.Equal(t, "child", r.TemplateMap["${SHARED}"].Value)
+				require.Equal(t, "child", r.TemplateMap["${AFTER}"].Value)
+			}
+		})
+	}
+}
+
+func Test_restoreTemplateMap(t *testing.T) {
+	r := newTestRunner(map[string]*zarfUtils.TextTemplate{
+		"${SHARED}": {Value: "caller"},
+		"${KEPT}":   {Value: "kept"},
+	})
+	kept, _ := r.getVariable("${KEPT}")
+
+	snapshot := r.copyTemplateMap()
+	changed := &zarfUtils.TextTemplate{Value: "child"}
+	r.setVariable("${SHARED}", changed)
+	r.setVariable("${INNER}", &zarfUtils.TextTemplate{Value: "inner"})
+	r.restoreTemplateMap(snapshot)
+
+	require.Equal(t, "caller", r.TemplateMap["${SHARED}"].Value)
+	require.NotContains(t, r.TemplateMap, "${INNER}")
+	// the templates are replaced rather than changed, so the ones already read stay as they were
+	require.Equal(t, "child", changed.Value)
+	require.Same(t, kept, r.TemplateMap["${KEPT}"])
+}
@@ -0,0 +1,50 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023-Present The UDS Authors
+
+// Package runner provides functions for running tasks in a run.yaml
+package runner
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/defenseunicorns/uds-cli/src/types"
+)
+
+// labelSelectorPrefix is the prefix of a selector that matches the tasks with a label
+const labelSelectorPrefix = "label="
+
+// SelectTasks returns the names of the tasks in a tasks file (in the order they're declared) that match every
+// selector, e.g. label=ci. Tasks from included files can't be selected
+func SelectTasks(tasksFile types.TasksFile, selectors []string) ([]string, error) {
+	var labels []string
+	for _, selector := range selectors {
+		label, ok := strings.CutPrefix(strings.TrimSpace(selector), labelSelectorPrefix)
+		if !ok || label == "" {
+			return nil, fmt.Errorf("invalid selector %q, must be %s<label>", selector, labelSelectorPrefix)
+		}
+		labels = append(labels, label)
+	}
+	if len(labels) == 0 {
+		return nil, fmt.Errorf("no selector given")
+	}
+
+	var names []string
+	for _, task := range tasksFile.Tasks {
+		matches := true
+		for _, label := range labels {
+			if !slices.Contains(task.Labels, label) {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			names = append(names, task.Name)
+		}
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("no tasks match the selector %s", strings.Join(selectors, ","))
+	}
+	return names, nil
+}
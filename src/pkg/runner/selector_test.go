@@ -0,0 +1,44 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023-Present The UDS Authors
+
+package runner
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/defenseunicorns/uds-cli/src/types"
+)
+
+func TestSelectTasks(t *testing.T) {
+	tasksFile := types.TasksFile{Tasks: []types.Task{
+		{Name: "lint", Labels: []string{"ci", "lint"}},
+		{Name: "build"},
+		{Name: "test", Labels: []string{"ci"}},
+	}}
+
+	tests := []struct {
+		name      string
+		selectors []string
+		want      []string
+		wantErr   string
+	}{
+		{name: "one label", selectors: []string{"label=ci"}, want: []string{"lint", "test"}},
+		{name: "every label must match", selectors: []string{"label=ci", "label=lint"}, want: []string{"lint"}},
+		{name: "no match", selectors: []string{"label=release"}, wantErr: "no tasks match the selector label=release"},
+		{name: "invalid selector", selectors: []string{"ci"}, wantErr: `invalid selector "ci"`},
+		{name: "empty label", selectors: []string{"label="}, wantErr: "invalid selector"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			names, err := SelectTasks(tasksFile, tt.selectors)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, names)
+		})
+	}
+}
@@ -0,0 +1,74 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023-Present The UDS Authors
+
+// Package runner provides functions for running tasks in a run.yaml
+package runner
+
+import (
+	"bytes"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+
+	zarfUtils "github.com/defenseunicorns/zarf/src/pkg/utils"
+)
+
+// maskedValue replaces the values of sensitive variables in anything the runner prints
+const maskedValue = "****"
+
+// mask replaces the values of sensitive variables in s
+func (r *Runner) mask(s string) string {
+	values := []string{}
+	r.readTemplateMap(func(templateMap map[string]*zarfUtils.TextTemplate) {
+		for _, template := range templateMap {
+			if template.Sensitive && template.Value != "" {
+				values = append(values, template.Value)
+			}
+		}
+	})
+
+	// replace longer values first so a value containing another is fully masked
+	sort.Slice(values, func(i, j int) bool { return len(values[i]) > len(values[j]) })
+	for _, value := range values {
+		s = strings.ReplaceAll(s, value, maskedValue)
+	}
+	return s
+}
+
+// maskWriter masks the values of sensitive variables written to w, buffering partial lines so values aren't
+// split across writes
+type maskWriter struct {
+	w    io.Writer
+	mask func(string) string
+	mu   sync.Mutex
+	buf  bytes.Buffer
+}
+
+// Write writes the complete (masked) lines of p to the underlying writer
+func (m *maskWriter) Write(p []byte) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.buf.Write(p)
+	if i := bytes.LastIndexByte(m.buf.Bytes(), '\n'); i >= 0 {
+		lines := m.buf.Next(i + 1)
+		if _, err := io.WriteString(m.w, m.mask(string(lines))); err != nil {
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
+
+// Flush writes any remaining partial line to the underlying writer
+func (m *maskWriter) Flush() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.buf.Len() == 0 {
+		return nil
+	}
+	_, err := io.WriteString(m.w, m.mask(m.buf.String()))
+	m.buf.Reset()
+	return err
+}
@@ -0,0 +1,36 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023-Present The UDS Authors
+
+package runner
+
+import (
+	"bytes"
+	"testing"
+
+	zarfUtils "github.com/defenseunicorns/zarf/src/pkg/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_mask(t *testing.T) {
+	r := newTestRunner(map[string]*zarfUtils.TextTemplate{
+		"${PASSWORD}":      {Value: "hunter2", Sensitive: true},
+		"${LONG_PASSWORD}": {Value: "hunter2hunter2", Sensitive: true},
+		"${EMPTY}":         {Value: "", Sensitive: true},
+		"${USER}":          {Value: "admin"},
+	})
+
+	require.Equal(t, "admin:****", r.mask("admin:hunter2"))
+	require.Equal(t, "admin:****", r.mask("admin:hunter2hunter2"))
+	require.Equal(t, "nothing to hide", r.mask("nothing to hide"))
+
+	var out bytes.Buffer
+	writer := &maskWriter{w: &out, mask: r.mask}
+	_, err := writer.Write([]byte("password: hun"))
+	require.NoError(t, err)
+	require.Empty(t, out.String())
+	_, err = writer.Write([]byte("ter2\nuser: adm"))
+	require.NoError(t, err)
+	require.Equal(t, "password: ****\n", out.String())
+	require.NoError(t, writer.Flush())
+	require.Equal(t, "password: ****\nuser: adm", out.String())
+}
@@ -0,0 +1,57 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023-Present The UDS Authors
+
+// Package runner provides functions for running tasks in a run.yaml
+package runner
+
+import (
+	"fmt"
+	osExec "os/exec"
+	"strings"
+
+	"github.com/defenseunicorns/zarf/src/pkg/utils/exec"
+	zarfTypes "github.com/defenseunicorns/zarf/src/types"
+)
+
+// actionShell returns the shell preference of an action, falling back to the tasks file's shell for each OS that the
+// action doesn't set a shell for
+func (r *Runner) actionShell(shell *zarfTypes.ZarfComponentActionShell) zarfTypes.ZarfComponentActionShell {
+	var merged zarfTypes.ZarfComponentActionShell
+	if r.TasksFile.Shell != nil {
+		merged = *r.TasksFile.Shell
+	}
+	if shell == nil {
+		return merged
+	}
+	if shell.Windows != "" {
+		merged.Windows = shell.Windows
+	}
+	if shell.Linux != "" {
+		merged.Linux = shell.Linux
+	}
+	if shell.Darwin != "" {
+		merged.Darwin = shell.Darwin
+	}
+	return merged
+}
+
+// shellCommand returns the shell to run commands with on this OS and the args that come before the command
+//
+// a shell preference can include flags for the shell (e.g. bash -euo pipefail), which are passed before the arg that
+// introduces the command (-c, -Command or /c)
+func shellCommand(shellPref zarfTypes.ZarfComponentActionShell) (string, []string, error) {
+	preferred, _ := exec.GetOSShell(shellPref)
+	fields := strings.Fields(preferred)
+	if len(fields) == 0 {
+		return "", nil, fmt.Errorf("shell %q is empty", preferred)
+	}
+
+	shell := fields[0]
+	if _, err := osExec.LookPath(shell); err != nil {
+		return "", nil, fmt.Errorf("unknown shell %q, it must be a shell in your PATH such as sh, bash, zsh, fish, pwsh, powershell or cmd: %w", shell, err)
+	}
+
+	// get the arg that introduces the command for the shell itself rather than for its flags
+	_, shellArgs := exec.GetOSShell(zarfTypes.ZarfComponentActionShell{Windows: shell, Linux: shell, Darwin: shell})
+	return shell, append(fields[1:], shellArgs), nil
+}
@@ -0,0 +1,46 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023-Present The UDS Authors
+
+package runner
+
+import (
+	"runtime"
+	"testing"
+
+	zarfTypes "github.com/defenseunicorns/zarf/src/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/defenseunicorns/uds-cli/src/types"
+)
+
+func Test_actionShell(t *testing.T) {
+	r := newTestRunner(nil)
+	require.Equal(t, zarfTypes.ZarfComponentActionShell{}, r.actionShell(nil))
+
+	r.TasksFile = types.TasksFile{Shell: &zarfTypes.ZarfComponentActionShell{Linux: "bash", Darwin: "bash", Windows: "pwsh"}}
+	require.Equal(t, *r.TasksFile.Shell, r.actionShell(nil))
+	require.Equal(t, zarfTypes.ZarfComponentActionShell{Linux: "zsh", Darwin: "bash", Windows: "pwsh"},
+		r.actionShell(&zarfTypes.ZarfComponentActionShell{Linux: "zsh"}))
+}
+
+func Test_shellCommand(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shells differ on windows")
+	}
+	everywhere := func(shell string) zarfTypes.ZarfComponentActionShell {
+		return zarfTypes.ZarfComponentActionShell{Windows: shell, Linux: shell, Darwin: shell}
+	}
+
+	shell, args, err := shellCommand(zarfTypes.ZarfComponentActionShell{})
+	require.NoError(t, err)
+	require.Equal(t, "sh", shell)
+	require.Equal(t, []string{"-c"}, args)
+
+	shell, args, err = shellCommand(everywhere("sh -eu"))
+	require.NoError(t, err)
+	require.Equal(t, "sh", shell)
+	require.Equal(t, []string{"-eu", "-c"}, args)
+
+	_, _, err = shellCommand(everywhere("not-a-shell -e"))
+	require.ErrorContains(t, err, `unknown shell "not-a-shell"`)
+}
@@ -0,0 +1,210 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023-Present The UDS Authors
+
+// Package runner provides functions for running tasks in a run.yaml
+package runner
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/defenseunicorns/zarf/src/pkg/message"
+	zarfUtils "github.com/defenseunicorns/zarf/src/pkg/utils"
+
+	"github.com/defenseunicorns/uds-cli/src/types"
+)
+
+// runState is the state of a run persisted to its --state-file, which records the actions the run completed so that
+// a failed run can be resumed with --resume without running them again
+type runState struct {
+	// Tasks are the names of the tasks the run was started with
+	Tasks []string `json:"tasks"`
+	// Completed maps the ID of each completed action to its record
+	Completed map[string]completedAction `json:"completed"`
+}
+
+// completedAction is the record of an action that completed in a run
+type completedAction struct {
+	// Digest is the digest of the action's definition, an action whose definition changed since it completed runs again
+	Digest string `json:"digest"`
+	// Variables holds the values of the variables the action set, which are set again when it is skipped
+	Variables map[string]string `json:"variables,omitempty"`
+}
+
+// stateFile tracks the completed actions of a run in its state file
+//
+// only cmd, wait and http actions are tracked, an action that references a task always runs so that the actions of
+// the referenced task are tracked (and skipped) one by one
+type stateFile struct {
+	path string
+
+	mu    sync.Mutex
+	state runState
+	// occurrences counts the times each action ran in this run, so an action of a task that runs more than once (e.g.
+	// from a forEach) has a different ID each time
+	occurrences map[string]int
+}
+
+// newStateFile starts tracking the completed actions of a run of tasks in the state file at path, carrying over the
+// actions completed by the previous run recorded in it when resume is set
+func newStateFile(path string, tasks []string, resume bool) (*stateFile, error) {
+	s := &stateFile{
+		path:        path,
+		state:       runState{Tasks: tasks, Completed: map[string]completedAction{}},
+		occurrences: map[string]int{},
+	}
+	if !resume {
+		return s, nil
+	}
+
+	content, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		message.Debugf("No state file at %s, running every action", path)
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to read state file: %w", err)
+	}
+	var previous runState
+	if err := json.Unmarshal(content, &previous); err != nil {
+		return nil, fmt.Errorf("unable to read state file %s: %w", path, err)
+	}
+	if !slices.Equal(previous.Tasks, tasks) {
+		return nil, fmt.Errorf("state file %s is from a run of %s, not %s", path,
+			strings.Join(previous.Tasks, ", "), strings.Join(tasks, ", "))
+	}
+	if previous.Completed != nil {
+		s.state.Completed = previous.Completed
+	}
+	message.Infof("Resuming the run of %s, %d completed action(s) are skipped", strings.Join(tasks, ", "), len(s.state.Completed))
+	return s, nil
+}
+
+// actionID returns the ID of the action at index of a task, which is stable between runs of the same tasks file
+func (s *stateFile) actionID(taskName string, index int) string {
+	key := fmt.Sprintf("%s/%d", taskName, index)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.occurrences[key]++
+	if n := s.occurrences[key]; n > 1 {
+		return fmt.Sprintf("%s#%d", key, n)
+	}
+	return key
+}
+
+// completed returns the record of an action that completed in the previous run and hasn't changed since
+func (s *stateFile) completed(id string, action types.Action) (completedAction, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	record, ok := s.state.Completed[id]
+	if !ok || record.Digest != actionDigest(action) {
+		return completedAction{}, false
+	}
+	return record, true
+}
+
+// complete records an action as completed along with the variables it set, saving the state file
+func (s *stateFile) complete(id string, action types.Action, variables map[string]string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.state.Completed[id] = completedAction{Digest: actionDigest(action), Variables: variables}
+	return s.save()
+}
+
+// save writes the state file, replacing it in one go so an interrupted run can't leave a partial file behind
+func (s *stateFile) save() error {
+	content, err := json.MarshalIndent(s.state, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0700); err != nil {
+		return fmt.Errorf("unable to write state file: %w", err)
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, content, 0600); err != nil {
+		return fmt.Errorf("unable to write state file: %w", err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return fmt.Errorf("unable to write state file: %w", err)
+	}
+	return nil
+}
+
+// remove deletes the state file once the run succeeded, so the next run starts from the beginning
+func (s *stateFile) remove() error {
+	if err := os.Remove(s.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("unable to remove state file: %w", err)
+	}
+	return nil
+}
+
+// actionDigest returns the digest of the definition of an action
+func actionDigest(action types.Action) string {
+	content, _ := json.Marshal(action)
+	return fmt.Sprintf("sha256:%x", sha256.Sum256(content))
+}
+
+// tracksAction returns true if an action is skipped when it completed in the run being resumed
+func tracksAction(action types.Action) bool {
+	// sensitive variables aren't written to the state file, so the actions that set them always run
+	setsSensitive := slices.ContainsFunc(action.SetVariables, func(v types.SetVariable) bool { return v.Sensitive })
+	return action.TaskReference == "" && !action.AlwaysRun && !setsSensitive
+}
+
+// performTaskAction performs the action at index of a task, recording it in the state file once it completes or
+// skipping it if it already completed in the run being resumed
+func (r *Runner) performTaskAction(ctx context.Context, task types.Task, index int, action types.Action, buffered bool) error {
+	// the beforeAll actions set up the run, so they run again along with the tasks
+	if r.state == nil || task.Name == beforeAllTask || !tracksAction(action) {
+		return r.performAction(ctx, task.Name, action, buffered)
+	}
+
+	id := r.state.actionID(task.Name, index)
+	if record, ok := r.state.completed(id, action); ok {
+		r.restoreVariables(action, record.Variables)
+		name := r.mask(actionName(action))
+		progress := r.newActionProgress(buffered, "Checking state of \"%s\"", name)
+		progress.Successf("Skipped \"%s\" (completed in the previous run)", name)
+		r.logAction(task.Name, action, time.Now(), 0, errActionSkipped)
+		return nil
+	}
+
+	err := r.attemptAction(ctx, task.Name, action, buffered)
+	if err == nil {
+		variables := map[string]string{}
+		for _, v := range action.SetVariables {
+			if template, ok := r.getVariable("${" + v.Name + "}"); ok {
+				variables[v.Name] = template.Value
+			}
+		}
+		if err := r.state.complete(id, action, variables); err != nil {
+			return err
+		}
+	}
+	return r.settleAction(ctx, action, err)
+}
+
+// restoreVariables sets the variables an action set when it completed in the run being resumed
+func (r *Runner) restoreVariables(action types.Action, values map[string]string) {
+	for _, v := range action.SetVariables {
+		value, ok := values[v.Name]
+		if !ok {
+			continue
+		}
+		r.setVariable("${"+v.Name+"}", &zarfUtils.TextTemplate{
+			AutoIndent: v.AutoIndent,
+			Type:       v.Type,
+			Value:      value,
+		})
+	}
+}
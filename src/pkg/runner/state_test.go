@@ -0,0 +1,91 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023-Present The UDS Authors
+
+package runner
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	zarfTypes "github.com/defenseunicorns/zarf/src/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/defenseunicorns/uds-cli/src/types"
+)
+
+func Test_resume(t *testing.T) {
+	cmd := func(cmd string) types.Action {
+		return types.Action{ZarfComponentAction: &zarfTypes.ZarfComponentAction{Cmd: cmd}}
+	}
+	setName := cmd("echo first >> log && echo v1")
+	setName.SetVariables = []types.SetVariable{{ZarfComponentActionSetVariable: zarfTypes.ZarfComponentActionSetVariable{Name: "NAME"}}}
+	always := cmd("echo always >> log")
+	always.AlwaysRun = true
+
+	dir := t.TempDir()
+	statePath := filepath.Join(dir, "state.json")
+	task := types.Task{Name: "test", Dir: dir, Actions: []types.Action{
+		setName,
+		always,
+		cmd("test -f ok"),
+		cmd("echo ${NAME} >> log"),
+	}}
+
+	run := func(resume bool) error {
+		r := newTestRunner(nil)
+		state, err := newStateFile(statePath, []string{"test"}, resume)
+		require.NoError(t, err)
+		r.state = state
+		if err := r.executeTask(context.Background(), task, false); err != nil {
+			return err
+		}
+		return r.state.remove()
+	}
+	readLog := func() string {
+		log, err := os.ReadFile(filepath.Join(dir, "log"))
+		require.NoError(t, err)
+		return string(log)
+	}
+
+	// the first run fails, recording the actions that completed before it did
+	require.ErrorContains(t, run(false), "exit status 1")
+	require.Equal(t, "first\nalways\n", readLog())
+	require.FileExists(t, statePath)
+
+	// resuming skips the completed action (setting its variable again) but runs the alwaysRun action
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "ok"), nil, 0600))
+	require.NoError(t, run(true))
+	require.Equal(t, "first\nalways\nalways\nv1\n", readLog())
+	require.NoFileExists(t, statePath)
+
+	// without a state file to resume from every action runs
+	require.NoError(t, run(true))
+	require.Equal(t, "first\nalways\nalways\nv1\nfirst\nalways\nv1\n", readLog())
+}
+
+func Test_resumeChangedAction(t *testing.T) {
+	dir := t.TempDir()
+	statePath := filepath.Join(dir, "state.json")
+	action := types.Action{ZarfComponentAction: &zarfTypes.ZarfComponentAction{Cmd: "echo one"}}
+
+	state, err := newStateFile(statePath, []string{"test"}, false)
+	require.NoError(t, err)
+	require.NoError(t, state.complete(state.actionID("test", 0), action, nil))
+
+	state, err = newStateFile(statePath, []string{"test"}, true)
+	require.NoError(t, err)
+	id := state.actionID("test", 0)
+	_, ok := state.completed(id, action)
+	require.True(t, ok)
+	action.Cmd = "echo two"
+	_, ok = state.completed(id, action)
+	require.False(t, ok)
+
+	// the next run of the same action in the run has a different ID
+	require.Equal(t, "test/0#2", state.actionID("test", 0))
+
+	_, err = newStateFile(statePath, []string{"other"}, true)
+	require.ErrorContains(t, err, "is from a run of test, not other")
+}
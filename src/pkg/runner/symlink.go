@@ -0,0 +1,64 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023-Present The UDS Authors
+
+// Package runner provides functions for running tasks in a run.yaml
+package runner
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	zarfUtils "github.com/defenseunicorns/zarf/src/pkg/utils"
+
+	"github.com/defenseunicorns/uds-cli/src/types"
+)
+
+// placeSymlinks creates the symlinks of a file placed at dest, resolving relative links against the working directory
+func (r *Runner) placeSymlinks(ctx context.Context, file types.File, workingDir, dest string) error {
+	absolute := filepath.IsAbs(r.templateString(ctx, file.Target))
+	for _, link := range file.Symlinks {
+		link = r.resolveDir(ctx, workingDir, link)
+
+		if !file.AllowOutsideWorkdir {
+			for _, path := range []string{link, dest} {
+				if !withinDir(workingDir, path) {
+					return fmt.Errorf("unable to create symlink %s->%s: %s is outside of the working directory %s (set allowOutsideWorkdir to allow it)",
+						link, dest, path, workingDir)
+				}
+			}
+		}
+
+		target, err := symlinkTarget(link, dest, absolute)
+		if err != nil {
+			return fmt.Errorf("unable to create symlink %s->%s: %w", link, dest, err)
+		}
+
+		// Try to remove the filepath if it exists
+		_ = os.RemoveAll(link)
+		// Make sure the parent directory exists
+		_ = zarfUtils.CreateFilePath(link)
+		// Create the symlink
+		if err := os.Symlink(target, link); err != nil {
+			return fmt.Errorf("unable to create symlink %s->%s: %w", link, target, err)
+		}
+	}
+	return nil
+}
+
+// symlinkTarget returns the target of a symlink at link to dest, which is relative to the link's own directory
+// unless the file's target was given as an absolute path
+func symlinkTarget(link, dest string, absolute bool) (string, error) {
+	if absolute {
+		return dest, nil
+	}
+	return filepath.Rel(filepath.Dir(link), dest)
+}
+
+// withinDir returns true if path is dir or is inside of it
+func withinDir(dir, path string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
@@ -0,0 +1,91 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023-Present The UDS Authors
+
+package runner
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	zarfUtils "github.com/defenseunicorns/zarf/src/pkg/utils"
+	zarfTypes "github.com/defenseunicorns/zarf/src/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/defenseunicorns/uds-cli/src/types"
+)
+
+func Test_placeSymlinks(t *testing.T) {
+	r := newTestRunner(map[string]*zarfUtils.TextTemplate{
+		"${BIN}": {Value: "bin"},
+	})
+
+	tests := []struct {
+		name       string
+		target     func(workingDir string) string
+		symlinks   []string
+		allow      bool
+		wantTarget func(workingDir string) string
+		wantErr    string
+	}{
+		{
+			name:       "RelativeToLink",
+			target:     func(string) string { return "tools/tool" },
+			symlinks:   []string{"${BIN}/nested/tool"},
+			wantTarget: func(string) string { return "../../tools/tool" },
+		},
+		{
+			name:       "AbsoluteTarget",
+			target:     func(workingDir string) string { return filepath.Join(workingDir, "tools", "tool") },
+			symlinks:   []string{"tool"},
+			wantTarget: func(workingDir string) string { return filepath.Join(workingDir, "tools", "tool") },
+		},
+		{
+			name:     "LinkOutsideWorkdir",
+			target:   func(string) string { return "tools/tool" },
+			symlinks: []string{"../tool"},
+			wantErr:  "is outside of the working directory",
+		},
+		{
+			name:     "TargetOutsideWorkdir",
+			target:   func(string) string { return "../tool" },
+			symlinks: []string{"tool"},
+			wantErr:  "is outside of the working directory",
+		},
+		{
+			name:       "AllowOutsideWorkdir",
+			target:     func(string) string { return "tools/tool" },
+			symlinks:   []string{"../tool"},
+			allow:      true,
+			wantTarget: func(workingDir string) string { return filepath.Join(filepath.Base(workingDir), "tools", "tool") },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			workingDir := filepath.Join(t.TempDir(), "work")
+			target := tt.target(workingDir)
+			dest := target
+			if !filepath.IsAbs(dest) {
+				dest = filepath.Join(workingDir, target)
+			}
+
+			file := types.File{
+				ZarfFile:            zarfTypes.ZarfFile{Target: target, Symlinks: tt.symlinks},
+				AllowOutsideWorkdir: tt.allow,
+			}
+			err := r.placeSymlinks(context.Background(), file, workingDir, dest)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			link := r.resolveDir(context.Background(), workingDir, tt.symlinks[0])
+			got, err := os.Readlink(link)
+			require.NoError(t, err)
+			require.Equal(t, tt.wantTarget(workingDir), got)
+		})
+	}
+}
@@ -0,0 +1,89 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023-Present The UDS Authors
+
+// Package runner provides functions for running tasks in a run.yaml
+package runner
+
+import (
+	"context"
+
+	zarfUtils "github.com/defenseunicorns/zarf/src/pkg/utils"
+	"golang.org/x/exp/maps"
+)
+
+// The template map is read and written by actions running in parallel, so it is only accessed through these helpers
+// (and the whole-map copy of isolated tasks), which hold templateMapMu. Templates are never changed in place once they
+// are in the map; setting a variable replaces its template instead, so a template returned by getVariable can be read
+// without holding the lock.
+//
+// Variables can also be scoped to the actions run with a context (e.g. the item of a forEach iteration), which see them
+// on top of the template map while the other actions (and the template map itself) don't.
+
+// getVariable returns the template of the variable with the given key (e.g. ${NAME})
+func (r *Runner) getVariable(key string) (*zarfUtils.TextTemplate, bool) {
+	r.templateMapMu.RLock()
+	defer r.templateMapMu.RUnlock()
+	template, ok := r.TemplateMap[key]
+	return template, ok
+}
+
+// setVariable sets the template of the variable with the given key (e.g. ${NAME}), or unsets it when template is nil
+func (r *Runner) setVariable(key string, template *zarfUtils.TextTemplate) {
+	r.templateMapMu.Lock()
+	defer r.templateMapMu.Unlock()
+	if template == nil {
+		delete(r.TemplateMap, key)
+		return
+	}
+	r.TemplateMap[key] = template
+}
+
+// readTemplateMap calls fn with the template map, which fn must not modify or keep, held for reading
+func (r *Runner) readTemplateMap(fn func(templateMap map[string]*zarfUtils.TextTemplate)) {
+	r.templateMapMu.RLock()
+	defer r.templateMapMu.RUnlock()
+	fn(r.TemplateMap)
+}
+
+// scopedVariablesKey is the context key of the variables scoped to a context
+type scopedVariablesKey struct{}
+
+// withScopedVariables returns a context whose actions see variables on top of the template map (and of the variables
+// already scoped to ctx), without setting them in it
+func withScopedVariables(ctx context.Context, variables map[string]*zarfUtils.TextTemplate) context.Context {
+	scoped := maps.Clone(scopedVariables(ctx))
+	if scoped == nil {
+		scoped = make(map[string]*zarfUtils.TextTemplate, len(variables))
+	}
+	maps.Copy(scoped, variables)
+	return context.WithValue(ctx, scopedVariablesKey{}, scoped)
+}
+
+// scopedVariables returns the variables scoped to ctx, which must not be modified
+func scopedVariables(ctx context.Context) map[string]*zarfUtils.TextTemplate {
+	scoped, _ := ctx.Value(scopedVariablesKey{}).(map[string]*zarfUtils.TextTemplate)
+	return scoped
+}
+
+// lookupVariable returns the template of the variable with the given key as seen by the actions run with ctx
+func (r *Runner) lookupVariable(ctx context.Context, key string) (*zarfUtils.TextTemplate, bool) {
+	if template, ok := scopedVariables(ctx)[key]; ok {
+		return template, true
+	}
+	return r.getVariable(key)
+}
+
+// readScopedTemplateMap calls fn with the template map as seen by the actions run with ctx (with its scoped variables
+// on top), which fn must not modify or keep
+func (r *Runner) readScopedTemplateMap(ctx context.Context, fn func(templateMap map[string]*zarfUtils.TextTemplate)) {
+	scoped := scopedVariables(ctx)
+	r.readTemplateMap(func(templateMap map[string]*zarfUtils.TextTemplate) {
+		if len(scoped) == 0 {
+			fn(templateMap)
+			return
+		}
+		merged := maps.Clone(templateMap)
+		maps.Copy(merged, scoped)
+		fn(merged)
+	})
+}
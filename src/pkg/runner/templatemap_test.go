@@ -0,0 +1,40 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023-Present The UDS Authors
+
+package runner
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	zarfUtils "github.com/defenseunicorns/zarf/src/pkg/utils"
+	"github.com/stretchr/testify/require"
+)
+
+// Test_templateMapConcurrentAccess is most useful with go test -race
+func Test_templateMapConcurrentAccess(t *testing.T) {
+	r := newTestRunner(map[string]*zarfUtils.TextTemplate{"${SECRET}": {Value: "hunter2", Sensitive: true}})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			key := fmt.Sprintf("${VAR_%d}", i)
+			for j := 0; j < 50; j++ {
+				r.setVariable(key, &zarfUtils.TextTemplate{Value: fmt.Sprint(j)})
+				template, ok := r.getVariable(key)
+				require.True(t, ok)
+				require.Equal(t, fmt.Sprint(j), template.Value)
+				require.Equal(t, "****-"+fmt.Sprint(j), r.mask(r.templateString(context.Background(), "${SECRET}-"+key)))
+				r.restoreTemplateMap(r.copyTemplateMap())
+			}
+			r.setVariable(key, nil)
+		}(i)
+	}
+	wg.Wait()
+
+	require.Len(t, r.TemplateMap, 1)
+}
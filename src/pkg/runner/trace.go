@@ -0,0 +1,78 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023-Present The UDS Authors
+
+// Package runner provides functions for running tasks in a run.yaml
+package runner
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/defenseunicorns/uds-cli/src/config"
+)
+
+// tracerName is the instrumentation scope of the spans of runs, tasks and actions
+const tracerName = "github.com/defenseunicorns/uds-cli/src/pkg/runner"
+
+// StartTracing exports a span for each run, task and action to the OTLP gRPC endpoint set by the standard
+// OTEL_EXPORTER_OTLP_ENDPOINT env var (localhost:4317 by default). Commands run by actions get the context of their
+// action's span in the TRACEPARENT env var. The returned func flushes the spans that haven't been exported yet
+func StartTracing(ctx context.Context) (func(context.Context) error, error) {
+	exporter, err := otlptracegrpc.New(ctx)
+	if err != nil {
+		return nil, err
+	}
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.ServiceNameKey.String("uds-cli"),
+		semconv.ServiceVersionKey.String(config.CLIVersion),
+	))
+	if err != nil {
+		return nil, err
+	}
+	provider := sdktrace.NewTracerProvider(sdktrace.WithBatcher(exporter), sdktrace.WithResource(res))
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	return provider.Shutdown, nil
+}
+
+// startSpan starts a span of the run, it is a no-op unless StartTracing was called
+func startSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// endSpan records the outcome of a run, task or action on its span (masking sensitive variables) and ends it
+func (r *Runner) endSpan(span trace.Span, err error) {
+	switch {
+	case err == nil:
+		span.SetStatus(codes.Ok, "")
+	case errors.Is(err, errActionSkipped):
+		span.SetAttributes(attribute.Bool("uds.skipped", true))
+	default:
+		msg := r.mask(err.Error())
+		span.RecordError(errors.New(msg))
+		span.SetStatus(codes.Error, msg)
+	}
+	span.End()
+}
+
+// traceEnv returns the env vars that propagate the trace context of ctx to a command, if it has one
+func traceEnv(ctx context.Context) []string {
+	carrier := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+	var env []string
+	for key, value := range carrier {
+		env = append(env, strings.ToUpper(key)+"="+value)
+	}
+	return env
+}
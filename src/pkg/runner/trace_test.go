@@ -0,0 +1,72 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023-Present The UDS Authors
+
+package runner
+
+import (
+	"context"
+	"runtime"
+	"strings"
+	"testing"
+
+	zarfUtils "github.com/defenseunicorns/zarf/src/pkg/utils"
+	zarfTypes "github.com/defenseunicorns/zarf/src/types"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/defenseunicorns/uds-cli/src/types"
+)
+
+func Test_taskSpans(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the command uses sh syntax")
+	}
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	prevProvider, prevPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	defer func() {
+		otel.SetTracerProvider(prevProvider)
+		otel.SetTextMapPropagator(prevPropagator)
+	}()
+
+	r := newTestRunner(map[string]*zarfUtils.TextTemplate{"${TOKEN}": {Value: "hunter2", Sensitive: true}})
+	task := types.Task{Name: "build", Actions: []types.Action{
+		{
+			ZarfComponentAction: &zarfTypes.ZarfComponentAction{Cmd: "echo $TRACEPARENT"},
+			SetVariables:        []types.SetVariable{{ZarfComponentActionSetVariable: zarfTypes.ZarfComponentActionSetVariable{Name: "PARENT"}}},
+		},
+		{ZarfComponentAction: &zarfTypes.ZarfComponentAction{Cmd: "echo ${TOKEN} && exit 3"}},
+	}}
+	require.Error(t, r.executeTask(context.Background(), task, false))
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 3)
+	first, failed, taskSpan := spans[0], spans[1], spans[2]
+	require.Equal(t, "task build", taskSpan.Name())
+	require.Equal(t, codes.Error, taskSpan.Status().Code)
+	for _, span := range []sdktrace.ReadOnlySpan{first, failed} {
+		require.Equal(t, taskSpan.SpanContext().SpanID(), span.Parent().SpanID())
+		require.Contains(t, span.Attributes(), attribute.String("uds.task.name", "build"))
+	}
+
+	require.Equal(t, "action echo $TRACEPARENT", first.Name())
+	require.Equal(t, codes.Ok, first.Status().Code)
+	require.Contains(t, first.Attributes(), attribute.Int("uds.action.retries", 0))
+	// the command got the context of its action's span
+	parent, ok := r.getVariable("${PARENT}")
+	require.True(t, ok)
+	require.Contains(t, strings.TrimSpace(parent.Value), first.SpanContext().SpanID().String())
+
+	// sensitive variables are masked in the span of a failed action
+	require.Equal(t, codes.Error, failed.Status().Code)
+	require.Contains(t, failed.Attributes(), attribute.Int("uds.action.exit_code", 3))
+	require.NotContains(t, failed.Status().Description, "hunter2")
+	require.NotContains(t, failed.Name(), "hunter2")
+}
@@ -0,0 +1,212 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023-Present The UDS Authors
+
+// Package runner provides functions for running tasks in a run.yaml
+package runner
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/defenseunicorns/zarf/src/pkg/message"
+	zarfUtils "github.com/defenseunicorns/zarf/src/pkg/utils"
+	zarfTypes "github.com/defenseunicorns/zarf/src/types"
+	"golang.org/x/term"
+
+	"github.com/defenseunicorns/uds-cli/src/types"
+)
+
+// isInteractive reports whether the runner can prompt the user for variable values
+var isInteractive = func() bool {
+	return term.IsTerminal(int(os.Stdin.Fd()))
+}
+
+// promptVariables asks the user for the values of prompt and (unset) required variables, failing with a list
+// of the missing required variables when the runner can't prompt for them
+func (r *Runner) promptVariables(variables []types.Variable) error {
+	if r.checking {
+		return nil
+	}
+
+	interactive := isInteractive()
+	missing := []string{}
+
+	for _, variable := range variables {
+		if _, ok := r.setVariables[variable.Name]; ok {
+			continue
+		}
+
+		key := fmt.Sprintf("${%s}", variable.Name)
+		template, ok := r.getVariable(key)
+		unset := !ok || template.Value == ""
+
+		if !variable.Prompt && !(variable.Required && unset) {
+			continue
+		}
+
+		if !interactive {
+			if variable.Required && unset {
+				missing = append(missing, variable.Name)
+			}
+			continue
+		}
+
+		value, err := promptVariable(variable, template)
+		if err != nil {
+			return fmt.Errorf("unable to get a value for variable %s: %w", variable.Name, err)
+		}
+
+		r.setVariable(key, &zarfUtils.TextTemplate{
+			Sensitive:  variable.Sensitive,
+			AutoIndent: variable.AutoIndent,
+			Type:       variable.Type,
+			Value:      value,
+		})
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("missing values for required variables %s, set them with --set (e.g. --set %s=value)",
+			strings.Join(missing, ", "), missing[0])
+	}
+	return nil
+}
+
+// promptVariable asks the user for the value of a variable, masking the input of sensitive variables
+func promptVariable(variable types.Variable, current *zarfUtils.TextTemplate) (value string, err error) {
+	if variable.Description != "" {
+		message.Question(variable.Description)
+	}
+
+	msg := fmt.Sprintf("Please provide a value for \"%s\"", variable.Name)
+	var opts []survey.AskOpt
+	if variable.Required {
+		opts = append(opts, survey.WithValidator(survey.Required))
+	}
+	opts = append(opts, survey.WithValidator(func(answer interface{}) error {
+		if value, ok := answer.(string); ok && value != "" {
+			return validateVariableValue(variable, value)
+		}
+		return nil
+	}))
+
+	var prompt survey.Prompt
+	if variable.Sensitive {
+		prompt = &survey.Password{Message: msg}
+	} else {
+		input := &survey.Input{Message: msg}
+		if current != nil {
+			input.Default = current.Value
+		}
+		prompt = input
+	}
+
+	if err = survey.AskOne(prompt, &value, opts...); err != nil {
+		return "", err
+	}
+	return value, nil
+}
+
+// validateVariables checks the declarations of variables and that the values they're set to are valid for their
+// type and enum, failing with every invalid variable
+func (r *Runner) validateVariables(variables []types.Variable) error {
+	return errors.Join(r.variableErrors(variables)...)
+}
+
+// variableErrors returns the errors of the variables that are declared wrong or set to an invalid value, unset
+// variables are left to required
+func (r *Runner) variableErrors(variables []types.Variable) []error {
+	var errs []error
+	for _, variable := range variables {
+		if err := validateVariableDeclaration(variable); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		template, ok := r.getVariable(fmt.Sprintf("${%s}", variable.Name))
+		if !ok || template.Value == "" {
+			continue
+		}
+		if err := validateVariableValue(variable, template.Value); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+// validateVariableDeclaration checks that a variable has a known type, and values to choose from when it's an enum
+func validateVariableDeclaration(variable types.Variable) error {
+	switch variable.Type {
+	case "", zarfTypes.RawVariableType, zarfTypes.FileVariableType, types.VariableTypeInt, types.VariableTypeBool:
+	case types.VariableTypeEnum:
+		if len(variable.Enum) == 0 {
+			return fmt.Errorf("variable %s is of type enum but has no enum values", variable.Name)
+		}
+	default:
+		return fmt.Errorf("variable %s has unknown type %q, must be one of: raw, file, int, bool, enum", variable.Name, variable.Type)
+	}
+	return nil
+}
+
+// validateVariableValue checks that a value parses as the variable's type and is one of its enum values (if it has any)
+func validateVariableValue(variable types.Variable, value string) error {
+	shown := value
+	if variable.Sensitive {
+		shown = maskedValue
+	}
+	switch variable.Type {
+	case types.VariableTypeInt:
+		if _, err := strconv.ParseInt(value, 10, 64); err != nil {
+			return fmt.Errorf("value %q of variable %s is not an int", shown, variable.Name)
+		}
+	case types.VariableTypeBool:
+		if _, err := strconv.ParseBool(value); err != nil {
+			return fmt.Errorf("value %q of variable %s is not a bool (true or false)", shown, variable.Name)
+		}
+	}
+	if len(variable.Enum) > 0 && !slices.Contains(variable.Enum, value) {
+		return fmt.Errorf("value %q of variable %s is not one of: %s", shown, variable.Name, strings.Join(variable.Enum, ", "))
+	}
+	return nil
+}
+
+// validateVariablePattern checks that the value set for a variable matches its pattern (if it has one)
+func validateVariablePattern(variable types.SetVariable, value string) error {
+	if variable.Pattern == "" {
+		return nil
+	}
+
+	pattern, err := regexp.Compile(variable.Pattern)
+	if err != nil {
+		return fmt.Errorf("invalid pattern %q for variable %s: %w", variable.Pattern, variable.Name, err)
+	}
+	if !pattern.MatchString(value) {
+		if variable.Sensitive {
+			value = maskedValue
+		}
+		return fmt.Errorf("value %q of variable %s does not match the pattern %q", value, variable.Name, variable.Pattern)
+	}
+	return nil
+}
+
+// unresolvedVariables returns the ${VAR} references left in a templated command that aren't set in the environment
+// of the command either, lowercase names are left alone as they're usually shell variables
+func unresolvedVariables(cmd string, env []string) []string {
+	set := map[string]bool{}
+	for _, e := range append(os.Environ(), env...) {
+		name, _, _ := strings.Cut(e, "=")
+		set[name] = true
+	}
+
+	var names []string
+	for _, match := range variableReference.FindAllStringSubmatch(cmd, -1) {
+		if name := match[1]; !set[name] && !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
@@ -0,0 +1,212 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023-Present The UDS Authors
+
+package runner
+
+import (
+	"runtime"
+	"testing"
+
+	zarfTypes "github.com/defenseunicorns/zarf/src/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/defenseunicorns/uds-cli/src/types"
+)
+
+func Test_promptVariables(t *testing.T) {
+	isInteractive = func() bool { return false }
+
+	variable := func(name, def string, required bool) types.Variable {
+		return types.Variable{ZarfPackageVariable: zarfTypes.ZarfPackageVariable{Name: name, Default: def}, Required: required}
+	}
+
+	tests := []struct {
+		name         string
+		variables    []types.Variable
+		setVariables map[string]string
+		wantErr      string
+	}{
+		{
+			name:      "OptionalUnset",
+			variables: []types.Variable{variable("FOO", "", false)},
+		},
+		{
+			name:      "RequiredWithDefault",
+			variables: []types.Variable{variable("FOO", "foo", true)},
+		},
+		{
+			name:         "RequiredFromSet",
+			variables:    []types.Variable{variable("FOO", "", true)},
+			setVariables: map[string]string{"FOO": "foo"},
+		},
+		{
+			name:      "RequiredMissing",
+			variables: []types.Variable{variable("FOO", "", true), variable("BAR", "bar", true), variable("BAZ", "", true)},
+			wantErr:   "missing values for required variables FOO, BAZ",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRunner(nil)
+			r.setVariables = tt.setVariables
+			r.populateTemplateMap(tt.variables, tt.setVariables)
+			err := r.promptVariables(tt.variables)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func Test_validateVariablePattern(t *testing.T) {
+	variable := func(pattern string, sensitive bool) types.SetVariable {
+		return types.SetVariable{ZarfComponentActionSetVariable: zarfTypes.ZarfComponentActionSetVariable{
+			Name: "FOO", Pattern: pattern, Sensitive: sensitive,
+		}}
+	}
+
+	tests := []struct {
+		name     string
+		variable types.SetVariable
+		value    string
+		wantErr  string
+	}{
+		{name: "NoPattern", variable: variable("", false), value: "anything"},
+		{name: "Match", variable: variable("^v[0-9]+$", false), value: "v1"},
+		{name: "NoMatch", variable: variable("^v[0-9]+$", false), value: "latest", wantErr: `value "latest" of variable FOO does not match the pattern "^v[0-9]+$"`},
+		{name: "NoMatchSensitive", variable: variable("^v[0-9]+$", true), value: "hunter2", wantErr: `value "****" of variable FOO`},
+		{name: "InvalidPattern", variable: variable("(", false), value: "v1", wantErr: `invalid pattern "(" for variable FOO`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateVariablePattern(tt.variable, tt.value)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func Test_validateVariables(t *testing.T) {
+	variable := func(varType zarfTypes.VariableType, def string, enum ...string) types.Variable {
+		return types.Variable{ZarfPackageVariable: zarfTypes.ZarfPackageVariable{Name: "FOO", Default: def}, Type: varType, Enum: enum}
+	}
+
+	tests := []struct {
+		name         string
+		variable     types.Variable
+		setVariables map[string]string
+		wantErr      string
+	}{
+		{name: "Untyped", variable: variable("", "anything")},
+		{name: "Int", variable: variable(types.VariableTypeInt, "3")},
+		{name: "NotAnInt", variable: variable(types.VariableTypeInt, "three"), wantErr: `value "three" of variable FOO is not an int`},
+		{name: "NotAnIntFromSet", variable: variable(types.VariableTypeInt, "3"), setVariables: map[string]string{"FOO": "3.5"}, wantErr: `value "3.5" of variable FOO is not an int`},
+		{name: "Bool", variable: variable(types.VariableTypeBool, "true")},
+		{name: "NotABool", variable: variable(types.VariableTypeBool, "yes"), wantErr: `value "yes" of variable FOO is not a bool`},
+		{name: "Enum", variable: variable(types.VariableTypeEnum, "dev", "dev", "prod")},
+		{name: "NotInEnum", variable: variable(types.VariableTypeEnum, "staging", "dev", "prod"), wantErr: `value "staging" of variable FOO is not one of: dev, prod`},
+		{name: "EnumWithoutValues", variable: variable(types.VariableTypeEnum, "dev"), wantErr: "variable FOO is of type enum but has no enum values"},
+		{name: "UnsetInt", variable: variable(types.VariableTypeInt, "")},
+		{name: "UnknownType", variable: variable("float", "1.5"), wantErr: `variable FOO has unknown type "float"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRunner(nil)
+			r.populateTemplateMap([]types.Variable{tt.variable}, tt.setVariables)
+			err := r.validateVariables([]types.Variable{tt.variable})
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func Test_populateTemplateMap(t *testing.T) {
+	tests := []struct {
+		name         string
+		variables    []types.Variable
+		setVariables map[string]string
+		want         map[string]string
+	}{
+		{
+			name: "Builtins",
+			want: map[string]string{"${OS}": runtime.GOOS, "${UDS_ARCH}": runtime.GOARCH},
+		},
+		{
+			name:      "DeclaredVariableWins",
+			variables: []types.Variable{{ZarfPackageVariable: zarfTypes.ZarfPackageVariable{Name: "OS", Default: "plan9"}}},
+			want:      map[string]string{"${OS}": "plan9", "${UDS_ARCH}": runtime.GOARCH},
+		},
+		{
+			name:         "SetVariableWins",
+			setVariables: map[string]string{"UDS_ARCH": "riscv64"},
+			want:         map[string]string{"${OS}": runtime.GOOS, "${UDS_ARCH}": "riscv64"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRunner(nil)
+			r.populateTemplateMap(tt.variables, tt.setVariables)
+			for name, value := range tt.want {
+				require.Equal(t, value, r.TemplateMap[name].Value)
+			}
+			require.NotEmpty(t, r.TemplateMap["${TIMESTAMP}"].Value)
+			require.NotEmpty(t, r.TemplateMap["${CWD}"].Value)
+		})
+	}
+}
+
+func Test_populateTemplateMapSensitive(t *testing.T) {
+	r := newTestRunner(nil)
+	r.populateTemplateMap(
+		[]types.Variable{{ZarfPackageVariable: zarfTypes.ZarfPackageVariable{Name: "TOKEN", Sensitive: true}}},
+		map[string]string{"TOKEN": "s3cr3t"},
+	)
+
+	// a sensitive variable given with --set is still masked
+	require.Equal(t, "s3cr3t", r.TemplateMap["${TOKEN}"].Value)
+	require.True(t, r.TemplateMap["${TOKEN}"].Sensitive)
+	require.Equal(t, "token: ****", r.mask("token: s3cr3t"))
+}
+
+func Test_unresolvedVariables(t *testing.T) {
+	t.Setenv("FROM_CLI_ENV", "set")
+	tests := []struct {
+		name string
+		cmd  string
+		env  []string
+		want []string
+	}{
+		{
+			name: "Resolved",
+			cmd:  "echo hello",
+		},
+		{
+			name: "Unresolved",
+			cmd:  "echo ${FOO} ${BAR} ${FOO}",
+			want: []string{"FOO", "BAR"},
+		},
+		{
+			name: "SetInEnv",
+			cmd:  "echo ${TOKEN} ${UDS_ARCH} ${FROM_CLI_ENV} ${MISSING}",
+			env:  []string{"TOKEN=secret", "UDS_ARCH=amd64"},
+			want: []string{"MISSING"},
+		},
+		{
+			name: "ShellVariables",
+			cmd:  "for f in *; do echo ${f} ${f%.txt}; done",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, unresolvedVariables(tt.cmd, tt.env))
+		})
+	}
+}
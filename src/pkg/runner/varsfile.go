@@ -0,0 +1,56 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023-Present The UDS Authors
+
+// Package runner provides functions for running tasks in a run.yaml
+package runner
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	zarfUtils "github.com/defenseunicorns/zarf/src/pkg/utils"
+)
+
+// envVariableName matches the names environment variables can be imported as
+var envVariableName = regexp.MustCompile(`^[A-Za-z0-9_]+$`)
+
+// EnvVariables returns the variables of the environment variables whose name starts with prefix, named without the
+// prefix (e.g. UDS_FOO=bar with the prefix UDS_ sets FOO to bar); an empty prefix imports nothing
+func EnvVariables(prefix string) map[string]string {
+	variables := map[string]string{}
+	if prefix == "" {
+		return variables
+	}
+	for _, env := range os.Environ() {
+		name, value, _ := strings.Cut(env, "=")
+		if name, ok := strings.CutPrefix(name, prefix); ok && envVariableName.MatchString(name) {
+			variables[name] = value
+		}
+	}
+	return variables
+}
+
+// ReadVarsFiles reads the flat KEY: value maps of YAML variables files, merging them in order so that later files
+// override earlier ones
+func ReadVarsFiles(paths []string) (map[string]string, error) {
+	variables := map[string]string{}
+	for _, path := range paths {
+		var values map[string]any
+		if err := zarfUtils.ReadYaml(path, &values); err != nil {
+			return nil, fmt.Errorf("unable to read variables file %s: %w", path, err)
+		}
+		for name, value := range values {
+			switch value.(type) {
+			case map[string]any, map[any]any, []any:
+				return nil, fmt.Errorf("variable %s in %s has a nested value, variables files must be a flat map of KEY: value", name, path)
+			case nil:
+				variables[name] = ""
+			default:
+				variables[name] = fmt.Sprint(value)
+			}
+		}
+	}
+	return variables, nil
+}
@@ -0,0 +1,48 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023-Present The UDS Authors
+
+package runner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ReadVarsFiles(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+		return path
+	}
+	dev := write("dev.yaml", "ENV: dev\nREPLICAS: 1\nDEBUG: true\nEMPTY:\n")
+	prod := write("prod.yaml", "ENV: prod\nREPLICAS: 3\n")
+	nested := write("nested.yaml", "DB:\n  HOST: localhost\n")
+	list := write("list.yaml", "HOSTS:\n  - a\n  - b\n")
+
+	variables, err := ReadVarsFiles([]string{dev, prod})
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"ENV": "prod", "REPLICAS": "3", "DEBUG": "true", "EMPTY": ""}, variables)
+
+	_, err = ReadVarsFiles([]string{dev, nested})
+	require.ErrorContains(t, err, "variable DB in "+nested+" has a nested value")
+
+	_, err = ReadVarsFiles([]string{list})
+	require.ErrorContains(t, err, "variable HOSTS in "+list+" has a nested value")
+
+	_, err = ReadVarsFiles([]string{filepath.Join(dir, "missing.yaml")})
+	require.ErrorContains(t, err, "unable to read variables file")
+}
+
+func Test_EnvVariables(t *testing.T) {
+	t.Setenv("UDSTEST_FOO", "bar")
+	t.Setenv("UDSTEST_EMPTY", "")
+	t.Setenv("UDSTEST_", "no name")
+	t.Setenv("OTHER_UDSTEST_BAZ", "not prefixed")
+
+	require.Equal(t, map[string]string{"FOO": "bar", "EMPTY": ""}, EnvVariables("UDSTEST_"))
+	require.Empty(t, EnvVariables(""))
+}
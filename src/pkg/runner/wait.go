@@ -0,0 +1,126 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023-Present The UDS Authors
+
+// Package runner provides functions for running tasks in a run.yaml
+package runner
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	zarfUtils "github.com/defenseunicorns/zarf/src/pkg/utils"
+	zarfTypes "github.com/defenseunicorns/zarf/src/types"
+
+	"github.com/defenseunicorns/uds-cli/src/types"
+)
+
+// waitPollInterval is how often native waits check their condition
+const waitPollInterval = time.Second
+
+// templateWait templates the string fields of a cluster or network wait, copying them so the task's wait isn't
+// modified, which lets a wait target a resource whose name or address was set by an earlier action
+func (r *Runner) templateWait(ctx context.Context, wait zarfTypes.ZarfComponentActionWait) zarfTypes.ZarfComponentActionWait {
+	if wait.Cluster != nil {
+		cluster := *wait.Cluster
+		cluster.Kind = r.templateString(ctx, cluster.Kind)
+		cluster.Identifier = r.templateString(ctx, cluster.Identifier)
+		cluster.Namespace = r.templateString(ctx, cluster.Namespace)
+		cluster.Condition = r.templateString(ctx, cluster.Condition)
+		wait.Cluster = &cluster
+	}
+	if wait.Network != nil {
+		network := *wait.Network
+		network.Protocol = r.templateString(ctx, network.Protocol)
+		network.Address = r.templateString(ctx, network.Address)
+		wait.Network = &network
+	}
+	return wait
+}
+
+// performWait performs a file or command wait natively instead of shelling out to uds tools wait-for
+func (r *Runner) performWait(ctx context.Context, action types.Action, buffered bool) error {
+	var (
+		name  string
+		check func(ctx context.Context) bool
+	)
+
+	zarfAction := *action.ZarfComponentAction
+	zarfAction.Env = r.templateEnv(ctx, zarfAction.Env)
+
+	var cfg zarfTypes.ZarfComponentActionDefaults
+	r.readScopedTemplateMap(ctx, func(templateMap map[string]*zarfUtils.TextTemplate) {
+		cfg = actionGetCfg(zarfTypes.ZarfComponentActionDefaults{}, zarfAction, templateMap)
+	})
+	cfg.Shell = r.actionShell(action.Shell)
+	cfg.Mute = true
+
+	switch {
+	case action.Wait.File != nil:
+		condition := action.Wait.File.Condition
+		if condition == "" {
+			condition = types.WaitFileExists
+		}
+		if condition != types.WaitFileExists && condition != types.WaitFileDeleted {
+			return fmt.Errorf("invalid file wait condition %q, must be one of %s or %s", condition, types.WaitFileExists, types.WaitFileDeleted)
+		}
+
+		// describe the wait with the path as given, but check it relative to the action's dir
+		givenPath := r.templateString(ctx, action.Wait.File.Path)
+		path := givenPath
+		if !filepath.IsAbs(path) && cfg.Dir != "" {
+			path = filepath.Join(cfg.Dir, path)
+		}
+		name = fmt.Sprintf("%s to be %s", givenPath, map[string]string{types.WaitFileExists: "created", types.WaitFileDeleted: "deleted"}[condition])
+		check = func(_ context.Context) bool {
+			_, err := os.Stat(path)
+			return (err == nil) == (condition == types.WaitFileExists)
+		}
+	case action.Wait.Command != nil:
+		if _, _, err := shellCommand(cfg.Shell); err != nil {
+			return err
+		}
+		cmd := r.templateString(ctx, action.Wait.Command.Cmd)
+		name = fmt.Sprintf("%s to succeed", cmd)
+		check = func(ctx context.Context) bool {
+			_, err := r.actionRun(ctx, cfg, cmd, cfg.Shell, nil, nil, outputLimit{maxBytes: defaultMaxOutputBytes}, false)
+			return err == nil
+		}
+	}
+
+	if action.Description != "" {
+		name = action.Description
+	}
+	name = r.mask(name)
+
+	progress := r.newActionProgress(buffered, "Waiting for \"%s\" (timeout: %ds)", name, *action.MaxTotalSeconds)
+
+	waitCtx, cancel := context.WithTimeout(ctx, time.Duration(*action.MaxTotalSeconds)*time.Second)
+	defer cancel()
+
+	ticker := time.NewTicker(waitPollInterval)
+	defer ticker.Stop()
+
+	attempts := 0
+
+	for {
+		attempts++
+		if check(waitCtx) {
+			progress.Successf("Wait for \"%s\" succeeded", name)
+			return nil
+		}
+
+		select {
+		case <-waitCtx.Done():
+			var err error = &ActionError{Action: name, Attempts: attempts, TimedOut: true, MaxTotalSeconds: *action.MaxTotalSeconds}
+			if ctx.Err() != nil {
+				err = fmt.Errorf("command \"%s\" was canceled: %w", name, context.Cause(ctx))
+			}
+			progress.Failf(err, "Failed \"%s\"", name)
+			return err
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,140 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023-Present The UDS Authors
+
+// Package runner provides functions for running tasks in a run.yaml
+package runner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/defenseunicorns/zarf/src/pkg/message"
+	zarfUtils "github.com/defenseunicorns/zarf/src/pkg/utils"
+	"github.com/fsnotify/fsnotify"
+
+	"github.com/defenseunicorns/uds-cli/src/config"
+	"github.com/defenseunicorns/uds-cli/src/types"
+)
+
+// watchDebounce is how long the watched paths must stay unchanged before the task is run again
+const watchDebounce = 500 * time.Millisecond
+
+// Watch runs a task, then runs it again whenever a file in paths changes until the CLI is interrupted. The tasks file is
+// read again and every run starts from a fresh Runner, so variables set by one run don't leak into the next. A failed
+// run is reported rather than returned
+func Watch(taskName string, setVariables map[string]string, paths []string) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("unable to watch for changes: %w", err)
+	}
+	defer watcher.Close()
+	for _, path := range paths {
+		if err := watchPath(watcher, path); err != nil {
+			return err
+		}
+	}
+
+	ctx, stop := interruptContext()
+	defer stop()
+
+	for {
+		if err := runWatched(taskName, setVariables); err != nil {
+			if errors.Is(err, ErrNoDefaultTask) {
+				return err
+			}
+			message.Warnf("Failed to run task: %s", err)
+		}
+		// an interrupt during the run stops watching too
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		message.Infof("Watching %s for changes, press Ctrl-C to stop", strings.Join(paths, ", "))
+		changed, err := waitForChange(ctx, watcher, watchDebounce)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		message.Infof("%s changed, running the task again", changed)
+	}
+}
+
+// runWatched reads the tasks file and runs the task once
+func runWatched(taskName string, setVariables map[string]string) error {
+	var tasksFile types.TasksFile
+	if err := zarfUtils.ReadYaml(config.TaskFileLocation, &tasksFile); err != nil {
+		return fmt.Errorf("cannot unmarshal %s: %w", config.TaskFileLocation, err)
+	}
+	return Run(tasksFile, taskName, setVariables)
+}
+
+// watchPath watches a file, or a directory and all of its subdirectories (except .git directories)
+func watchPath(watcher *fsnotify.Watcher, path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("unable to watch %s: %w", path, err)
+	}
+	if !info.IsDir() {
+		return watcher.Add(path)
+	}
+	return filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if d.Name() == ".git" {
+			return filepath.SkipDir
+		}
+		if err := watcher.Add(p); err != nil {
+			return fmt.Errorf("unable to watch %s: %w", p, err)
+		}
+		return nil
+	})
+}
+
+// waitForChange waits until a watched file changes and no other change follows within debounce, returning the last
+// changed file. Directories created in a watched directory are watched as well
+func waitForChange(ctx context.Context, watcher *fsnotify.Watcher, debounce time.Duration) (string, error) {
+	var changed string
+	var settled <-chan time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return "", context.Cause(ctx)
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return "", errors.New("stopped watching for changes")
+			}
+			// permission and timestamp changes alone don't change what a task would do
+			if event.Op == fsnotify.Chmod {
+				continue
+			}
+			if event.Has(fsnotify.Create) {
+				if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+					if err := watchPath(watcher, event.Name); err != nil {
+						message.Debugf("Unable to watch new directory %s: %s", event.Name, err)
+					}
+				}
+			}
+			changed = event.Name
+			settled = time.After(debounce)
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return "", errors.New("stopped watching for changes")
+			}
+			return "", fmt.Errorf("unable to watch for changes: %w", err)
+		case <-settled:
+			return changed, nil
+		}
+	}
+}
@@ -0,0 +1,53 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023-Present The UDS Authors
+
+package runner
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_waitForChange(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "sub"), 0755))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, ".git"), 0755))
+
+	watcher, err := fsnotify.NewWatcher()
+	require.NoError(t, err)
+	defer watcher.Close()
+	require.NoError(t, watchPath(watcher, dir))
+	require.ElementsMatch(t, []string{dir, filepath.Join(dir, "sub")}, watcher.WatchList())
+
+	// several changes in a row are reported once, as the last one
+	go func() {
+		for _, name := range []string{"a.txt", "b.txt", filepath.Join("sub", "c.txt")} {
+			_ = os.WriteFile(filepath.Join(dir, name), []byte("changed"), 0644)
+			time.Sleep(10 * time.Millisecond)
+		}
+	}()
+	changed, err := waitForChange(context.Background(), watcher, 200*time.Millisecond)
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(dir, "sub", "c.txt"), changed)
+
+	// new directories are watched as well
+	go func() {
+		_ = os.Mkdir(filepath.Join(dir, "new"), 0755)
+	}()
+	changed, err = waitForChange(context.Background(), watcher, 100*time.Millisecond)
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(dir, "new"), changed)
+	require.Contains(t, watcher.WatchList(), filepath.Join(dir, "new"))
+
+	// an interrupt stops waiting
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = waitForChange(ctx, watcher, 100*time.Millisecond)
+	require.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,72 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023-Present The UDS Authors
+
+// Package runner provides functions for running tasks in a run.yaml
+package runner
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/defenseunicorns/uds-cli/src/config"
+	"github.com/defenseunicorns/uds-cli/src/types"
+)
+
+// resolveDir templates a directory and resolves it against base when it is relative
+func (r *Runner) resolveDir(ctx context.Context, base, dir string) string {
+	dir = r.templateString(ctx, dir)
+	if filepath.IsAbs(dir) {
+		return dir
+	}
+	return filepath.Join(base, dir)
+}
+
+// baseDir returns the base directory of the run, --dir when set and otherwise the directory of the tasks file
+func baseDir() string {
+	if config.RunDir != "" {
+		return config.RunDir
+	}
+	return filepath.Dir(config.TaskFileLocation)
+}
+
+// taskDir returns the working directory of a task, resolved against the base directory of the run (which is also the
+// working directory of tasks without a dir)
+func (r *Runner) taskDir(ctx context.Context, task types.Task) string {
+	if task.Dir == "" {
+		return baseDir()
+	}
+	return r.resolveDir(ctx, baseDir(), task.Dir)
+}
+
+// withWorkingDir returns a copy of actions whose working directories default to the task's dir, with relative
+// dirs resolved against the task's dir (or the base directory of the run when the task has no dir)
+func (r *Runner) withWorkingDir(ctx context.Context, actions []types.Action, taskDir string) []types.Action {
+	base := taskDir
+	if base == "" {
+		base = baseDir()
+	}
+
+	resolved := make([]types.Action, len(actions))
+	for i, action := range actions {
+		resolved[i] = action
+		if action.ZarfComponentAction == nil {
+			continue
+		}
+
+		var dir string
+		switch {
+		case action.Dir != nil && *action.Dir != "":
+			dir = r.resolveDir(ctx, base, *action.Dir)
+		case taskDir != "":
+			dir = taskDir
+		default:
+			continue
+		}
+
+		// copy the Zarf action so the task's actions aren't modified
+		zarfAction := *action.ZarfComponentAction
+		zarfAction.Dir = &dir
+		resolved[i].ZarfComponentAction = &zarfAction
+	}
+	return resolved
+}
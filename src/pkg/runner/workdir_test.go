@@ -0,0 +1,52 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023-Present The UDS Authors
+
+package runner
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	zarfTypes "github.com/defenseunicorns/zarf/src/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/defenseunicorns/uds-cli/src/config"
+	"github.com/defenseunicorns/uds-cli/src/types"
+)
+
+func Test_taskDir(t *testing.T) {
+	defer func(location, dir string) { config.TaskFileLocation, config.RunDir = location, dir }(config.TaskFileLocation, config.RunDir)
+	config.TaskFileLocation = filepath.Join("repo", "tasks.yaml")
+
+	r := newTestRunner(nil)
+	dir := func(d string) types.Action {
+		return types.Action{ZarfComponentAction: &zarfTypes.ZarfComponentAction{Dir: &d}}
+	}
+
+	tests := []struct {
+		name       string
+		runDir     string
+		task       types.Task
+		wantTask   string
+		wantAction string
+	}{
+		{name: "TasksFileDir", task: types.Task{}, wantTask: "repo", wantAction: filepath.Join("repo", "src")},
+		{name: "TasksFileDirAndDot", task: types.Task{Dir: "."}, wantTask: "repo", wantAction: filepath.Join("repo", "src")},
+		{name: "TaskDir", task: types.Task{Dir: "docs"}, wantTask: filepath.Join("repo", "docs"), wantAction: filepath.Join("repo", "docs", "src")},
+		{name: "RunDir", runDir: "/work", task: types.Task{}, wantTask: "/work", wantAction: filepath.Join("/work", "src")},
+		{name: "RunDirAndTaskDir", runDir: "/work", task: types.Task{Dir: "docs"}, wantTask: filepath.Join("/work", "docs"), wantAction: filepath.Join("/work", "docs", "src")},
+		{name: "AbsoluteTaskDir", runDir: "/work", task: types.Task{Dir: "/opt"}, wantTask: "/opt", wantAction: filepath.Join("/opt", "src")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config.RunDir = tt.runDir
+			taskDir := r.taskDir(context.Background(), tt.task)
+			require.Equal(t, tt.wantTask, taskDir)
+
+			actions := r.withWorkingDir(context.Background(), []types.Action{dir(""), dir("src")}, taskDir)
+			require.Equal(t, tt.wantTask, *actions[0].Dir)
+			require.Equal(t, tt.wantAction, *actions[1].Dir)
+		})
+	}
+}
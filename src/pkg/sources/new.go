@@ -5,15 +5,20 @@
 package sources
 
 import (
+	"context"
 	"strings"
 
-	"github.com/defenseunicorns/zarf/src/pkg/oci"
 	zarfSources "github.com/defenseunicorns/zarf/src/pkg/packager/sources"
 	zarfTypes "github.com/defenseunicorns/zarf/src/types"
+
+	"github.com/defenseunicorns/uds-cli/src/config"
+	"github.com/defenseunicorns/uds-cli/src/pkg/utils"
+	"github.com/defenseunicorns/uds-cli/src/types"
 )
 
-// New creates a new package source based on pkgLocation
-func New(pkgLocation string, pkgName string, opts zarfTypes.ZarfPackageOptions, sha string) (zarfSources.PackageSource, error) {
+// New creates a new package source based on pkgLocation, policy is how the signature of remote bundles is validated.
+// Cancelling ctx aborts the source's registry operations and archive reads
+func New(ctx context.Context, pkgLocation string, pkgName string, opts zarfTypes.ZarfPackageOptions, sha string, policy types.SignaturePolicy) (zarfSources.PackageSource, error) {
 	var source zarfSources.PackageSource
 	if strings.Contains(pkgLocation, "tar.zst") {
 		source = &TarballBundle{
@@ -22,18 +27,25 @@ func New(pkgLocation string, pkgName string, opts zarfTypes.ZarfPackageOptions,
 			PkgManifestSHA: sha,
 			TmpDir:         opts.PackageSource,
 			BundleLocation: pkgLocation,
+			ctx:            ctx,
 		}
 	} else {
-		remote, err := oci.NewOrasRemote(pkgLocation)
+		remote, err := utils.NewOrasRemote(pkgLocation)
 		if err != nil {
 			return nil, err
 		}
+		remote.WithContext(ctx)
+		if err := utils.ResolveIndex(ctx, remote, config.GetArch()); err != nil {
+			return nil, err
+		}
 		source = &RemoteBundle{
-			PkgName:        pkgName,
-			PkgOpts:        &opts,
-			PkgManifestSHA: sha,
-			TmpDir:         opts.PackageSource,
-			Remote:         remote,
+			PkgName:         pkgName,
+			PkgOpts:         &opts,
+			PkgManifestSHA:  sha,
+			TmpDir:          opts.PackageSource,
+			Remote:          remote,
+			SignaturePolicy: policy,
+			ctx:             ctx,
 		}
 	}
 	return source, nil
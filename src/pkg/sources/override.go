@@ -0,0 +1,133 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023-Present The UDS Authors
+
+// Package sources contains Zarf packager sources
+package sources
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/defenseunicorns/zarf/src/pkg/layout"
+	"github.com/defenseunicorns/zarf/src/pkg/message"
+	"github.com/defenseunicorns/zarf/src/pkg/oci"
+	"github.com/defenseunicorns/zarf/src/pkg/packager/sources"
+	zarfUtils "github.com/defenseunicorns/zarf/src/pkg/utils"
+	"github.com/defenseunicorns/zarf/src/pkg/utils/helpers"
+	zarfTypes "github.com/defenseunicorns/zarf/src/types"
+
+	"github.com/defenseunicorns/uds-cli/src/config"
+	"github.com/defenseunicorns/uds-cli/src/pkg/utils"
+)
+
+// PackageOverride is a package source that pulls a bundle's package from a Zarf package ref given at deploy time
+// (with --ref) instead of from the bundle. The package isn't pinned by the bundle and its signature isn't verified.
+type PackageOverride struct {
+	PkgName string
+	PkgOpts *zarfTypes.ZarfPackageOptions
+	Ref     string
+	Remote  *oci.OrasRemote
+}
+
+// NewOverride creates a package source that pulls the package pkgName from ref instead of from the bundle, cancelling
+// ctx aborts the pull
+func NewOverride(ctx context.Context, ref string, pkgName string, opts zarfTypes.ZarfPackageOptions) (*PackageOverride, error) {
+	remote, err := utils.NewOrasRemote(ref)
+	if err != nil {
+		return nil, err
+	}
+	remote.WithContext(ctx)
+	if err := utils.ResolveIndex(ctx, remote, config.GetArch()); err != nil {
+		return nil, err
+	}
+	return &PackageOverride{
+		PkgName: pkgName,
+		PkgOpts: &opts,
+		Ref:     ref,
+		Remote:  remote,
+	}, nil
+}
+
+// LoadPackage loads the overriding Zarf package, skipping its signature verification
+func (o *PackageOverride) LoadPackage(dst *layout.PackagePaths, unarchiveAll bool) error {
+	message.Debugf("Loading package %s from %s", o.PkgName, o.Ref)
+
+	// only pull the layers of the requested components
+	layersToPull, err := o.Remote.LayersFromRequestedComponents(helpers.StringToSlice(o.PkgOpts.OptionalComponents))
+	if err != nil {
+		return fmt.Errorf("unable to get the layers of package %s from %s: %w", o.PkgName, o.Ref, err)
+	}
+	root, err := o.Remote.FetchRoot()
+	if err != nil {
+		return err
+	}
+	isPartial := len(root.Layers) != len(layersToPull)
+
+	layers, err := o.Remote.PullPackage(dst.Base, config.CommonOptions.OCIConcurrency, layersToPull...)
+	if err != nil {
+		return fmt.Errorf("unable to pull package %s from %s: %w", o.PkgName, o.Ref, err)
+	}
+	dst.SetFromLayers(layers)
+
+	pkg, err := o.readPackage(dst)
+	if err != nil {
+		return err
+	}
+	if err := sources.ValidatePackageIntegrity(dst, pkg.Metadata.AggregateChecksum, isPartial); err != nil {
+		return err
+	}
+
+	if unarchiveAll {
+		for _, component := range pkg.Components {
+			if err := dst.Components.Unarchive(component); err != nil {
+				if layout.IsNotLoaded(err) {
+					_, err := dst.Components.Create(component)
+					if err != nil {
+						return err
+					}
+				} else {
+					return err
+				}
+			}
+		}
+
+		if dst.SBOMs.Path != "" {
+			if err := dst.SBOMs.Unarchive(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// LoadPackageMetadata loads the overriding Zarf package's metadata, skipping its signature verification
+func (o *PackageOverride) LoadPackageMetadata(dst *layout.PackagePaths, _ bool, _ bool) error {
+	layers, err := o.Remote.PullPackagePaths(oci.PackageAlwaysPull, dst.Base)
+	if err != nil {
+		return fmt.Errorf("unable to pull the metadata of package %s from %s: %w", o.PkgName, o.Ref, err)
+	}
+	dst.SetFromLayers(layers)
+
+	pkg, err := o.readPackage(dst)
+	if err != nil {
+		return err
+	}
+	return sources.ValidatePackageIntegrity(dst, pkg.Metadata.AggregateChecksum, true)
+}
+
+// Collect doesn't need to be implemented
+func (o *PackageOverride) Collect(_ string) (string, error) {
+	return "", fmt.Errorf("not implemented in %T", o)
+}
+
+// readPackage reads the pulled zarf.yaml, warning when it isn't the package it overrides
+func (o *PackageOverride) readPackage(dst *layout.PackagePaths) (zarfTypes.ZarfPackage, error) {
+	var pkg zarfTypes.ZarfPackage
+	if err := zarfUtils.ReadYaml(dst.ZarfYAML, &pkg); err != nil {
+		return pkg, err
+	}
+	if pkg.Metadata.Name != o.PkgName {
+		message.Warnf("%s is package %s, not package %s", o.Ref, pkg.Metadata.Name, o.PkgName)
+	}
+	return pkg, nil
+}
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"sync"
 
@@ -26,33 +27,39 @@ import (
 	"github.com/defenseunicorns/uds-cli/src/config"
 	"github.com/defenseunicorns/uds-cli/src/pkg/cache"
 	"github.com/defenseunicorns/uds-cli/src/pkg/utils"
+	"github.com/defenseunicorns/uds-cli/src/types"
 )
 
 // RemoteBundle is a package source for remote bundles that implements Zarf's packager.PackageSource
 type RemoteBundle struct {
-	PkgName        string
-	PkgOpts        *zarfTypes.ZarfPackageOptions
-	PkgManifestSHA string
-	TmpDir         string
-	Remote         *oci.OrasRemote
-	isPartial      bool
+	PkgName         string
+	PkgOpts         *zarfTypes.ZarfPackageOptions
+	PkgManifestSHA  string
+	TmpDir          string
+	Remote          *oci.OrasRemote
+	SignaturePolicy types.SignaturePolicy
+	isPartial       bool
+	prefetched      []ocispec.Descriptor
+	ctx             context.Context
 }
 
 // LoadPackage loads a Zarf package from a remote bundle
 func (r *RemoteBundle) LoadPackage(dst *layout.PackagePaths, unarchiveAll bool) error {
-	layers, err := r.downloadPkgFromRemoteBundle()
-	if err != nil {
-		return err
+	if r.prefetched == nil {
+		if err := r.Prefetch(r.ctx); err != nil {
+			return err
+		}
 	}
+	layers := r.prefetched
 
 	var pkg zarfTypes.ZarfPackage
-	if err = zarfUtils.ReadYaml(dst.ZarfYAML, &pkg); err != nil {
+	if err := zarfUtils.ReadYaml(dst.ZarfYAML, &pkg); err != nil {
 		return err
 	}
 
 	dst.SetFromLayers(layers)
 
-	err = sources.ValidatePackageIntegrity(dst, pkg.Metadata.AggregateChecksum, r.isPartial)
+	err := sources.ValidatePackageIntegrity(dst, pkg.Metadata.AggregateChecksum, r.isPartial)
 	if err != nil {
 		return err
 	}
@@ -82,7 +89,11 @@ func (r *RemoteBundle) LoadPackage(dst *layout.PackagePaths, unarchiveAll bool)
 
 // LoadPackageMetadata loads a Zarf package's metadata from a remote bundle
 func (r *RemoteBundle) LoadPackageMetadata(dst *layout.PackagePaths, _ bool, _ bool) (err error) {
-	root, err := r.Remote.FetchRoot()
+	if err := r.verifyBundleSignature(); err != nil {
+		return err
+	}
+
+	root, err := r.fetchRoot()
 	if err != nil {
 		return err
 	}
@@ -92,7 +103,10 @@ func (r *RemoteBundle) LoadPackageMetadata(dst *layout.PackagePaths, _ bool, _ b
 	}
 
 	// look at Zarf pkg manifest, grab zarf.yaml desc and download it
-	pkgManifest, err := r.Remote.FetchManifest(pkgManifestDesc)
+	pkgManifest, err := r.fetchManifest(pkgManifestDesc)
+	if err != nil {
+		return err
+	}
 	var zarfYAMLDesc ocispec.Descriptor
 	for _, layer := range pkgManifest.Layers {
 		if layer.Annotations[ocispec.AnnotationTitle] == config.ZarfYAML {
@@ -100,7 +114,7 @@ func (r *RemoteBundle) LoadPackageMetadata(dst *layout.PackagePaths, _ bool, _ b
 			break
 		}
 	}
-	zarfYAMLBytes, err := r.Remote.FetchLayer(zarfYAMLDesc)
+	zarfYAMLBytes, err := r.fetchLayer(zarfYAMLDesc)
 	if err != nil {
 		return err
 	}
@@ -114,7 +128,7 @@ func (r *RemoteBundle) LoadPackageMetadata(dst *layout.PackagePaths, _ bool, _ b
 	var checksumLayer ocispec.Descriptor
 	for _, layer := range pkgManifest.Layers {
 		if layer.Annotations[ocispec.AnnotationTitle] == config.ChecksumsTxt {
-			checksumBytes, err := r.Remote.FetchLayer(layer)
+			checksumBytes, err := r.fetchLayer(layer)
 			if err != nil {
 				return err
 			}
@@ -133,14 +147,121 @@ func (r *RemoteBundle) LoadPackageMetadata(dst *layout.PackagePaths, _ bool, _ b
 	return err
 }
 
+// Prefetch downloads the Zarf package from the remote bundle into TmpDir ahead of LoadPackage, which allows
+// downloading several packages concurrently; cancelling ctx aborts the download
+func (r *RemoteBundle) Prefetch(ctx context.Context) error {
+	if err := r.verifyBundleSignature(); err != nil {
+		return err
+	}
+	layers, err := r.downloadPkgFromRemoteBundle(ctx)
+	if err != nil {
+		return err
+	}
+	r.prefetched = layers
+	return nil
+}
+
 // Collect doesn't need to be implemented
 func (r *RemoteBundle) Collect(_ string) (string, error) {
 	return "", fmt.Errorf("not implemented in %T", r)
 }
 
+// verifyBundleSignature applies the signature policy to the bundle: when a public key was provided, it verifies the
+// signature of the bundle's uds-bundle.yaml and that the package being loaded is one the signed uds-bundle.yaml references
+func (r *RemoteBundle) verifyBundleSignature() error {
+	root, err := r.fetchRoot()
+	if err != nil {
+		return err
+	}
+	signatureDesc := root.Locate(config.BundleYAMLSignature)
+	verify, err := utils.CheckSignaturePolicy(r.SignaturePolicy, !oci.IsEmptyDescriptor(signatureDesc))
+	if err != nil || !verify {
+		return err
+	}
+	bundleYAMLDesc := root.Locate(config.BundleYAML)
+	if oci.IsEmptyDescriptor(bundleYAMLDesc) {
+		return fmt.Errorf("%s not found in bundle", config.BundleYAML)
+	}
+
+	// reconstruct the signed uds-bundle.yaml and its signature on disk so cosign can verify them
+	tmp, err := zarfUtils.MakeTempDir(config.CommonOptions.TempDirectory)
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmp)
+	bundleYAMLBytes, err := r.fetchLayer(bundleYAMLDesc)
+	if err != nil {
+		return err
+	}
+	bundleYAMLPath := filepath.Join(tmp, config.BundleYAML)
+	if err := os.WriteFile(bundleYAMLPath, bundleYAMLBytes, 0600); err != nil {
+		return err
+	}
+	signatureBytes, err := r.fetchLayer(signatureDesc)
+	if err != nil {
+		return err
+	}
+	signaturePath := filepath.Join(tmp, config.BundleYAMLSignature)
+	if err := os.WriteFile(signaturePath, signatureBytes, 0600); err != nil {
+		return err
+	}
+	if err := zarfUtils.CosignVerifyBlob(bundleYAMLPath, signaturePath, r.SignaturePolicy.PublicKeyPath); err != nil {
+		return fmt.Errorf("bundle signature verification failed, %s may have been tampered with: %w", config.BundleYAML, err)
+	}
+
+	// the package's manifest sha is pinned in the package refs of the signed uds-bundle.yaml
+	var bundle types.UDSBundle
+	if err := goyaml.Unmarshal(bundleYAMLBytes, &bundle); err != nil {
+		return err
+	}
+	signed := slices.ContainsFunc(bundle.ZarfPackages, func(pkg types.BundleZarfPackage) bool {
+		return strings.HasSuffix(pkg.Ref, "sha256:"+r.PkgManifestSHA)
+	})
+	if !signed {
+		return fmt.Errorf("zarf package %s with manifest sha %s is not part of the signed bundle", r.PkgName, r.PkgManifestSHA)
+	}
+	message.Debugf("Verified the bundle signature for package %s", r.PkgName)
+	return nil
+}
+
+// layersInBundle returns the layers of the Zarf pkg that are in the bundle, using the layer digests recorded in the
+// bundle's build data and falling back to probing the remote for bundles created without them
+func (r *RemoteBundle) layersInBundle(ctx context.Context, rootManifest *oci.ZarfOCIManifest, pkgManifest *oci.ZarfOCIManifest) ([]ocispec.Descriptor, error) {
+	bundleYAMLDesc := rootManifest.Locate(config.BundleYAML)
+	if !oci.IsEmptyDescriptor(bundleYAMLDesc) {
+		bundleYAMLBytes, err := r.fetchLayer(bundleYAMLDesc)
+		if err != nil {
+			return nil, err
+		}
+		var bundle types.UDSBundle
+		if err := goyaml.Unmarshal(bundleYAMLBytes, &bundle); err != nil {
+			return nil, err
+		}
+		if digests, ok := bundle.Build.PackageLayers[r.PkgName]; ok {
+			return utils.LayersByDigest(pkgManifest.Layers, digests), nil
+		}
+	}
+
+	message.Debugf("%s has no recorded layers for %s, probing the remote for them", config.BundleYAML, r.PkgName)
+	progressBar := message.NewProgressBar(int64(len(pkgManifest.Layers)), fmt.Sprintf("Verifying layers in Zarf package: %s", r.PkgName))
+	var layers []ocispec.Descriptor
+	for _, layer := range pkgManifest.Layers {
+		ok, err := r.layerExists(ctx, layer)
+		if err != nil {
+			return nil, err
+		}
+		progressBar.Add(1)
+		if ok {
+			layers = append(layers, layer)
+		}
+	}
+	progressBar.Successf("Verified %s package", r.PkgName)
+	return layers, nil
+}
+
 // downloadPkgFromRemoteBundle downloads a Zarf package from a remote bundle
-func (r *RemoteBundle) downloadPkgFromRemoteBundle() ([]ocispec.Descriptor, error) {
-	rootManifest, err := r.Remote.FetchRoot()
+func (r *RemoteBundle) downloadPkgFromRemoteBundle(ctx context.Context) ([]ocispec.Descriptor, error) {
+	rootManifest, err := r.fetchRoot()
 	if err != nil {
 		return nil, err
 	}
@@ -151,41 +272,36 @@ func (r *RemoteBundle) downloadPkgFromRemoteBundle() ([]ocispec.Descriptor, erro
 	}
 	// hack Zarf media type so that FetchManifest works
 	pkgManifestDesc.MediaType = oci.ZarfLayerMediaTypeBlob
-	pkgManifest, err := r.Remote.FetchManifest(pkgManifestDesc)
+	pkgManifest, err := r.fetchManifest(pkgManifestDesc)
 	if err != nil || pkgManifest == nil {
 		return nil, err
 	}
 
 	// only fetch layers that exist in the remote as optional ones might not exist
-	// todo: this is incredibly slow; maybe keep track of layers in bundle metadata instead of having to query the remote?
-	progressBar := message.NewProgressBar(int64(len(pkgManifest.Layers)), fmt.Sprintf("Verifying layers in Zarf package: %s", r.PkgName))
+	pkgLayers, err := r.layersInBundle(ctx, rootManifest, pkgManifest)
+	if err != nil {
+		return nil, err
+	}
+	// only count the bytes that go over the network, cached layers are copied into the tmp dir before the pull
 	estimatedBytes := int64(0)
+	cacheHits := 0
 	layersToPull := []ocispec.Descriptor{pkgManifestDesc}
 	layersInBundle := []ocispec.Descriptor{pkgManifestDesc}
 
-	for _, layer := range pkgManifest.Layers {
-		ok, err := r.Remote.Repo().Blobs().Exists(context.TODO(), layer)
-		if err != nil {
-			return nil, err
-		}
-		progressBar.Add(1)
-		if ok {
-			estimatedBytes += layer.Size
-			layersInBundle = append(layersInBundle, layer)
-			digest := layer.Digest.Encoded()
-			if strings.Contains(layer.Annotations[ocispec.AnnotationTitle], config.BlobsDir) && cache.Exists(digest) {
-				dst := filepath.Join(r.TmpDir, "images", config.BlobsDir)
-				err = cache.Use(digest, dst)
-				if err != nil {
-					return nil, err
-				}
-			} else {
-				layersToPull = append(layersToPull, layer)
+	for _, layer := range pkgLayers {
+		layersInBundle = append(layersInBundle, layer)
+		digest := layer.Digest.Encoded()
+		if strings.Contains(layer.Annotations[ocispec.AnnotationTitle], config.BlobsDir) && cache.Exists(digest) {
+			// the layer may have been pruned from the cache since, in which case it is pulled
+			if err := cache.Use(digest, filepath.Join(r.TmpDir, "images", config.BlobsDir)); err == nil {
+				cacheHits++
+				continue
 			}
-
+			message.Debugf("Unable to use cached layer %s, pulling it", digest)
 		}
+		estimatedBytes += layer.Size
+		layersToPull = append(layersToPull, layer)
 	}
-	progressBar.Successf("Verified %s package", r.PkgName)
 
 	store, err := file.New(r.TmpDir)
 	if err != nil {
@@ -199,8 +315,9 @@ func (r *RemoteBundle) downloadPkgFromRemoteBundle() ([]ocispec.Descriptor, erro
 	errChan := make(chan int)
 	var wg sync.WaitGroup
 	wg.Add(1)
-	go zarfUtils.RenderProgressBarForLocalDirWrite(r.TmpDir, estimatedBytes, &wg, doneSaving, errChan, fmt.Sprintf("Pulling bundled Zarf pkg: %s", r.PkgName), fmt.Sprintf("Successfully pulled package: %s", r.PkgName))
-	_, err = oras.Copy(context.TODO(), r.Remote.Repo(), r.Remote.Repo().Reference.String(), store, "", copyOpts)
+	progress := utils.NewPullProgress(r.TmpDir, estimatedBytes, cacheHits)
+	go progress.Render(&wg, doneSaving, errChan, fmt.Sprintf("Pulling bundled Zarf pkg: %s", r.PkgName), fmt.Sprintf("Successfully pulled package: %s", r.PkgName))
+	_, err = oras.Copy(ctx, r.Remote.Repo(), r.Remote.Repo().Reference.String(), store, "", copyOpts)
 	if err != nil {
 		errChan <- 1
 		return nil, err
@@ -213,3 +330,41 @@ func (r *RemoteBundle) downloadPkgFromRemoteBundle() ([]ocispec.Descriptor, erro
 	}
 	return layersInBundle, nil
 }
+
+// fetchRoot fetches the bundle's root manifest (once per command), retrying transient registry errors
+func (r *RemoteBundle) fetchRoot() (*oci.ZarfOCIManifest, error) {
+	return rootManifests.get(r.Remote.Repo().Reference.String(), func() (root *oci.ZarfOCIManifest, err error) {
+		err = utils.RetryOCI("fetching the bundle manifest", func() error {
+			root, err = r.Remote.FetchRoot()
+			return err
+		})
+		return root, err
+	})
+}
+
+// fetchManifest fetches a manifest from the bundle, retrying transient registry errors
+func (r *RemoteBundle) fetchManifest(desc ocispec.Descriptor) (manifest *oci.ZarfOCIManifest, err error) {
+	err = utils.RetryOCI(fmt.Sprintf("fetching manifest %s", desc.Digest.Encoded()), func() error {
+		manifest, err = r.Remote.FetchManifest(desc)
+		return err
+	})
+	return manifest, err
+}
+
+// fetchLayer fetches a layer from the bundle, retrying transient registry errors
+func (r *RemoteBundle) fetchLayer(desc ocispec.Descriptor) (layer []byte, err error) {
+	err = utils.RetryOCI(fmt.Sprintf("fetching layer %s", desc.Digest.Encoded()), func() error {
+		layer, err = r.Remote.FetchLayer(desc)
+		return err
+	})
+	return layer, err
+}
+
+// layerExists checks whether a layer is in the bundle, retrying transient registry errors
+func (r *RemoteBundle) layerExists(ctx context.Context, desc ocispec.Descriptor) (exists bool, err error) {
+	err = utils.RetryOCI(fmt.Sprintf("checking layer %s", desc.Digest.Encoded()), func() error {
+		exists, err = r.Remote.Repo().Blobs().Exists(ctx, desc)
+		return err
+	})
+	return exists, err
+}
@@ -0,0 +1,38 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023-Present The UDS Authors
+
+// Package sources contains Zarf packager sources
+package sources
+
+import (
+	"sync"
+
+	"github.com/defenseunicorns/zarf/src/pkg/oci"
+)
+
+// rootManifestCache memoizes the root manifests of remote bundles for the lifetime of a command, so that deploying
+// several pkgs from a bundle (each with its own RemoteBundle) only fetches the bundle's root manifest once
+type rootManifestCache struct {
+	mu        sync.Mutex
+	manifests map[string]*oci.ZarfOCIManifest
+}
+
+// rootManifests is the root manifest cache shared by every RemoteBundle
+var rootManifests = &rootManifestCache{manifests: map[string]*oci.ZarfOCIManifest{}}
+
+// get returns the cached root manifest of the bundle at ref, or fetches (and caches) it. Manifests are keyed by their
+// full reference so another tag or digest of the bundle is fetched again, and failed fetches aren't cached
+func (c *rootManifestCache) get(ref string, fetch func() (*oci.ZarfOCIManifest, error)) (*oci.ZarfOCIManifest, error) {
+	// fetch while locked so that pkgs loaded concurrently wait for a single fetch of the same root
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if root, ok := c.manifests[ref]; ok {
+		return root, nil
+	}
+	root, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+	c.manifests[ref] = root
+	return root, nil
+}
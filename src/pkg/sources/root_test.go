@@ -0,0 +1,39 @@
+package sources
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/defenseunicorns/zarf/src/pkg/oci"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_rootManifestCache(t *testing.T) {
+	cache := &rootManifestCache{manifests: map[string]*oci.ZarfOCIManifest{}}
+	fetches := 0
+	fetch := func() (*oci.ZarfOCIManifest, error) {
+		fetches++
+		return &oci.ZarfOCIManifest{}, nil
+	}
+
+	first, err := cache.get("ghcr.io/bundle:0.0.1", fetch)
+	require.NoError(t, err)
+	second, err := cache.get("ghcr.io/bundle:0.0.1", fetch)
+	require.NoError(t, err)
+	require.Same(t, first, second)
+	require.Equal(t, 1, fetches)
+
+	// another reference is fetched again
+	_, err = cache.get("ghcr.io/bundle:0.0.2", fetch)
+	require.NoError(t, err)
+	require.Equal(t, 2, fetches)
+
+	// failed fetches aren't cached
+	_, err = cache.get("ghcr.io/bundle:0.0.3", func() (*oci.ZarfOCIManifest, error) {
+		return nil, errors.New("unavailable")
+	})
+	require.ErrorContains(t, err, "unavailable")
+	_, err = cache.get("ghcr.io/bundle:0.0.3", fetch)
+	require.NoError(t, err)
+	require.Equal(t, 3, fetches)
+}
@@ -6,6 +6,7 @@ package sources
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
@@ -20,13 +21,14 @@ import (
 	"github.com/defenseunicorns/zarf/src/pkg/utils/helpers"
 	zarfTypes "github.com/defenseunicorns/zarf/src/types"
 	av4 "github.com/mholt/archiver/v4"
+	"github.com/opencontainers/go-digest"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 
 	"github.com/defenseunicorns/uds-cli/src/config"
-	"github.com/defenseunicorns/uds-cli/src/pkg/utils"
 )
 
-// TarballBundle is a package source for local tarball bundles that implements Zarf's packager.PackageSource
+// TarballBundle is a package source for local tarball bundles (ie. created, pulled or exported bundles deployed from
+// disk, including air-gapped deploys) that implements Zarf's packager.PackageSource, mirroring RemoteBundle
 type TarballBundle struct {
 	PkgOpts        *zarfTypes.ZarfPackageOptions
 	PkgManifestSHA string
@@ -34,6 +36,7 @@ type TarballBundle struct {
 	BundleLocation string
 	PkgName        string
 	isPartial      bool
+	ctx            context.Context
 }
 
 // LoadPackage loads a Zarf package from a local tarball bundle
@@ -82,7 +85,7 @@ func (t *TarballBundle) LoadPackage(dst *layout.PackagePaths, unarchiveAll bool)
 
 // LoadPackageMetadata loads a Zarf package's metadata from a local tarball bundle
 func (t *TarballBundle) LoadPackageMetadata(dst *layout.PackagePaths, _ bool, _ bool) (err error) {
-	ctx := context.TODO()
+	ctx := t.ctx
 	format := av4.CompressedArchive{
 		Compression: av4.Zstd{},
 		Archival:    av4.Tar{},
@@ -93,8 +96,9 @@ func (t *TarballBundle) LoadPackageMetadata(dst *layout.PackagePaths, _ bool, _
 		return err
 	}
 
-	var imageManifest oci.ZarfOCIManifest
-	if err := format.Extract(ctx, sourceArchive, []string{filepath.Join(config.BlobsDir, t.PkgManifestSHA)}, utils.ExtractJSON(&imageManifest)); err != nil {
+	imageManifest, err := t.extractPkgManifest(ctx, format, sourceArchive)
+	if err != nil {
+		sourceArchive.Close()
 		return err
 	}
 
@@ -178,6 +182,34 @@ func (t *TarballBundle) Collect(_ string) (string, error) {
 	return "", fmt.Errorf("not implemented in %T", t)
 }
 
+// extractPkgManifest extracts the Zarf package's manifest from the bundle, verifying that it is the manifest pinned
+// (by sha) in the bundle's uds-bundle.yaml
+func (t *TarballBundle) extractPkgManifest(ctx context.Context, format av4.CompressedArchive, sourceArchive io.Reader) (oci.ZarfOCIManifest, error) {
+	var manifestBytes []byte
+	if err := format.Extract(ctx, sourceArchive, []string{filepath.Join(config.BlobsDir, t.PkgManifestSHA)}, func(_ context.Context, file av4.File) error {
+		stream, err := file.Open()
+		if err != nil {
+			return err
+		}
+		defer stream.Close()
+		manifestBytes, err = io.ReadAll(stream)
+		return err
+	}); err != nil {
+		return oci.ZarfOCIManifest{}, err
+	}
+	if manifestBytes == nil {
+		return oci.ZarfOCIManifest{}, fmt.Errorf("zarf package %s with manifest sha %s not found in %s", t.PkgName, t.PkgManifestSHA, t.BundleLocation)
+	}
+	if sha := digest.FromBytes(manifestBytes).Encoded(); sha != t.PkgManifestSHA {
+		return oci.ZarfOCIManifest{}, fmt.Errorf("manifest of zarf package %s has sha %s instead of %s, %s may have been tampered with", t.PkgName, sha, t.PkgManifestSHA, t.BundleLocation)
+	}
+	var manifest oci.ZarfOCIManifest
+	if err := json.Unmarshal(manifestBytes, &manifest); err != nil {
+		return oci.ZarfOCIManifest{}, err
+	}
+	return manifest, nil
+}
+
 // extractPkgFromBundle extracts a Zarf package from a local tarball bundle
 func (t *TarballBundle) extractPkgFromBundle() ([]string, error) {
 	var files []string
@@ -190,8 +222,8 @@ func (t *TarballBundle) extractPkgFromBundle() ([]string, error) {
 		return nil, err
 	}
 
-	var manifest oci.ZarfOCIManifest
-	if err := format.Extract(context.TODO(), sourceArchive, []string{filepath.Join(config.BlobsDir, t.PkgManifestSHA)}, utils.ExtractJSON(&manifest)); err != nil {
+	manifest, err := t.extractPkgManifest(t.ctx, format, sourceArchive)
+	if err != nil {
 		if err := sourceArchive.Close(); err != nil {
 			return nil, err
 		}
@@ -252,7 +284,7 @@ func (t *TarballBundle) extractPkgFromBundle() ([]string, error) {
 		return nil, err
 	}
 	defer sourceArchive.Close()
-	err = format.Extract(context.TODO(), sourceArchive, layersToExtract, extractLayer)
+	err = format.Extract(t.ctx, sourceArchive, layersToExtract, extractLayer)
 	if len(manifest.Layers) > len(files) {
 		t.isPartial = true
 	}
@@ -0,0 +1,60 @@
+package sources
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/defenseunicorns/zarf/src/pkg/oci"
+	av4 "github.com/mholt/archiver/v4"
+	"github.com/opencontainers/go-digest"
+	"github.com/stretchr/testify/require"
+
+	"github.com/defenseunicorns/uds-cli/src/config"
+)
+
+func Test_extractPkgManifest(t *testing.T) {
+	manifestBytes := []byte(`{"schemaVersion":2,"layers":[{"mediaType":"application/vnd.zarf.layer.v1.blob","digest":"sha256:abc","size":1}]}`)
+	sha := digest.FromBytes(manifestBytes).Encoded()
+	tamperedSHA := digest.FromString("tampered").Encoded()
+
+	// a bundle whose tampered blob doesn't match its sha
+	tmp := t.TempDir()
+	blobs := filepath.Join(tmp, config.BlobsDir)
+	require.NoError(t, os.MkdirAll(blobs, 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(blobs, sha), manifestBytes, 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(blobs, tamperedSHA), manifestBytes, 0600))
+	files, err := av4.FilesFromDisk(nil, map[string]string{
+		filepath.Join(blobs, sha):         filepath.Join(config.BlobsDir, sha),
+		filepath.Join(blobs, tamperedSHA): filepath.Join(config.BlobsDir, tamperedSHA),
+	})
+	require.NoError(t, err)
+	format := av4.CompressedArchive{Compression: av4.Zstd{}, Archival: av4.Tar{}}
+	var archive bytes.Buffer
+	require.NoError(t, format.Archive(context.TODO(), &archive, files))
+
+	tests := []struct {
+		name    string
+		sha     string
+		wantErr string
+	}{
+		{name: "Valid", sha: sha},
+		{name: "Tampered", sha: tamperedSHA, wantErr: "may have been tampered with"},
+		{name: "Missing", sha: digest.FromString("missing").Encoded(), wantErr: "not found in"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tb := &TarballBundle{PkgName: "podinfo", PkgManifestSHA: tt.sha, BundleLocation: "uds-bundle-test.tar.zst"}
+			manifest, err := tb.extractPkgManifest(context.TODO(), format, bytes.NewReader(archive.Bytes()))
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, manifest.Layers, 1)
+			require.Equal(t, oci.ZarfLayerMediaTypeBlob, manifest.Layers[0].MediaType)
+		})
+	}
+}
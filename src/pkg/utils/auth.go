@@ -0,0 +1,85 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023-Present The UDS Authors
+
+// Package utils provides utility fns for UDS-CLI
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/defenseunicorns/zarf/src/pkg/message"
+	"github.com/defenseunicorns/zarf/src/pkg/oci"
+	"oras.land/oras-go/v2/registry/remote/auth"
+	"oras.land/oras-go/v2/registry/remote/errcode"
+)
+
+// RegistryCredentials are the credentials given on the command line for the registries of OCI remotes, which
+// take precedence over the Docker config file ($DOCKER_CONFIG/config.json or ~/.docker/config.json)
+type RegistryCredentials struct {
+	Username string
+	Password string
+	Token    string
+}
+
+// IsEmpty returns true if no credentials were given
+func (c RegistryCredentials) IsEmpty() bool {
+	return c.Username == "" && c.Password == "" && c.Token == ""
+}
+
+// validate checks that the credentials are either a username and password or a token
+func (c RegistryCredentials) validate() error {
+	if c.Token != "" && (c.Username != "" || c.Password != "") {
+		return errors.New("a registry token can't be combined with a registry username or password")
+	}
+	if c.Token == "" && (c.Username == "") != (c.Password == "") {
+		return errors.New("a registry username and password must be given together")
+	}
+	return nil
+}
+
+// WithCredentials authenticates the connections of remote to its registry with creds, instead of the credentials
+// Zarf read from the Docker config file
+func WithCredentials(remote *oci.OrasRemote, creds RegistryCredentials) error {
+	if creds.IsEmpty() {
+		return nil
+	}
+	if err := creds.validate(); err != nil {
+		return err
+	}
+	client, ok := remote.Repo().Client.(*auth.Client)
+	if !ok {
+		return fmt.Errorf("unable to configure credentials for %s", remote.Repo().Reference)
+	}
+	registry := remote.Repo().Reference.Registry
+	client.Credential = auth.StaticCredential(registry, auth.Credential{
+		Username:    creds.Username,
+		Password:    creds.Password,
+		AccessToken: creds.Token,
+	})
+	message.Debugf("Using the registry credentials from the command line for %s", registry)
+	return nil
+}
+
+// WithAuthHint adds a hint on how to authenticate to err if a registry rejected the request as unauthorized, or
+// asked for credentials that weren't given
+func WithAuthHint(err error) error {
+	registry := "<registry>"
+	var errResp *errcode.ErrorResponse
+	switch {
+	case errors.As(err, &errResp):
+		if errResp.StatusCode != http.StatusUnauthorized && errResp.StatusCode != http.StatusForbidden {
+			return err
+		}
+		if errResp.URL != nil {
+			registry = errResp.URL.Host
+		}
+	// oras doesn't export an error for a registry asking for basic auth without credentials having been given
+	case err != nil && strings.Contains(err.Error(), "credential required for basic auth"):
+	default:
+		return err
+	}
+	return fmt.Errorf("%w (log in with 'docker login %s', or pass --registry-username and --registry-password or --registry-token)", err, registry)
+}
@@ -0,0 +1,107 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	zarfConfig "github.com/defenseunicorns/zarf/src/config"
+	"github.com/stretchr/testify/require"
+
+	"github.com/defenseunicorns/uds-cli/src/config"
+)
+
+// stubAuthRegistry serves a single manifest to requests with the wanted Authorization header and challenges the rest
+type stubAuthRegistry struct {
+	scheme        string
+	authorization string
+}
+
+func (s *stubAuthRegistry) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") != s.authorization {
+		w.Header().Set("WWW-Authenticate", fmt.Sprintf(`%s realm="http://%s/token",service="stub"`, s.scheme, r.Host))
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	w.Header().Set("Content-Type", "application/vnd.oci.image.manifest.v1+json")
+	w.Header().Set("Docker-Content-Digest", "sha256:"+strings.Repeat("a", 64))
+	w.Header().Set("Content-Length", "2")
+	w.WriteHeader(http.StatusOK)
+}
+
+func Test_NewOrasRemoteCredentials(t *testing.T) {
+	t.Setenv("DOCKER_CONFIG", t.TempDir())
+	zarfConfig.CommonOptions.Insecure = true
+	defer func() {
+		zarfConfig.CommonOptions.Insecure = false
+		config.CommonOptions.RegistryUsername = ""
+		config.CommonOptions.RegistryPassword = ""
+		config.CommonOptions.RegistryToken = ""
+	}()
+
+	tests := []struct {
+		name     string
+		scheme   string
+		username string
+		password string
+		token    string
+		wantErr  string
+	}{
+		{
+			name:     "UsernameAndPassword",
+			scheme:   "Basic",
+			username: "user",
+			password: "pass",
+		},
+		{
+			name:   "Token",
+			scheme: "Bearer",
+			token:  "token",
+		},
+		{
+			name:    "NoCredentials",
+			scheme:  "Basic",
+			wantErr: "docker login <registry>",
+		},
+		{
+			name:     "WrongPassword",
+			scheme:   "Basic",
+			username: "user",
+			password: "wrong",
+			wantErr:  "docker login 127.0.0.1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stub := &stubAuthRegistry{scheme: tt.scheme, authorization: "Basic dXNlcjpwYXNz"}
+			if tt.scheme == "Bearer" {
+				stub.authorization = "Bearer token"
+			}
+			server := httptest.NewServer(stub)
+			defer server.Close()
+
+			config.CommonOptions.RegistryUsername = tt.username
+			config.CommonOptions.RegistryPassword = tt.password
+			config.CommonOptions.RegistryToken = tt.token
+
+			remote, err := NewOrasRemote(strings.TrimPrefix(server.URL, "http://") + "/bundle:0.0.1")
+			require.NoError(t, err)
+			_, err = remote.Repo().Resolve(context.TODO(), "0.0.1")
+			if tt.wantErr != "" {
+				require.ErrorContains(t, WithAuthHint(err), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func Test_RegistryCredentialsValidate(t *testing.T) {
+	require.NoError(t, RegistryCredentials{Username: "user", Password: "pass"}.validate())
+	require.NoError(t, RegistryCredentials{Token: "token"}.validate())
+	require.ErrorContains(t, RegistryCredentials{Username: "user"}.validate(), "must be given together")
+	require.ErrorContains(t, RegistryCredentials{Token: "token", Password: "pass"}.validate(), "can't be combined")
+}
@@ -0,0 +1,17 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023-Present The UDS Authors
+
+// Package utils provides utility fns for UDS-CLI
+package utils
+
+import "os"
+
+// ColorEnv returns whether the environment asks for output without colors (NO_COLOR is set to anything) or for colored
+// output even when stdout isn't a terminal (CLICOLOR_FORCE is set to anything but 0). NO_COLOR takes precedence
+func ColorEnv() (noColor bool, forceColor bool) {
+	if os.Getenv("NO_COLOR") != "" {
+		return true, false
+	}
+	force := os.Getenv("CLICOLOR_FORCE")
+	return false, force != "" && force != "0"
+}
@@ -0,0 +1,32 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ColorEnv(t *testing.T) {
+	tests := []struct {
+		name       string
+		noColor    string
+		force      string
+		wantNo     bool
+		wantForced bool
+	}{
+		{name: "unset"},
+		{name: "NO_COLOR", noColor: "1", wantNo: true},
+		{name: "CLICOLOR_FORCE", force: "1", wantForced: true},
+		{name: "CLICOLOR_FORCE=0", force: "0"},
+		{name: "NO_COLOR takes precedence", noColor: "true", force: "1", wantNo: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("NO_COLOR", tt.noColor)
+			t.Setenv("CLICOLOR_FORCE", tt.force)
+			noColor, forceColor := ColorEnv()
+			require.Equal(t, tt.wantNo, noColor)
+			require.Equal(t, tt.wantForced, forceColor)
+		})
+	}
+}
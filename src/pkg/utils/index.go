@@ -0,0 +1,124 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023-Present The UDS Authors
+
+// Package utils provides utility fns for UDS-CLI
+package utils
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/defenseunicorns/zarf/src/pkg/message"
+	"github.com/defenseunicorns/zarf/src/pkg/oci"
+	"github.com/opencontainers/image-spec/specs-go"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/errdef"
+)
+
+// bundleOS is the OS of every platform in a bundle's index, bundles always target (Linux) Kubernetes clusters
+const bundleOS = "linux"
+
+// PushToIndex adds (or replaces) the arch-specific bundle manifest in the multi-arch index tagged indexTag, creating the index if needed
+func PushToIndex(remote *oci.OrasRemote, indexTag string, manifestDesc ocispec.Descriptor, arch string) (ocispec.Descriptor, error) {
+	ctx := context.TODO()
+	repo := remote.Repo()
+
+	index := ocispec.Index{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		MediaType: ocispec.MediaTypeImageIndex,
+	}
+	existingDesc, err := repo.Resolve(ctx, indexTag)
+	switch {
+	case errors.Is(err, errdef.ErrNotFound):
+		message.Debugf("Creating a new index for %s", indexTag)
+	case err != nil:
+		return ocispec.Descriptor{}, err
+	case existingDesc.MediaType != ocispec.MediaTypeImageIndex:
+		message.Warnf("%s:%s is not a multi-arch index, it will be replaced by one", repo.Reference.Repository, indexTag)
+	default:
+		indexBytes, err := content.FetchAll(ctx, repo, existingDesc)
+		if err != nil {
+			return ocispec.Descriptor{}, err
+		}
+		if err := json.Unmarshal(indexBytes, &index); err != nil {
+			return ocispec.Descriptor{}, err
+		}
+	}
+
+	addToIndex(&index, manifestDesc, arch)
+	indexBytes, err := json.Marshal(index)
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	indexDesc := content.NewDescriptorFromBytes(ocispec.MediaTypeImageIndex, indexBytes)
+	err = RetryOCI("pushing index", func() error {
+		return repo.Manifests().PushReference(ctx, indexDesc, bytes.NewReader(indexBytes), indexTag)
+	})
+	if err != nil {
+		return ocispec.Descriptor{}, fmt.Errorf("failed to push index: %w", err)
+	}
+	return indexDesc, nil
+}
+
+// ResolveIndex points the remote at the bundle manifest for arch when its reference is a multi-arch index
+func ResolveIndex(ctx context.Context, remote *oci.OrasRemote, arch string) error {
+	repo := remote.Repo()
+	desc, err := repo.Resolve(ctx, repo.Reference.Reference)
+	if err != nil {
+		return err
+	}
+	if desc.MediaType != ocispec.MediaTypeImageIndex {
+		return nil
+	}
+	indexBytes, err := content.FetchAll(ctx, repo, desc)
+	if err != nil {
+		return err
+	}
+	var index ocispec.Index
+	if err := json.Unmarshal(indexBytes, &index); err != nil {
+		return err
+	}
+	manifestDesc, err := selectFromIndex(index, arch)
+	if err != nil {
+		return fmt.Errorf("%s: %w", repo.Reference, err)
+	}
+	message.Debugf("Resolved %s to the %s bundle %s", repo.Reference, arch, manifestDesc.Digest)
+	repo.Reference.Reference = manifestDesc.Digest.String()
+	return nil
+}
+
+// addToIndex adds a manifest to the index, replacing the manifest of the same arch
+func addToIndex(index *ocispec.Index, manifestDesc ocispec.Descriptor, arch string) {
+	manifests := []ocispec.Descriptor{}
+	for _, desc := range index.Manifests {
+		if desc.Platform == nil || desc.Platform.Architecture != arch {
+			manifests = append(manifests, desc)
+		}
+	}
+	manifestDesc.Annotations = nil
+	manifestDesc.Platform = &ocispec.Platform{
+		Architecture: arch,
+		OS:           bundleOS,
+	}
+	index.Manifests = append(manifests, manifestDesc)
+}
+
+// selectFromIndex returns the manifest for arch from the index
+func selectFromIndex(index ocispec.Index, arch string) (ocispec.Descriptor, error) {
+	var available []string
+	for _, desc := range index.Manifests {
+		if desc.Platform == nil {
+			continue
+		}
+		if desc.Platform.Architecture == arch {
+			return desc, nil
+		}
+		available = append(available, desc.Platform.Architecture)
+	}
+	return ocispec.Descriptor{}, fmt.Errorf("bundle not available for architecture %s (available: %s), use --architecture to select another one", arch, strings.Join(available, ", "))
+}
@@ -0,0 +1,36 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Index(t *testing.T) {
+	amd64 := ocispec.Descriptor{MediaType: ocispec.MediaTypeImageManifest, Digest: digest.FromString("amd64"), Size: 1}
+	arm64 := ocispec.Descriptor{MediaType: ocispec.MediaTypeImageManifest, Digest: digest.FromString("arm64"), Size: 1}
+	rebuilt := ocispec.Descriptor{MediaType: ocispec.MediaTypeImageManifest, Digest: digest.FromString("amd64-rebuilt"), Size: 1}
+
+	var index ocispec.Index
+	addToIndex(&index, amd64, "amd64")
+	addToIndex(&index, arm64, "arm64")
+	require.Len(t, index.Manifests, 2)
+
+	// re-publishing an arch replaces its manifest
+	addToIndex(&index, rebuilt, "amd64")
+	require.Len(t, index.Manifests, 2)
+
+	desc, err := selectFromIndex(index, "amd64")
+	require.NoError(t, err)
+	require.Equal(t, rebuilt.Digest, desc.Digest)
+	require.Equal(t, "linux", desc.Platform.OS)
+
+	desc, err = selectFromIndex(index, "arm64")
+	require.NoError(t, err)
+	require.Equal(t, arm64.Digest, desc.Digest)
+
+	_, err = selectFromIndex(index, "s390x")
+	require.ErrorContains(t, err, "bundle not available for architecture s390x (available: arm64, amd64)")
+}
@@ -9,10 +9,12 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"slices"
 
 	"github.com/defenseunicorns/zarf/src/pkg/message"
 	"github.com/defenseunicorns/zarf/src/pkg/oci"
+	"github.com/opencontainers/go-digest"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"oras.land/oras-go/v2"
 	"oras.land/oras-go/v2/content"
@@ -53,12 +55,17 @@ func ToOCIRemote(t any, mediaType string, remote *oci.OrasRemote) (ocispec.Descr
 	var layerDesc ocispec.Descriptor
 	// if image manifest media type, push to Manifests(), otherwise normal pushLayer()
 	if mediaType == ocispec.MediaTypeImageManifest {
-		layerDesc := content.NewDescriptorFromBytes(ocispec.MediaTypeImageManifest, b)
-		if err := remote.Repo().Manifests().PushReference(context.TODO(), layerDesc, bytes.NewReader(b), remote.Repo().Reference.String()); err != nil {
+		layerDesc = content.NewDescriptorFromBytes(ocispec.MediaTypeImageManifest, b)
+		if err := RetryOCI("pushing manifest", func() error {
+			return remote.Repo().Manifests().PushReference(context.TODO(), layerDesc, bytes.NewReader(b), remote.Repo().Reference.String())
+		}); err != nil {
 			return ocispec.Descriptor{}, fmt.Errorf("failed to push manifest: %w", err)
 		}
 	} else {
-		layerDesc, err = remote.PushLayer(b, mediaType)
+		err = RetryOCI("pushing layer", func() error {
+			layerDesc, err = remote.PushLayer(b, mediaType)
+			return err
+		})
 		if err != nil {
 			return ocispec.Descriptor{}, err
 		}
@@ -113,3 +120,54 @@ func CreateCopyOpts(layersToPull []ocispec.Descriptor, concurrency int) oras.Cop
 	}
 	return copyOpts
 }
+
+// LayersByDigest returns the layers whose digest is in digests, keeping their order
+func LayersByDigest(layers []ocispec.Descriptor, digests []string) []ocispec.Descriptor {
+	var filtered []ocispec.Descriptor
+	for _, layer := range layers {
+		if slices.Contains(digests, layer.Digest.String()) {
+			filtered = append(filtered, layer)
+		}
+	}
+	return filtered
+}
+
+// VerifyRootDigest fetches the manifest that the remote's reference points to and verifies that its content matches
+// the digest the registry resolved it to, and the digest of the reference itself when pulling by digest
+func VerifyRootDigest(remote *oci.OrasRemote) (ocispec.Descriptor, error) {
+	repo := remote.Repo()
+	desc, rc, err := repo.Manifests().FetchReference(context.TODO(), repo.Reference.Reference)
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	defer rc.Close()
+	manifestBytes, err := io.ReadAll(io.LimitReader(rc, desc.Size+1))
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+
+	var requested digest.Digest
+	if refDigest, err := repo.Reference.Digest(); err == nil {
+		requested = refDigest
+	}
+	if err := verifyManifestDigest(manifestBytes, desc.Digest, requested); err != nil {
+		return ocispec.Descriptor{}, fmt.Errorf("unable to verify %s: %w", repo.Reference, err)
+	}
+	return desc, nil
+}
+
+// verifyManifestDigest checks the digest of a manifest's content against the digest it was resolved to and, when
+// not empty, the digest that was requested
+func verifyManifestDigest(manifestBytes []byte, resolved, requested digest.Digest) error {
+	if err := resolved.Validate(); err != nil {
+		return fmt.Errorf("invalid manifest digest %q: %w", resolved, err)
+	}
+	computed := resolved.Algorithm().FromBytes(manifestBytes)
+	if computed != resolved {
+		return fmt.Errorf("manifest content has digest %s but the registry resolved it to %s", computed, resolved)
+	}
+	if requested != "" && computed != requested {
+		return fmt.Errorf("manifest has digest %s but %s was requested", computed, requested)
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_verifyManifestDigest(t *testing.T) {
+	manifest := []byte(`{"schemaVersion":2}`)
+	manifestDigest := digest.FromBytes(manifest)
+	other := digest.FromString("other")
+
+	tests := []struct {
+		name      string
+		resolved  digest.Digest
+		requested digest.Digest
+		wantErr   string
+	}{
+		{
+			name:     "ByTag",
+			resolved: manifestDigest,
+		},
+		{
+			name:      "ByDigest",
+			resolved:  manifestDigest,
+			requested: manifestDigest,
+		},
+		{
+			name:     "ContentMismatch",
+			resolved: other,
+			wantErr:  "but the registry resolved it to " + other.String(),
+		},
+		{
+			name:      "RequestedMismatch",
+			resolved:  manifestDigest,
+			requested: other,
+			wantErr:   "but " + other.String() + " was requested",
+		},
+		{
+			name:     "InvalidDigest",
+			resolved: "sha256:nope",
+			wantErr:  "invalid manifest digest",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := verifyManifestDigest(manifest, tt.resolved, tt.requested)
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
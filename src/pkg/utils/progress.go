@@ -0,0 +1,105 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023-Present The UDS Authors
+
+// Package utils provides utility fns for UDS-CLI
+package utils
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/defenseunicorns/zarf/src/pkg/message"
+	zarfUtils "github.com/defenseunicorns/zarf/src/pkg/utils"
+)
+
+// PullProgress tracks the bytes of a pull that are written to a local dir, excluding the layers that are served from
+// the cache (which are copied into the dir before the pull starts and so don't go over the network)
+type PullProgress struct {
+	dir           string
+	initialBytes  int64
+	expectedTotal int64
+	cacheHits     int
+}
+
+// NewPullProgress creates a PullProgress for a pull of expectedTotal bytes into dir; it must be called once the
+// cached layers have been copied into dir
+func NewPullProgress(dir string, expectedTotal int64, cacheHits int) *PullProgress {
+	initialBytes, err := zarfUtils.GetDirSize(dir)
+	if err != nil {
+		message.Debugf("unable to get initial size of %s: %s", dir, err.Error())
+	}
+	return &PullProgress{
+		dir:           dir,
+		initialBytes:  initialBytes,
+		expectedTotal: expectedTotal,
+		cacheHits:     cacheHits,
+	}
+}
+
+// pulledBytes returns the number of bytes written to the dir since the pull started
+func (p *PullProgress) pulledBytes() (int64, error) {
+	currentBytes, err := zarfUtils.GetDirSize(p.dir)
+	if err != nil {
+		return 0, err
+	}
+	pulled := currentBytes - p.initialBytes
+	if pulled < 0 {
+		return 0, nil
+	}
+	if pulled > p.expectedTotal {
+		return p.expectedTotal, nil
+	}
+	return pulled, nil
+}
+
+// successText appends the number of cache hits (if any) to text
+func (p *PullProgress) successText(text string) string {
+	if p.cacheHits == 0 {
+		return text
+	}
+	return fmt.Sprintf("%s, %d layer(s) from cache", text, p.cacheHits)
+}
+
+// Render renders a progress bar until completeChan or errChan receive; if there is nothing to pull (ie. every layer
+// was served from the cache) it completes as soon as completeChan receives instead of rendering an empty bar
+func (p *PullProgress) Render(wg *sync.WaitGroup, completeChan chan int, errChan chan int, updateText string, successText string) {
+	defer wg.Done()
+
+	if p.expectedTotal <= 0 {
+		select {
+		case <-completeChan:
+			message.Successf("%s (%s)", successText, p.successText("nothing to pull"))
+		case <-errChan:
+		}
+		return
+	}
+
+	total := zarfUtils.ByteFormat(float64(p.expectedTotal), 2)
+	title := fmt.Sprintf("%s (%s of %s)", updateText, zarfUtils.ByteFormat(0, 2), total)
+	progressBar := message.NewProgressBar(p.expectedTotal, title)
+
+	for {
+		select {
+		case <-completeChan:
+			progressBar.Successf("%s (%s)", successText, p.successText(total))
+			return
+
+		case <-errChan:
+			progressBar.Stop()
+			return
+
+		default:
+			pulled, err := p.pulledBytes()
+			if err != nil {
+				message.Debugf("unable to get updated progress: %s", err.Error())
+				time.Sleep(200 * time.Millisecond)
+				continue
+			}
+
+			title := fmt.Sprintf("%s (%s of %s)", updateText, zarfUtils.ByteFormat(float64(pulled), 2), total)
+			progressBar.Update(pulled, title)
+			time.Sleep(200 * time.Millisecond)
+		}
+	}
+}
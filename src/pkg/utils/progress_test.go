@@ -0,0 +1,42 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_PullProgress(t *testing.T) {
+	dir := t.TempDir()
+	// a layer served from the cache before the pull starts
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "cached"), make([]byte, 100), 0600))
+
+	progress := NewPullProgress(dir, 50, 1)
+	pulled, err := progress.pulledBytes()
+	require.NoError(t, err)
+	require.Equal(t, int64(0), pulled)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "pulled"), make([]byte, 20), 0600))
+	pulled, err = progress.pulledBytes()
+	require.NoError(t, err)
+	require.Equal(t, int64(20), pulled)
+
+	require.Equal(t, "50 B, 1 layer(s) from cache", progress.successText("50 B"))
+	require.Equal(t, "50 B", NewPullProgress(dir, 50, 0).successText("50 B"))
+}
+
+func Test_PullProgressEverythingCached(t *testing.T) {
+	progress := NewPullProgress(t.TempDir(), 0, 3)
+
+	// with nothing to pull the progress completes as soon as the pull does
+	doneSaving := make(chan int)
+	errChan := make(chan int)
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go progress.Render(&wg, doneSaving, errChan, "Pulling", "Pulled")
+	doneSaving <- 1
+	wg.Wait()
+}
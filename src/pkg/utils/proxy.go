@@ -0,0 +1,75 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023-Present The UDS Authors
+
+// Package utils provides utility fns for UDS-CLI
+package utils
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/defenseunicorns/zarf/src/pkg/oci"
+	"golang.org/x/net/http/httpproxy"
+
+	"github.com/defenseunicorns/uds-cli/src/config"
+)
+
+// NewOrasRemote returns a Zarf oras remote whose connections go through the configured proxy, trust the configured CA
+// file and are authenticated with the configured registry credentials (falling back to the Docker config file)
+func NewOrasRemote(url string) (*oci.OrasRemote, error) {
+	remote, err := oci.NewOrasRemote(url)
+	if err != nil {
+		return nil, err
+	}
+	if err := WithProxy(remote, config.CommonOptions.Proxy); err != nil {
+		return nil, err
+	}
+	if err := WithCAFile(remote, config.CommonOptions.CAFile); err != nil {
+		return nil, err
+	}
+	creds := RegistryCredentials{
+		Username: config.CommonOptions.RegistryUsername,
+		Password: config.CommonOptions.RegistryPassword,
+		Token:    config.CommonOptions.RegistryToken,
+	}
+	if err := WithCredentials(remote, creds); err != nil {
+		return nil, err
+	}
+	return remote, nil
+}
+
+// WithProxy routes the connections of remote through proxyURL, or through the proxy set by the HTTPS_PROXY,
+// HTTP_PROXY and NO_PROXY env vars if proxyURL is empty
+func WithProxy(remote *oci.OrasRemote, proxyURL string) error {
+	proxy, err := ProxyFunc(proxyURL)
+	if err != nil {
+		return err
+	}
+	transport, ok := remote.Transport.Base.(*http.Transport)
+	if !ok {
+		return fmt.Errorf("unable to configure proxy for %s", remote.Repo().Reference)
+	}
+	transport.Proxy = proxy
+	return nil
+}
+
+// ProxyFunc returns a fn that selects the proxy of a request, for use as an http.Transport's Proxy
+//
+// Unlike http.ProxyFromEnvironment, the env vars are read every time this is called rather than once per process
+func ProxyFunc(proxyURL string) (func(*http.Request) (*url.URL, error), error) {
+	proxyConfig := httpproxy.FromEnvironment()
+	if proxyURL != "" {
+		parsed, err := url.Parse(proxyURL)
+		if err != nil || parsed.Host == "" {
+			return nil, fmt.Errorf("invalid proxy URL %q", proxyURL)
+		}
+		proxyConfig.HTTPProxy = proxyURL
+		proxyConfig.HTTPSProxy = proxyURL
+	}
+
+	proxy := proxyConfig.ProxyFunc()
+	return func(req *http.Request) (*url.URL, error) {
+		return proxy(req.URL)
+	}, nil
+}
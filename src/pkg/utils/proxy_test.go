@@ -0,0 +1,95 @@
+package utils
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	zarfConfig "github.com/defenseunicorns/zarf/src/config"
+	"github.com/stretchr/testify/require"
+
+	"github.com/defenseunicorns/uds-cli/src/config"
+)
+
+// stubProxy records the hosts it's asked to connect to and rejects every request
+type stubProxy struct {
+	mu    sync.Mutex
+	hosts []string
+}
+
+func (p *stubProxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	p.mu.Lock()
+	p.hosts = append(p.hosts, r.Host)
+	p.mu.Unlock()
+	w.WriteHeader(http.StatusNotFound)
+}
+
+func Test_NewOrasRemoteProxy(t *testing.T) {
+	tests := []struct {
+		name      string
+		proxy     string
+		env       map[string]string
+		insecure  bool
+		wantHosts []string
+	}{
+		{
+			name:      "proxy flag",
+			proxy:     "stub",
+			wantHosts: []string{"registry.invalid:443"},
+		},
+		{
+			name:      "proxy flag with plain HTTP",
+			proxy:     "stub",
+			insecure:  true,
+			wantHosts: []string{"registry.invalid"},
+		},
+		{
+			name:      "HTTPS_PROXY env var",
+			env:       map[string]string{"HTTPS_PROXY": "stub"},
+			wantHosts: []string{"registry.invalid:443"},
+		},
+		{
+			name: "NO_PROXY env var",
+			env:  map[string]string{"HTTPS_PROXY": "stub", "NO_PROXY": "registry.invalid"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stub := &stubProxy{}
+			server := httptest.NewServer(stub)
+			defer server.Close()
+
+			stubURL := func(s string) string {
+				if s == "stub" {
+					return server.URL
+				}
+				return s
+			}
+			for _, key := range []string{"HTTPS_PROXY", "HTTP_PROXY", "NO_PROXY"} {
+				t.Setenv(key, stubURL(tt.env[key]))
+			}
+			config.CommonOptions.Proxy = stubURL(tt.proxy)
+			zarfConfig.CommonOptions.Insecure = tt.insecure
+			defer func() {
+				config.CommonOptions.Proxy = ""
+				zarfConfig.CommonOptions.Insecure = false
+			}()
+
+			remote, err := NewOrasRemote("registry.invalid/bundle:0.0.1")
+			require.NoError(t, err)
+			_, err = remote.Repo().Resolve(context.TODO(), "0.0.1")
+			require.Error(t, err)
+
+			stub.mu.Lock()
+			defer stub.mu.Unlock()
+			require.Equal(t, tt.wantHosts, stub.hosts)
+		})
+	}
+}
+
+func Test_ProxyFuncInvalidURL(t *testing.T) {
+	_, err := ProxyFunc("not a proxy")
+	require.ErrorContains(t, err, "invalid proxy URL")
+}
@@ -0,0 +1,60 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023-Present The UDS Authors
+
+// Package utils provides utility fns for UDS-CLI
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+
+	"github.com/defenseunicorns/zarf/src/pkg/message"
+	"oras.land/oras-go/v2/registry/remote/errcode"
+
+	"github.com/defenseunicorns/uds-cli/src/config"
+)
+
+// ociRetryDelay is the delay before the first retry of a failed registry operation, doubling with each retry
+var ociRetryDelay = time.Second
+
+// RetryOCI runs op, retrying it (up to --oci-retries times) with exponential backoff when it fails with a transient
+// (5xx, 429 or connection) error, other errors such as a 404 or an auth failure are returned right away
+func RetryOCI(description string, op func() error) error {
+	retries := max(config.CommonOptions.OCIRetries, 0)
+	delay := ociRetryDelay
+	for retry := 0; ; retry++ {
+		err := op()
+		if err == nil || retry >= retries || !IsTransientOCIError(err) {
+			if err != nil && retry > 0 {
+				return fmt.Errorf("%s failed after %d retries: %w", description, retry, err)
+			}
+			return err
+		}
+		message.Warnf("%s failed, retrying in %s (%d/%d): %s", description, delay, retry+1, retries, err)
+		time.Sleep(delay)
+		delay *= 2
+	}
+}
+
+// IsTransientOCIError returns true if a registry operation failed with an error that may not happen again
+func IsTransientOCIError(err error) bool {
+	// a canceled or timed out operation was stopped on purpose (a context deadline is also a net.Error)
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var errResp *errcode.ErrorResponse
+	if errors.As(err, &errResp) {
+		return errResp.StatusCode >= http.StatusInternalServerError || errResp.StatusCode == http.StatusTooManyRequests
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+	return errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.EPIPE)
+}
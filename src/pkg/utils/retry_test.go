@@ -0,0 +1,92 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"oras.land/oras-go/v2/registry/remote/errcode"
+
+	"github.com/defenseunicorns/uds-cli/src/config"
+)
+
+func Test_RetryOCI(t *testing.T) {
+	ociRetryDelay = 0
+
+	tests := []struct {
+		name      string
+		retries   int
+		errs      []error
+		wantCalls int
+		wantErr   string
+	}{
+		{
+			name:      "Succeeds",
+			errs:      []error{nil},
+			wantCalls: 1,
+		},
+		{
+			name:      "RetriesServerErrors",
+			errs:      []error{&errcode.ErrorResponse{StatusCode: http.StatusBadGateway}, fmt.Errorf("push: %w", syscall.ECONNRESET), nil},
+			wantCalls: 3,
+		},
+		{
+			name:      "DoesNotRetryClientErrors",
+			errs:      []error{&errcode.ErrorResponse{StatusCode: http.StatusUnauthorized}},
+			wantCalls: 1,
+			wantErr:   "401",
+		},
+		{
+			name:      "DoesNotRetryNotFound",
+			errs:      []error{&errcode.ErrorResponse{StatusCode: http.StatusNotFound}},
+			wantCalls: 1,
+			wantErr:   "404",
+		},
+		{
+			name:      "DoesNotRetryCanceled",
+			errs:      []error{&url.Error{Op: "Get", URL: "https://registry", Err: context.DeadlineExceeded}},
+			wantCalls: 1,
+			wantErr:   "context deadline exceeded",
+		},
+		{
+			name:      "GivesUp",
+			errs:      []error{io.ErrUnexpectedEOF, io.ErrUnexpectedEOF, io.ErrUnexpectedEOF, io.ErrUnexpectedEOF},
+			wantCalls: config.DefaultOCIRetries + 1,
+			wantErr:   "pushing layer failed after 3 retries: unexpected EOF",
+		},
+		{
+			name:      "ConfiguredRetries",
+			retries:   1,
+			errs:      []error{io.ErrUnexpectedEOF, io.ErrUnexpectedEOF},
+			wantCalls: 2,
+			wantErr:   "pushing layer failed after 1 retries: unexpected EOF",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config.CommonOptions.OCIRetries = config.DefaultOCIRetries
+			if tt.retries != 0 {
+				config.CommonOptions.OCIRetries = tt.retries
+			}
+			calls := 0
+			err := RetryOCI("pushing layer", func() error {
+				err := tt.errs[calls]
+				calls++
+				return err
+			})
+			require.Equal(t, tt.wantCalls, calls)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+	require.False(t, IsTransientOCIError(errors.New("manifest unknown")))
+}
@@ -5,13 +5,27 @@
 package utils
 
 import (
+	"archive/tar"
+	"bytes"
 	"context"
-	"github.com/defenseunicorns/uds-cli/src/config"
-	"github.com/mholt/archiver/v4"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
 	"os"
+	"path"
 	"path/filepath"
+
+	"github.com/defenseunicorns/uds-cli/src/config"
+	"github.com/mholt/archiver/v4"
 )
 
+// PackageSBOMs is the sboms.tar of a Zarf package in a bundle
+type PackageSBOMs struct {
+	PkgName string
+	Tarball []byte
+}
+
 // CreateSBOMArtifact creates sbom artifacts in the form of a tar archive
 func CreateSBOMArtifact(SBOMArtifactPathMap map[string]string) error {
 	out, err := os.Create(config.BundleSBOMTar)
@@ -67,3 +81,89 @@ func SBOMExtractor(dst string, SBOMArtifactPathMap map[string]string) func(ctx c
 	}
 	return extractor
 }
+
+// MergeSBOMs merges the sboms.tar of each Zarf package into a single tar archive
+//
+// files shared between packages (e.g. the SBOM viewer's assets) are only included once, while
+// different files with the same name are prefixed with the name of the package they came from
+func MergeSBOMs(pkgSBOMs []PackageSBOMs) ([]byte, error) {
+	var merged bytes.Buffer
+	tw := tar.NewWriter(&merged)
+	sums := make(map[string][sha256.Size]byte)
+
+	for _, pkg := range pkgSBOMs {
+		tr := tar.NewReader(bytes.NewReader(pkg.Tarball))
+		for {
+			header, err := tr.Next()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				return nil, fmt.Errorf("unable to read the %s of package %s: %w", config.SBOMsTar, pkg.PkgName, err)
+			}
+			if header.Typeflag != tar.TypeReg {
+				continue
+			}
+			contents, err := io.ReadAll(tr)
+			if err != nil {
+				return nil, err
+			}
+
+			sum := sha256.Sum256(contents)
+			if existing, ok := sums[header.Name]; ok {
+				if existing == sum {
+					continue
+				}
+				header.Name = path.Join(path.Dir(header.Name), fmt.Sprintf("%s-%s", pkg.PkgName, path.Base(header.Name)))
+			}
+			sums[header.Name] = sum
+
+			if err := tw.WriteHeader(header); err != nil {
+				return nil, err
+			}
+			if _, err := tw.Write(contents); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	return merged.Bytes(), nil
+}
+
+// OutputBundleSBOM writes a bundle's aggregated SBOM to the current directory, either as is or extracted into a directory
+func OutputBundleSBOM(sbomTar []byte, extractSBOM bool) error {
+	if !extractSBOM {
+		return os.WriteFile(config.BundleSBOMTar, sbomTar, 0644)
+	}
+
+	tr := tar.NewReader(bytes.NewReader(sbomTar))
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		if !filepath.IsLocal(header.Name) {
+			return fmt.Errorf("invalid file %s in %s", header.Name, config.BundleSBOMTar)
+		}
+		dst := filepath.Join(config.BundleSBOM, header.Name)
+		if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+			return err
+		}
+		contents, err := io.ReadAll(tr)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(dst, contents, 0644); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package utils
+
+import (
+	"archive/tar"
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_MergeSBOMs(t *testing.T) {
+	sbomTar := func(files map[string]string) []byte {
+		var buf bytes.Buffer
+		tw := tar.NewWriter(&buf)
+		for name, contents := range files {
+			require.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(contents)), Typeflag: tar.TypeReg}))
+			_, err := tw.Write([]byte(contents))
+			require.NoError(t, err)
+		}
+		require.NoError(t, tw.Close())
+		return buf.Bytes()
+	}
+
+	merged, err := MergeSBOMs([]PackageSBOMs{
+		{PkgName: "foo", Tarball: sbomTar(map[string]string{"compare.html": "viewer", "zarf-component-app.json": "foo app"})},
+		{PkgName: "bar", Tarball: sbomTar(map[string]string{"compare.html": "viewer", "zarf-component-app.json": "bar app", "sbom-viewer-nginx.html": "nginx"})},
+	})
+	require.NoError(t, err)
+
+	files := make(map[string]string)
+	tr := tar.NewReader(bytes.NewReader(merged))
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		contents, err := io.ReadAll(tr)
+		require.NoError(t, err)
+		files[header.Name] = string(contents)
+	}
+	require.Equal(t, map[string]string{
+		"compare.html":                "viewer",
+		"zarf-component-app.json":     "foo app",
+		"bar-zarf-component-app.json": "bar app",
+		"sbom-viewer-nginx.html":      "nginx",
+	}, files)
+
+	_, err = MergeSBOMs([]PackageSBOMs{{PkgName: "invalid", Tarball: []byte("not a tarball")}})
+	require.ErrorContains(t, err, "unable to read the sboms.tar of package invalid")
+}
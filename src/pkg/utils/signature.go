@@ -0,0 +1,31 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023-Present The UDS Authors
+
+// Package utils provides utility fns for UDS-CLI
+package utils
+
+import (
+	"errors"
+
+	"github.com/defenseunicorns/uds-cli/src/types"
+)
+
+// CheckSignaturePolicy returns whether the signature of a bundle has to be verified with the policy's public key, given
+// whether the bundle is signed, or an error when the policy rejects the bundle
+func CheckSignaturePolicy(policy types.SignaturePolicy, signed bool) (bool, error) {
+	hasKey := policy.PublicKeyPath != ""
+	switch {
+	case policy.SkipSignatureValidation && hasKey:
+		return false, errors.New("a public key can't be provided when skipping signature validation")
+	case policy.SkipSignatureValidation && policy.StrictSignatureValidation:
+		return false, errors.New("signature validation can't be both skipped and strict")
+	case policy.SkipSignatureValidation:
+		return false, nil
+	case !signed && hasKey:
+		return false, errors.New("bundle is not signed, but a public key was provided")
+	case signed && !hasKey && policy.StrictSignatureValidation:
+		return false, errors.New("bundle is signed, but no public key was provided to validate its signature, " +
+			"pass --key to validate it or --skip-signature-validation to skip validating it")
+	}
+	return signed && hasKey, nil
+}
@@ -0,0 +1,44 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/defenseunicorns/uds-cli/src/types"
+)
+
+func Test_CheckSignaturePolicy(t *testing.T) {
+	key := "cosign.pub"
+	tests := []struct {
+		name       string
+		policy     types.SignaturePolicy
+		signed     bool
+		wantVerify bool
+		wantErr    string
+	}{
+		{name: "unsigned without a key"},
+		{name: "unsigned with a key", policy: types.SignaturePolicy{PublicKeyPath: key}, wantErr: "not signed"},
+		{name: "signed without a key", signed: true},
+		{name: "signed with a key", policy: types.SignaturePolicy{PublicKeyPath: key}, signed: true, wantVerify: true},
+		{name: "strict unsigned without a key", policy: types.SignaturePolicy{StrictSignatureValidation: true}},
+		{name: "strict unsigned with a key", policy: types.SignaturePolicy{PublicKeyPath: key, StrictSignatureValidation: true}, wantErr: "not signed"},
+		{name: "strict signed without a key", policy: types.SignaturePolicy{StrictSignatureValidation: true}, signed: true, wantErr: "no public key"},
+		{name: "strict signed with a key", policy: types.SignaturePolicy{PublicKeyPath: key, StrictSignatureValidation: true}, signed: true, wantVerify: true},
+		{name: "skipped unsigned", policy: types.SignaturePolicy{SkipSignatureValidation: true}},
+		{name: "skipped signed", policy: types.SignaturePolicy{SkipSignatureValidation: true}, signed: true},
+		{name: "skipped with a key", policy: types.SignaturePolicy{PublicKeyPath: key, SkipSignatureValidation: true}, signed: true, wantErr: "can't be provided"},
+		{name: "skipped and strict", policy: types.SignaturePolicy{SkipSignatureValidation: true, StrictSignatureValidation: true}, signed: true, wantErr: "both skipped and strict"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verify, err := CheckSignaturePolicy(tt.policy, tt.signed)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.wantVerify, verify)
+		})
+	}
+}
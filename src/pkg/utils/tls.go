@@ -0,0 +1,52 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023-Present The UDS Authors
+
+// Package utils provides utility fns for UDS-CLI
+package utils
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/defenseunicorns/zarf/src/pkg/oci"
+)
+
+// WithCAFile makes the connections of remote trust the CA certificates in the PEM file at caFile, in addition to the
+// system's. It does nothing if caFile is empty
+func WithCAFile(remote *oci.OrasRemote, caFile string) error {
+	if caFile == "" {
+		return nil
+	}
+	pool, err := CertPool(caFile)
+	if err != nil {
+		return err
+	}
+	transport, ok := remote.Transport.Base.(*http.Transport)
+	if !ok {
+		return fmt.Errorf("unable to configure CA file for %s", remote.Repo().Reference)
+	}
+	if transport.TLSClientConfig == nil {
+		transport.TLSClientConfig = &tls.Config{}
+	}
+	transport.TLSClientConfig.RootCAs = pool
+	return nil
+}
+
+// CertPool returns the system's cert pool with the CA certificates in the PEM file at caFile added to it
+func CertPool(caFile string) (*x509.CertPool, error) {
+	pem, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read CA file: %w", err)
+	}
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no PEM certificates found in CA file %s", caFile)
+	}
+	return pool, nil
+}
@@ -0,0 +1,70 @@
+package utils
+
+import (
+	"context"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/require"
+
+	"github.com/defenseunicorns/uds-cli/src/config"
+)
+
+// newTLSRegistry starts a registry serving a single manifest at bundle:0.0.1 with a self-signed certificate, and
+// returns its host and the path of a PEM file of its certificate
+func newTLSRegistry(t *testing.T) (string, string) {
+	manifest := []byte(`{"schemaVersion":2,"mediaType":"application/vnd.oci.image.manifest.v1+json","config":{"mediaType":"application/vnd.oci.image.config.v1+json","digest":"sha256:44136fa355b3678a1146ad16f7e8649e94fb4fc21fe77e8310c060f61caaff8a","size":2},"layers":[]}`)
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/bundle/manifests/0.0.1" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", ocispec.MediaTypeImageManifest)
+		w.Header().Set("Docker-Content-Digest", digest.FromBytes(manifest).String())
+		w.Header().Set("Content-Length", strconv.Itoa(len(manifest)))
+		if r.Method == http.MethodGet {
+			_, _ = w.Write(manifest)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	require.NoError(t, os.WriteFile(caFile, certPEM, 0600))
+	return strings.TrimPrefix(server.URL, "https://"), caFile
+}
+
+func Test_NewOrasRemoteCAFile(t *testing.T) {
+	host, caFile := newTLSRegistry(t)
+	defer func() { config.CommonOptions.CAFile = "" }()
+
+	remote, err := NewOrasRemote(host + "/bundle:0.0.1")
+	require.NoError(t, err)
+	_, err = remote.Repo().Resolve(context.TODO(), "0.0.1")
+	require.ErrorContains(t, err, "certificate")
+
+	config.CommonOptions.CAFile = caFile
+	remote, err = NewOrasRemote(host + "/bundle:0.0.1")
+	require.NoError(t, err)
+	desc, err := remote.Repo().Resolve(context.TODO(), "0.0.1")
+	require.NoError(t, err)
+	require.Equal(t, ocispec.MediaTypeImageManifest, desc.MediaType)
+}
+
+func Test_CertPool(t *testing.T) {
+	_, err := CertPool(filepath.Join(t.TempDir(), "missing.pem"))
+	require.ErrorContains(t, err, "unable to read CA file")
+
+	notPEM := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, os.WriteFile(notPEM, []byte("not a certificate"), 0600))
+	_, err = CertPool(notPEM)
+	require.ErrorContains(t, err, "no PEM certificates found")
+}
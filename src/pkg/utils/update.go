@@ -0,0 +1,125 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023-Present The UDS Authors
+
+// Package utils provides utility fns for UDS-CLI
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/defenseunicorns/zarf/src/pkg/message"
+
+	"github.com/defenseunicorns/uds-cli/src/config"
+)
+
+const (
+	// updateCheckTimeout bounds the release lookup so that it never hangs a command
+	updateCheckTimeout = 3 * time.Second
+	// updateCheckInterval is how long the result of a release lookup is reused for
+	updateCheckInterval = 24 * time.Hour
+	// updateCheckFile is the name of the file in the UDS cache that holds the result of the last release lookup
+	updateCheckFile = "update-check.json"
+)
+
+// latestReleaseURL is the GitHub API endpoint of the latest UDS-CLI release
+var latestReleaseURL = "https://api.github.com/repos/defenseunicorns/uds-cli/releases/latest"
+
+// updateCheck is the cached result of a release lookup
+type updateCheck struct {
+	CheckedAt time.Time `json:"checkedAt"`
+	Latest    string    `json:"latest"`
+}
+
+// CheckForUpdate returns the latest UDS-CLI release if it's newer than current, or "" if current is up to date, isn't a
+// release version or the lookup failed; lookups are cached for 24h
+func CheckForUpdate(ctx context.Context, current string) string {
+	currentVersion, err := semver.NewVersion(current)
+	if err != nil {
+		message.Debugf("skipping update check, %q is not a release version", current)
+		return ""
+	}
+
+	cachePath := filepath.Join(config.CommonOptions.CachePath, updateCheckFile)
+	check, err := readUpdateCheck(cachePath)
+	if err != nil || time.Since(check.CheckedAt) > updateCheckInterval {
+		latest, err := fetchLatestRelease(ctx)
+		if err != nil {
+			message.Debugf("unable to check for a newer UDS-CLI release: %s", err.Error())
+			return ""
+		}
+		check = updateCheck{CheckedAt: time.Now(), Latest: latest}
+		if err := writeUpdateCheck(cachePath, check); err != nil {
+			message.Debugf("unable to cache the UDS-CLI update check: %s", err.Error())
+		}
+	}
+
+	latestVersion, err := semver.NewVersion(check.Latest)
+	if err != nil || !latestVersion.GreaterThan(currentVersion) {
+		return ""
+	}
+	return check.Latest
+}
+
+// fetchLatestRelease returns the tag of the latest UDS-CLI release
+func fetchLatestRelease(ctx context.Context) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, updateCheckTimeout)
+	defer cancel()
+
+	proxy, err := ProxyFunc(config.CommonOptions.Proxy)
+	if err != nil {
+		return "", err
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = proxy
+	client := &http.Client{Transport: transport}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, latestReleaseURL, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected response from %s: %s", latestReleaseURL, resp.Status)
+	}
+
+	var release struct {
+		TagName string `json:"tag_name"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
+		return "", err
+	}
+	return release.TagName, nil
+}
+
+func readUpdateCheck(path string) (updateCheck, error) {
+	var check updateCheck
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return check, err
+	}
+	err = json.Unmarshal(b, &check)
+	return check, err
+}
+
+func writeUpdateCheck(path string, check updateCheck) error {
+	b, err := json.Marshal(check)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, b, 0644)
+}
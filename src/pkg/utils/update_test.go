@@ -0,0 +1,65 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/defenseunicorns/uds-cli/src/config"
+)
+
+func Test_CheckForUpdate(t *testing.T) {
+	var requests atomic.Int32
+	latest := "v0.5.0"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		fmt.Fprintf(w, `{"tag_name": %q}`, latest)
+	}))
+	defer server.Close()
+
+	originalURL := latestReleaseURL
+	latestReleaseURL = server.URL
+	defer func() { latestReleaseURL = originalURL }()
+	config.CommonOptions.CachePath = t.TempDir()
+
+	ctx := context.TODO()
+	require.Equal(t, "v0.5.0", CheckForUpdate(ctx, "v0.4.1"))
+	require.Equal(t, int32(1), requests.Load())
+
+	// the cached result is reused within 24h
+	latest = "v0.6.0"
+	require.Equal(t, "", CheckForUpdate(ctx, "v0.5.0"))
+	require.Equal(t, int32(1), requests.Load())
+
+	// and refreshed once it's stale
+	cachePath := filepath.Join(config.CommonOptions.CachePath, updateCheckFile)
+	require.NoError(t, writeUpdateCheck(cachePath, updateCheck{CheckedAt: time.Now().Add(-25 * time.Hour), Latest: "v0.5.0"}))
+	require.Equal(t, "v0.6.0", CheckForUpdate(ctx, "v0.5.0"))
+	require.Equal(t, int32(2), requests.Load())
+
+	// dev builds are never checked
+	require.Equal(t, "", CheckForUpdate(ctx, "unset"))
+	require.Equal(t, int32(2), requests.Load())
+}
+
+func Test_CheckForUpdateUnreachable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.Close()
+
+	originalURL := latestReleaseURL
+	latestReleaseURL = server.URL
+	defer func() { latestReleaseURL = originalURL }()
+	config.CommonOptions.CachePath = t.TempDir()
+
+	require.Equal(t, "", CheckForUpdate(context.TODO(), "v0.4.1"))
+	_, err := os.Stat(filepath.Join(config.CommonOptions.CachePath, updateCheckFile))
+	require.True(t, os.IsNotExist(err))
+}
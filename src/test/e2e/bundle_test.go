@@ -21,6 +21,7 @@ import (
 	"oras.land/oras-go/v2/registry"
 
 	"github.com/defenseunicorns/uds-cli/src/config"
+	"github.com/defenseunicorns/uds-cli/src/pkg/bundle"
 )
 
 func zarfPublish(t *testing.T, path string, reg string) {
@@ -104,12 +105,51 @@ func TestBundleWithLocalAndRemotePkgs(t *testing.T) {
 	}
 	createSecure(t, bundleDir)
 	inspect(t, bundlePath)
+
+	// --size-only reports the bundle's size without pushing it
+	cmd := strings.Split(fmt.Sprintf("publish %s oci://localhost:888 --insecure --size-only", bundlePath), " ")
+	_, stderr, err := e2e.UDS(cmd...)
+	require.NoError(t, err)
+	require.Contains(t, stderr, "Bundle size:")
+	_, _, err = e2e.UDS("inspect", fmt.Sprintf("oci://%s", bundleRef.String()), "--insecure")
+	require.Error(t, err)
+
 	publish(t, bundlePath, "localhost:888")
 	pull(t, bundleRef.String(), tarballPath)
 	deploy(t, tarballPath)
 	remove(t, tarballPath)
 }
 
+func TestCreateRemoteWithLocalPkgs(t *testing.T) {
+	zarfPkgPath1 := "src/test/packages/no-cluster/output-var"
+	zarfPkgPath2 := "src/test/packages/no-cluster/receive-var"
+	e2e.CreateZarfPkg(t, zarfPkgPath1)
+	e2e.CreateZarfPkg(t, zarfPkgPath2)
+
+	e2e.SetupDockerRegistry(t, 888)
+	defer e2e.TeardownRegistry(t, 888)
+
+	pkg := filepath.Join(zarfPkgPath1, fmt.Sprintf("zarf-package-output-var-%s-0.0.1.tar.zst", e2e.Arch))
+	zarfPublish(t, pkg, "localhost:888")
+
+	// the local receive-var pkg is pushed from its tarball straight into the bundle
+	bundleDir := "src/test/bundles/08-local-pkg-to-oci"
+	bundleRef := registry.Reference{
+		Registry:   "localhost:888",
+		Repository: "local-pkg-to-oci",
+		Reference:  fmt.Sprintf("0.0.1-%s", e2e.Arch),
+	}
+	createRemote(t, bundleDir, "localhost:888")
+
+	// creating the bundle again only pushes what is missing
+	createRemote(t, bundleDir, "localhost:888")
+
+	cmd := strings.Split(fmt.Sprintf("deploy oci://%s --insecure --confirm -l=debug", bundleRef.String()), " ")
+	_, stderr, err := e2e.UDS(cmd...)
+	require.NoError(t, err)
+	require.Contains(t, stderr, "This fun-fact was imported: Unicorns are the national animal of Scotland")
+}
+
 func TestBundleDeployFromOCIFromGHCR(t *testing.T) {
 	deployZarfInit(t)
 	e2e.CreateZarfPkg(t, "src/test/packages/podinfo")
@@ -158,6 +198,7 @@ func TestBundle(t *testing.T) {
 	create(t, bundleDir) // todo: allow creating from both the folder containing and direct reference to uds-bundle.yaml
 	inspect(t, bundlePath)
 	inspectAndSBOMExtract(t, bundlePath)
+	inspectJSON(t, bundlePath)
 	deploy(t, bundlePath)
 	remove(t, bundlePath)
 }
@@ -191,6 +232,17 @@ func TestRemoteBundle(t *testing.T) {
 	inspectRemote(t, bundleRef.String())
 	inspectRemoteAndSBOMExtract(t, bundleRef.String())
 	deployAndRemoveRemote(t, bundleRef.String(), tarballPath)
+
+	// the version tag is a multi-arch index that resolves to the bundle for the CLI's architecture
+	indexRef := fmt.Sprintf("%s/%s:0.0.1", bundleRef.Registry, bundleRef.Repository)
+	inspectRemote(t, indexRef)
+	otherArch := "arm64"
+	if e2e.Arch == "arm64" {
+		otherArch = "amd64"
+	}
+	_, stderr, err := e2e.UDS("inspect", "oci://"+indexRef, "--insecure", "-a", otherArch)
+	require.Error(t, err)
+	require.Contains(t, stderr, fmt.Sprintf("bundle not available for architecture %s (available: %s)", otherArch, e2e.Arch))
 }
 
 func TestBundleWithGitRepo(t *testing.T) {
@@ -287,6 +339,23 @@ func inspectAndSBOMExtract(t *testing.T, tarballPath string) {
 	require.NoError(t, err)
 }
 
+func inspectJSON(t *testing.T, tarballPath string) {
+	cmd := strings.Split(fmt.Sprintf("inspect %s -o json --no-build-data", tarballPath), " ")
+	stdout, _, err := e2e.UDS(cmd...)
+	require.NoError(t, err)
+
+	var report bundle.InspectReport
+	require.NoError(t, json.Unmarshal([]byte(stdout), &report))
+	require.Equal(t, "example", report.Bundle.Metadata.Name)
+	require.Empty(t, report.Bundle.Build.User)
+	require.Len(t, report.Packages, 2)
+	for _, pkg := range report.Packages {
+		require.Contains(t, pkg.Ref, "@sha256:")
+		require.Equal(t, e2e.Arch, pkg.Architecture)
+	}
+	require.Equal(t, ocispec.MediaTypeImageManifest, report.Manifest.MediaType)
+}
+
 func deploy(t *testing.T, tarballPath string) (stdout string, stderr string) {
 	cmd := strings.Split(fmt.Sprintf("deploy %s --confirm -l=debug", tarballPath), " ")
 	stdout, stderr, err := e2e.UDS(cmd...)
@@ -5,21 +5,30 @@
 package test
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
+	"runtime"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/defenseunicorns/uds-cli/src/pkg/runner"
 )
 
 func TestUseCLI(t *testing.T) {
 	t.Log("E2E: Use CLI")
 
+	// tasks without a dir run in the directory of the tasks file, so the files they read and write are relative to it
+	tasksDir := filepath.Join("src", "test", "tasks")
+
 	t.Run("run copy", func(t *testing.T) {
 		t.Parallel()
 
-		baseFilePath := "base"
-		copiedFilePath := "copy"
+		baseFilePath := filepath.Join(tasksDir, "base")
+		copiedFilePath := filepath.Join(tasksDir, "copy")
 
 		e2e.CleanFiles(baseFilePath, copiedFilePath)
 		t.Cleanup(func() {
@@ -38,8 +47,8 @@ func TestUseCLI(t *testing.T) {
 	t.Run("run copy-exec", func(t *testing.T) {
 		t.Parallel()
 
-		baseFilePath := "exectest"
-		copiedFilePath := "exec"
+		baseFilePath := filepath.Join(tasksDir, "exectest")
+		copiedFilePath := filepath.Join(tasksDir, "exec")
 
 		e2e.CleanFiles(baseFilePath, copiedFilePath)
 		t.Cleanup(func() {
@@ -61,8 +70,8 @@ func TestUseCLI(t *testing.T) {
 	t.Run("run copy-verify", func(t *testing.T) {
 		t.Parallel()
 
-		baseFilePath := "data"
-		copiedFilePath := "verify"
+		baseFilePath := filepath.Join(tasksDir, "data")
+		copiedFilePath := filepath.Join(tasksDir, "verify")
 
 		e2e.CleanFiles(baseFilePath, copiedFilePath)
 		t.Cleanup(func() {
@@ -81,9 +90,9 @@ func TestUseCLI(t *testing.T) {
 	t.Run("run copy-symlink", func(t *testing.T) {
 		t.Parallel()
 
-		baseFilePath := "symtest"
-		copiedFilePath := "symcopy"
-		symlinkName := "testlink"
+		baseFilePath := filepath.Join(tasksDir, "symtest")
+		copiedFilePath := filepath.Join(tasksDir, "symcopy")
+		symlinkName := filepath.Join(tasksDir, "testlink")
 
 		e2e.CleanFiles(baseFilePath, copiedFilePath, symlinkName)
 		t.Cleanup(func() {
@@ -102,7 +111,7 @@ func TestUseCLI(t *testing.T) {
 	t.Run("run local-import-with-curl", func(t *testing.T) {
 		t.Parallel()
 
-		downloadedFile := "checksums.txt"
+		downloadedFile := filepath.Join(tasksDir, "checksums.txt")
 
 		e2e.CleanFiles(downloadedFile)
 		t.Cleanup(func() {
@@ -123,8 +132,8 @@ func TestUseCLI(t *testing.T) {
 	t.Run("run template-file", func(t *testing.T) {
 		t.Parallel()
 
-		baseFilePath := "raw"
-		copiedFilePath := "templated"
+		baseFilePath := filepath.Join(tasksDir, "raw")
+		copiedFilePath := filepath.Join(tasksDir, "templated")
 
 		e2e.CleanFiles(baseFilePath, copiedFilePath)
 		t.Cleanup(func() {
@@ -157,7 +166,9 @@ func TestUseCLI(t *testing.T) {
 
 		stdOut, stdErr, err := e2e.RunTasksWithFile("run", "cmd-set-variable")
 		require.NoError(t, err, stdOut, stdErr)
-		require.Contains(t, stdErr, "I'm set from setVariables - unique-value")
+		// ACTION_VAR is sensitive, so its value is masked in the output
+		require.Contains(t, stdErr, "I'm set from setVariables - ****")
+		require.NotContains(t, stdErr, "I'm set from setVariables - unique-value")
 		require.Contains(t, stdErr, "I'm set from a runner var - replaced")
 	})
 
@@ -218,4 +229,285 @@ func TestUseCLI(t *testing.T) {
 		require.Error(t, err, stdOut, stdErr)
 		require.Contains(t, stdErr, "task loop detected")
 	})
+
+	t.Run("run parallel", func(t *testing.T) {
+		t.Parallel()
+		stdOut, stdErr, err := e2e.RunTasksWithFile("run", "parallel")
+		require.NoError(t, err, stdOut, stdErr)
+		require.Contains(t, stdErr, "first parallel action")
+		require.Contains(t, stdErr, "second parallel action")
+		require.Contains(t, stdErr, "third parallel action")
+	})
+
+	t.Run("run parallel-fail", func(t *testing.T) {
+		t.Parallel()
+		stdOut, stdErr, err := e2e.RunTasksWithFile("run", "parallel-fail")
+		require.Error(t, err, stdOut, stdErr)
+		require.Contains(t, stdErr, "slow parallel action")
+	})
+
+	t.Run("run depends-on", func(t *testing.T) {
+		t.Parallel()
+		// the shared dependency appends a line to this file each time it runs
+		setupLogPath := filepath.Join(tasksDir, "depends-on-setup.log")
+		e2e.CleanFiles(setupLogPath)
+		t.Cleanup(func() {
+			e2e.CleanFiles(setupLogPath)
+		})
+
+		stdOut, stdErr, err := e2e.RunTasksWithFile("run", "depends-on")
+		require.NoError(t, err, stdOut, stdErr)
+		setupLog, err := os.ReadFile(setupLogPath)
+		require.NoError(t, err)
+		require.Equal(t, "running the shared setup dependency\n", string(setupLog))
+		require.Contains(t, stdErr, "running the build dependency")
+		require.Contains(t, stdErr, "running the test dependency")
+		require.Contains(t, stdErr, "running the target task")
+	})
+
+	t.Run("run depends-on-cycle", func(t *testing.T) {
+		t.Parallel()
+		stdOut, stdErr, err := e2e.RunTasksWithFile("run", "depends-on-cycle")
+		require.Error(t, err, stdOut, stdErr)
+		require.Contains(t, stdErr, "task dependency cycle detected")
+	})
+
+	t.Run("run conditional", func(t *testing.T) {
+		t.Parallel()
+		stdOut, stdErr, err := e2e.RunTasksWithFile("run", "conditional")
+		require.NoError(t, err, stdOut, stdErr)
+		require.Contains(t, stdErr, "running in prod")
+		require.Contains(t, stdErr, "not running in dev")
+		require.NotContains(t, stdErr, "Completed \"echo \"running in dev\"\"")
+		require.Contains(t, stdErr, "Skipped \"echo \"unset variables are false\"\" (condition false)")
+	})
+
+	t.Run("run for-each", func(t *testing.T) {
+		t.Parallel()
+		stdOut, stdErr, err := e2e.RunTasksWithFile("run", "for-each")
+		// the failed item is non-fatal, but still fails the run once it completes
+		require.Error(t, err, stdOut, stdErr)
+		require.Contains(t, stdErr, "namespace alpha at index 0")
+		require.Contains(t, stdErr, "namespace bravo at index 1")
+		require.Contains(t, stdErr, "1 of 2 forEach items failed: bad")
+	})
+
+	t.Run("run for-each-fail", func(t *testing.T) {
+		t.Parallel()
+		stdOut, stdErr, err := e2e.RunTasksWithFile("run", "for-each-fail")
+		require.Error(t, err, stdOut, stdErr)
+		require.Contains(t, stdErr, "forEach item \"bad\" failed")
+		require.NotContains(t, stdErr, "should not run")
+	})
+
+	t.Run("run env-file", func(t *testing.T) {
+		t.Parallel()
+		stdOut, stdErr, err := e2e.RunTasksWithFile("run", "env-file")
+		require.NoError(t, err, stdOut, stdErr)
+		require.Contains(t, stdErr, "loaded-from-env-file")
+		require.Contains(t, stdErr, "replace me is overridden-by-env-file")
+	})
+
+	t.Run("run env-file with --set", func(t *testing.T) {
+		t.Parallel()
+		stdOut, stdErr, err := e2e.RunTasksWithFile("run", "env-file", "--set", "REPLACE_ME=from-set")
+		require.NoError(t, err, stdOut, stdErr)
+		require.Contains(t, stdErr, "replace me is from-set")
+	})
+
+	t.Run("run env-file-missing", func(t *testing.T) {
+		t.Parallel()
+		stdOut, stdErr, err := e2e.RunTasksWithFile("run", "env-file-missing")
+		require.Error(t, err, stdOut, stdErr)
+		require.Contains(t, stdErr, "unable to read env file")
+		require.NotContains(t, stdErr, "should not run")
+	})
+
+	t.Run("run --list", func(t *testing.T) {
+		t.Parallel()
+		stdOut, stdErr, err := e2e.RunTasksWithFile("run", "--list")
+		require.NoError(t, err, stdOut, stdErr)
+		require.Contains(t, stdErr, "a task shown by --list")
+		require.NotContains(t, stdErr, "list-internal")
+	})
+
+	t.Run("run --list-all -o json", func(t *testing.T) {
+		t.Parallel()
+		stdOut, stdErr, err := e2e.RunTasksWithFile("run", "--list-all", "-o", "json")
+		require.NoError(t, err, stdOut, stdErr)
+		var tasks []runner.TaskSummary
+		require.NoError(t, json.Unmarshal([]byte(stdOut), &tasks))
+		require.Contains(t, tasks, runner.TaskSummary{Name: "list-public", Description: "a task shown by --list"})
+		require.Contains(t, tasks, runner.TaskSummary{Name: "list-internal", Description: "a task only shown by --list-all"})
+	})
+
+	t.Run("run required-variable", func(t *testing.T) {
+		t.Parallel()
+		stdOut, stdErr, err := e2e.UDS("run", "required-variable", "--file", "src/test/tasks/required-variables.yaml")
+		require.Error(t, err, stdOut, stdErr)
+		require.Contains(t, stdErr, "missing values for required variables REQUIRED_VAR")
+	})
+
+	t.Run("run required-variable with --set", func(t *testing.T) {
+		t.Parallel()
+		stdOut, stdErr, err := e2e.UDS("run", "required-variable", "--file", "src/test/tasks/required-variables.yaml", "--set", "REQUIRED_VAR=provided")
+		require.NoError(t, err, stdOut, stdErr)
+		require.Contains(t, stdErr, "required is provided")
+	})
+
+	t.Run("run include-cycle", func(t *testing.T) {
+		t.Parallel()
+		stdOut, stdErr, err := e2e.UDS("run", "include-cycle", "--file", "src/test/tasks/include-cycle.yaml")
+		require.Error(t, err, stdOut, stdErr)
+		require.Contains(t, stdErr, "include cycle detected")
+		require.NotContains(t, stdErr, "should not run")
+	})
+
+	t.Run("run include-conflict", func(t *testing.T) {
+		t.Parallel()
+		stdOut, stdErr, err := e2e.UDS("run", "include-conflict", "--file", "src/test/tasks/include-conflict.yaml")
+		require.Error(t, err, stdOut, stdErr)
+		require.Contains(t, stdErr, "include key common refers to both")
+	})
+
+	t.Run("run dir", func(t *testing.T) {
+		t.Parallel()
+		stdOut, stdErr, err := e2e.RunTasksWithFile("run", "dir")
+		require.NoError(t, err, stdOut, stdErr)
+		require.Contains(t, stdErr, "ENV_FILE_VAR=loaded-from-env-file")
+		require.Regexp(t, "task dir is .*/src/test/tasks\n", stdErr)
+		require.Regexp(t, "action dir is .*/src/test/bundles\n", stdErr)
+	})
+
+	t.Run("run set-variable-json", func(t *testing.T) {
+		t.Parallel()
+		stdOut, stdErr, err := e2e.RunTasksWithFile("run", "set-variable-json")
+		require.NoError(t, err, stdOut, stdErr)
+		require.Contains(t, stdErr, "deployment podinfo ready true")
+	})
+
+	t.Run("run set-variable-json-invalid", func(t *testing.T) {
+		t.Parallel()
+		stdOut, stdErr, err := e2e.RunTasksWithFile("run", "set-variable-json-invalid")
+		require.Error(t, err, stdOut, stdErr)
+		require.Contains(t, stdErr, "command output is not valid JSON")
+	})
+
+	t.Run("run retry-delay", func(t *testing.T) {
+		t.Parallel()
+		start := time.Now()
+		stdOut, stdErr, err := e2e.RunTasksWithFile("run", "retry-delay")
+		require.Error(t, err, stdOut, stdErr)
+		// 1s then 2s of exponential backoff between the 3 attempts
+		require.GreaterOrEqual(t, time.Since(start), 3*time.Second)
+		require.Contains(t, stdErr, "Retry 2/2 of \"exit 1\" in 2s")
+	})
+
+	t.Run("run sensitive", func(t *testing.T) {
+		t.Parallel()
+		stdOut, stdErr, err := e2e.RunTasksWithFile("run", "sensitive", "--log-level", "debug")
+		require.NoError(t, err, stdOut, stdErr)
+		require.Contains(t, stdErr, "the password is ****")
+		require.Contains(t, stdErr, "print ****")
+		require.NotContains(t, stdErr, "the password is hunter2")
+		require.NotContains(t, stdErr, "print hunter2")
+	})
+
+	t.Run("run sensitive-set", func(t *testing.T) {
+		t.Parallel()
+		stdOut, stdErr, err := e2e.RunTasksWithFile("run", "sensitive-set", "--set", "API_TOKEN=s3cr3t-token")
+		require.NoError(t, err, stdOut, stdErr)
+		require.Contains(t, stdErr, "the token is ****")
+		require.NotContains(t, stdErr, "s3cr3t-token")
+	})
+
+	t.Run("run wait-file", func(t *testing.T) {
+		t.Parallel()
+		stdOut, stdErr, err := e2e.RunTasksWithFile("run", "wait-file")
+		require.NoError(t, err, stdOut, stdErr)
+		require.Contains(t, stdErr, "Wait for \"wait-file-marker to be created\" succeeded")
+		require.Contains(t, stdErr, "Wait for \"test -f wait-file-marker to succeed\" succeeded")
+		require.Contains(t, stdErr, "Wait for \"wait-file-marker to be deleted\" succeeded")
+	})
+
+	t.Run("run wait-timeout", func(t *testing.T) {
+		t.Parallel()
+		stdOut, stdErr, err := e2e.RunTasksWithFile("run", "wait-timeout")
+		require.Error(t, err, stdOut, stdErr)
+		require.Contains(t, stdErr, "command \"a command that never succeeds\" timed out after 2 seconds")
+	})
+
+	t.Run("run --dry-run", func(t *testing.T) {
+		t.Parallel()
+		stdOut, stdErr, err := e2e.RunTasksWithFile("run", "set-variable-json", "--dry-run")
+		require.NoError(t, err, stdOut, stdErr)
+		require.Contains(t, stdErr, "Dry run of task set-variable-json")
+		require.Contains(t, stdErr, "2. set variable DEPLOYMENT_NAME from the output of the command")
+		require.Contains(t, stdErr, "4. run: echo \"deployment <DEPLOYMENT_NAME> ready <REPLICA_READY>\"")
+		require.NotContains(t, stdErr, "deployment podinfo")
+	})
+
+	t.Run("run attempt-timeout", func(t *testing.T) {
+		t.Parallel()
+		start := time.Now()
+		stdOut, stdErr, err := e2e.RunTasksWithFile("run", "attempt-timeout")
+		require.Error(t, err, stdOut, stdErr)
+		// 3 attempts of 1s each, well within maxTotalSeconds
+		require.Less(t, time.Since(start), 10*time.Second)
+		require.Contains(t, stdErr, "failed after 2 retries")
+	})
+
+	t.Run("run continue-on-error", func(t *testing.T) {
+		t.Parallel()
+		stdOut, stdErr, err := e2e.RunTasksWithFile("run", "continue-on-error")
+		require.Error(t, err, stdOut, stdErr)
+		require.Contains(t, stdErr, "\"best-effort teardown\" failed, continuing")
+		require.Contains(t, stdErr, "still running after a non-fatal failure")
+		require.Contains(t, stdErr, "1 action(s) with continueOnError failed: best-effort teardown")
+	})
+
+	t.Run("run default task", func(t *testing.T) {
+		t.Parallel()
+		stdOut, stdErr, err := e2e.RunTasksWithFile("run")
+		require.NoError(t, err, stdOut, stdErr)
+		require.Contains(t, stdErr, "Completed \"echo \"public\"\"")
+	})
+
+	t.Run("complete task names", func(t *testing.T) {
+		t.Parallel()
+		stdOut, stdErr, err := e2e.UDS("__complete", "run", "--file", "src/test/tasks/tasks.yaml", "")
+		require.NoError(t, err, stdOut, stdErr)
+		require.Contains(t, stdOut, "copy-exec\n")
+		require.NotContains(t, stdOut, "list-internal")
+
+		stdOut, stdErr, err = e2e.UDS("completion", "bash")
+		require.NoError(t, err, stdOut, stdErr)
+		require.Contains(t, stdOut, "bash completion V2 for uds")
+	})
+
+	t.Run("run without a default task", func(t *testing.T) {
+		t.Parallel()
+		stdOut, stdErr, err := e2e.UDS("run", "--file", "src/test/tasks/required-variables.yaml")
+		require.Error(t, err, stdOut, stdErr)
+		require.Contains(t, stdErr, "the task file has no default task")
+		require.Contains(t, stdErr, "required-variable")
+	})
+
+	t.Run("run set-variable-pattern", func(t *testing.T) {
+		t.Parallel()
+		stdOut, stdErr, err := e2e.RunTasksWithFile("run", "set-variable-pattern")
+		require.Error(t, err, stdOut, stdErr)
+		// the error is wrapped at the width of the terminal, so check the parts of it that stay on one line
+		require.Contains(t, stdErr, "value \"latest\" of variable")
+		require.Contains(t, stdErr, "VERSION does not match the pattern \"^v[0-9]+$\"")
+		require.NotContains(t, stdErr, "should not run")
+	})
+
+	t.Run("run builtin-variables", func(t *testing.T) {
+		t.Parallel()
+		stdOut, stdErr, err := e2e.RunTasksWithFile("run", "builtin-variables")
+		require.NoError(t, err, stdOut, stdErr)
+		require.Contains(t, stdErr, fmt.Sprintf("os=%s arch=%s", runtime.GOOS, runtime.GOARCH))
+		require.Regexp(t, `timestamp=\d{8}T\d{6}Z`, stdErr)
+	})
 }
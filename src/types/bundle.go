@@ -17,9 +17,10 @@ type BundleZarfPackage struct {
 	Name               string                 `json:"name" jsonschema:"name=Name of the Zarf package"`
 	Repository         string                 `json:"repository,omitempty" jsonschema:"description=The repository to import the package from"`
 	Path               string                 `json:"path,omitempty" jsonschema:"description=The local path to import the package from"`
-	Ref                string                 `json:"ref" jsonschema:"description=Ref (tag) of the Zarf package"`
+	Ref                string                 `json:"ref" jsonschema:"description=Ref of the Zarf package: a tag (e.g. 0.0.1) or a digest to pin it to (e.g. 0.0.1-amd64@sha256:<digest> or sha256:<digest>)"`
 	OptionalComponents []string               `json:"optional-components,omitempty" jsonschema:"description=List of optional components to include from the package (required components are always included)"`
 	PublicKey          string                 `json:"public-key,omitempty" jsonschema:"description=The public key to use to verify the package"`
+	DependsOn          []string               `json:"depends-on,omitempty" jsonschema:"description=Names of packages in the bundle that must be deployed before this package"`
 	Imports            []BundleVariableImport `json:"imports,omitempty" jsonschema:"description=List of Zarf variables to import from another Zarf package"`
 	Exports            []BundleVariableExport `json:"exports,omitempty" jsonschema:"description=List of Zarf variables to export from the Zarf package"`
 	Overrides          BundleChartOverrides   `json:"overrides,omitempty" jsonschema:"description=List of Helm chart overrides to set"`
@@ -69,17 +70,18 @@ type BundleVariableExport struct {
 
 // UDSMetadata lists information about the current UDS Bundle.
 type UDSMetadata struct {
-	Name              string `json:"name" jsonschema:"description=Name to identify this Zarf package,pattern=^[a-z0-9\\-]+$"`
-	Description       string `json:"description,omitempty" jsonschema:"description=Additional information about this package"`
-	Version           string `json:"version,omitempty" jsonschema:"description=Generic string set by a package author to track the package version"`
-	URL               string `json:"url,omitempty" jsonschema:"description=Link to package information when online"`
-	Uncompressed      bool   `json:"uncompressed,omitempty" jsonschema:"description=Disable compression of this package"`
-	Architecture      string `json:"architecture,omitempty" jsonschema:"description=The target cluster architecture for this package,example=arm64,example=amd64"`
-	Authors           string `json:"authors,omitempty" jsonschema:"description=Comma-separated list of package authors (including contact info),example=Doug &#60;hello@defenseunicorns.com&#62;&#44; Pepr &#60;hello@defenseunicorns.com&#62;"`
-	Documentation     string `json:"documentation,omitempty" jsonschema:"description=Link to package documentation when online"`
-	Source            string `json:"source,omitempty" jsonschema:"description=Link to package source code when online"`
-	Vendor            string `json:"vendor,omitempty" jsonschema_description:"Name of the distributing entity, organization or individual."`
-	AggregateChecksum string `json:"aggregateChecksum,omitempty" jsonschema:"description=Checksum of a checksums.txt file that contains checksums all the layers within the package."`
+	Name              string            `json:"name" jsonschema:"description=Name to identify this Zarf package,pattern=^[a-z0-9\\-]+$"`
+	Description       string            `json:"description,omitempty" jsonschema:"description=Additional information about this package"`
+	Version           string            `json:"version,omitempty" jsonschema:"description=Generic string set by a package author to track the package version"`
+	URL               string            `json:"url,omitempty" jsonschema:"description=Link to package information when online"`
+	Uncompressed      bool              `json:"uncompressed,omitempty" jsonschema:"description=Disable compression of this package"`
+	Architecture      string            `json:"architecture,omitempty" jsonschema:"description=The target cluster architecture for this package,example=arm64,example=amd64"`
+	Authors           string            `json:"authors,omitempty" jsonschema:"description=Comma-separated list of package authors (including contact info),example=Doug &#60;hello@defenseunicorns.com&#62;&#44; Pepr &#60;hello@defenseunicorns.com&#62;"`
+	Documentation     string            `json:"documentation,omitempty" jsonschema:"description=Link to package documentation when online"`
+	Source            string            `json:"source,omitempty" jsonschema:"description=Link to package source code when online"`
+	Vendor            string            `json:"vendor,omitempty" jsonschema_description:"Name of the distributing entity, organization or individual."`
+	AggregateChecksum string            `json:"aggregateChecksum,omitempty" jsonschema:"description=Checksum of a checksums.txt file that contains checksums all the layers within the package."`
+	Annotations       map[string]string `json:"annotations,omitempty" jsonschema:"description=Extra OCI annotations to add to the bundle's manifest for registry UIs (e.g. org.opencontainers.image.licenses). The annotations set from the other metadata fields can't be overridden"`
 }
 
 // UDSBuildData is written during the bundle.Create() operation to track details of the created package.
@@ -89,4 +91,6 @@ type UDSBuildData struct {
 	Architecture string `json:"architecture" jsonschema:"description=The architecture this package was created on"`
 	Timestamp    string `json:"timestamp" jsonschema:"description=The timestamp when this package was created"`
 	Version      string `json:"version" jsonschema:"description=The version of Zarf used to build this package"`
+	// PackageLayers lists the digests of the layers each Zarf package (by name) has in the bundle
+	PackageLayers map[string][]string `json:"packageLayers,omitempty" jsonschema:"description=The digests of the layers each Zarf package has in the bundle"`
 }
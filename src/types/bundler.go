@@ -12,6 +12,8 @@ type BundlerConfig struct {
 	PullOpts    BundlerPullOptions
 	InspectOpts BundlerInspectOptions
 	RemoveOpts  BundlerRemoveOptions
+	DiffOpts    BundlerDiffOptions
+	VerifyOpts  BundlerVerifyOptions
 }
 
 // BundlerCreateOptions is the options for the bundler.Create() function
@@ -21,13 +23,29 @@ type BundlerCreateOptions struct {
 	SigningKeyPath     string
 	SigningKeyPassword string
 	SetVariables       map[string]string
+	RequireDigests     bool
+	AllowArchMismatch  bool
 }
 
 // BundlerDeployOptions is the options for the bundler.Deploy() function
 type BundlerDeployOptions struct {
-	Source               string
-	PublicKeyPath        string
+	Source string
+	SignaturePolicy
 	ZarfPackageVariables map[string]SetVariables
+	// Packages are the names of the bundle's packages to deploy, all of them are deployed when it is empty
+	Packages []string
+	// PackageRefs are Zarf package refs to deploy packages from instead of from the bundle, keyed by package name
+	PackageRefs map[string]string
+}
+
+// SignaturePolicy is how the signature of a bundle's uds-bundle.yaml is validated
+type SignaturePolicy struct {
+	// PublicKeyPath is the trusted public key, a path or a cosign KMS URI (e.g. awskms://...)
+	PublicKeyPath string
+	// SkipSignatureValidation skips validating the signature of signed bundles
+	SkipSignatureValidation bool
+	// StrictSignatureValidation errors when a signed bundle is used without a public key
+	StrictSignatureValidation bool
 }
 
 // SetVariables is a map of variables
@@ -37,23 +55,43 @@ type SetVariables struct {
 
 // BundlerInspectOptions is the options for the bundler.Inspect() function
 type BundlerInspectOptions struct {
-	PublicKeyPath string
-	Source        string
-	IncludeSBOM   bool
-	ExtractSBOM   bool
+	SignaturePolicy
+	Source      string
+	IncludeSBOM bool
+	ExtractSBOM bool
+	Output      string
+	NoBuildData bool
 }
 
 // BundlerPublishOptions is the options for the bundle.Publish() function
 type BundlerPublishOptions struct {
 	Source      string
 	Destination string
+	SizeOnly    bool
 }
 
 // BundlerPullOptions is the options for the bundler.Pull() function
 type BundlerPullOptions struct {
 	OutputDirectory string
-	PublicKeyPath   string
-	Source          string
+	// PackagesDirectory is where the bundle's uds-bundle.yaml and Zarf pkg tarballs are written instead of a bundle tarball
+	PackagesDirectory string
+	// Force overwrites existing files in the PackagesDirectory
+	Force bool
+	SignaturePolicy
+	Source string
+}
+
+// BundlerDiffOptions is the options for the bundler.Diff() function
+type BundlerDiffOptions struct {
+	Source string
+	Target string
+	Output string
+}
+
+// BundlerVerifyOptions is the options for the bundler.Verify() function
+type BundlerVerifyOptions struct {
+	Source string
+	SignaturePolicy
 }
 
 // BundlerRemoveOptions is the options for the bundler.Remove() function
@@ -68,4 +106,12 @@ type BundlerCommonOptions struct {
 	CachePath      string `json:"cachePath" jsonschema:"description=Path to use to cache images and git repos on package create"`
 	TempDirectory  string `json:"tempDirectory" jsonschema:"description=Location Zarf should use as a staging ground when managing files and images for package creation and deployment"`
 	OCIConcurrency int    `jsonschema:"description=Number of concurrent layer operations to perform when interacting with a remote package"`
+	OCIRetries     int    `jsonschema:"description=Number of times a registry operation that failed with a transient error is retried"`
+	Proxy          string `json:"proxy" jsonschema:"description=Proxy URL to use for connections to OCI registries"`
+	CAFile         string `json:"caFile" jsonschema:"description=Path to a PEM file of CA certificates to trust for connections to OCI registries in addition to the system's"`
+
+	// registry credentials are never marshaled so that they can't end up in debug output
+	RegistryUsername string `json:"-"`
+	RegistryPassword string `json:"-"`
+	RegistryToken    string `json:"-"`
 }
@@ -10,25 +10,142 @@ import (
 
 // TasksFile represents the contents of a tasks file
 type TasksFile struct {
-	Includes  []map[string]string             `json:"includes,omitempty" jsonschema:"description=List of local task files to include"`
-	Variables []zarfTypes.ZarfPackageVariable `json:"variables,omitempty" jsonschema:"description=Definitions and default values for variables used in run.yaml"`
-	Tasks     []Task                          `json:"tasks" jsonschema:"description=The list of tasks that can be run"`
+	Includes  []map[string]string                 `json:"includes,omitempty" jsonschema:"description=List of local task files to include"`
+	Variables []Variable                          `json:"variables,omitempty" jsonschema:"description=Definitions and default values for variables used in run.yaml"`
+	EnvFile   string                              `json:"envFile,omitempty" jsonschema:"description=Path to a dotenv file of KEY=VALUE variables to load (overrides variable defaults)"`
+	Default   string                              `json:"default,omitempty" jsonschema:"description=Name of the task to run when uds run is given no task name (defaults to a task named default)"`
+	Shell     *zarfTypes.ZarfComponentActionShell `json:"shell,omitempty" jsonschema:"description=Default shell (per OS) of every action that doesn't set its own shell for the OS (a shell can include flags such as bash -euo pipefail)"`
+	Tasks     []Task                              `json:"tasks" jsonschema:"description=The list of tasks that can be run"`
+	BeforeAll []Action                            `json:"beforeAll,omitempty" jsonschema:"description=Actions to run before the task that is run (and its dependencies)"`
+	AfterAll  []Action                            `json:"afterAll,omitempty" jsonschema:"description=Actions that always run after the task that is run (like a global finally) with its status in ${TASK_STATUS}"`
 }
 
+// Variable is a Zarf variable that can also be marked as required and typed
+type Variable struct {
+	zarfTypes.ZarfPackageVariable `yaml:",inline"`
+	Required                      bool                   `json:"required,omitempty" jsonschema:"description=Whether the variable must have a value, prompting for it when interactive and failing otherwise"`
+	Type                          zarfTypes.VariableType `json:"type,omitempty" jsonschema:"description=The type of the variable's value that is checked when the run starts (or file to load the value from a file),enum=raw,enum=file,enum=int,enum=bool,enum=enum"`
+	Enum                          []string               `json:"enum,omitempty" jsonschema:"description=The values allowed for the variable (required by the enum type)"`
+}
+
+// Types of a variable's value, in addition to Zarf's raw and file types
+const (
+	VariableTypeInt  zarfTypes.VariableType = "int"
+	VariableTypeBool zarfTypes.VariableType = "bool"
+	VariableTypeEnum zarfTypes.VariableType = "enum"
+)
+
 // Task represents a single task
 type Task struct {
-	Name        string               `json:"name" jsonschema:"description=Name of the task"`
-	Description string               `json:"description,omitempty" jsonschema:"description=Description of the task"`
-	Files       []zarfTypes.ZarfFile `json:"files,omitempty" jsonschema:"description=Files or folders to download or copy"`
-	Actions     []Action             `json:"actions,omitempty" jsonschema:"description=Actions to take when running the task"`
+	Name           string            `json:"name" jsonschema:"description=Name of the task"`
+	Description    string            `json:"description,omitempty" jsonschema:"description=Description of the task"`
+	Files          []File            `json:"files,omitempty" jsonschema:"description=Files or folders to download or copy"`
+	Actions        []Action          `json:"actions,omitempty" jsonschema:"description=Actions to take when running the task"`
+	Finally        []Action          `json:"finally,omitempty" jsonschema:"description=Actions that always run after the task's actions (like a defer) whether or not they succeeded"`
+	Parallel       bool              `json:"parallel,omitempty" jsonschema:"description=Run the task's actions concurrently instead of in order"`
+	MaxConcurrency int               `json:"maxConcurrency,omitempty" jsonschema:"description=Maximum number of actions to run at once when parallel is set (defaults to all of them)"`
+	DependsOn      []string          `json:"dependsOn,omitempty" jsonschema:"description=Names of tasks that must run (once) before this task"`
+	EnvFile        string            `json:"envFile,omitempty" jsonschema:"description=Path to a dotenv file of KEY=VALUE variables to load before the task runs (overrides variable defaults)"`
+	Env            map[string]string `json:"env,omitempty" jsonschema:"description=Environment variables (which can reference variables) set for every cmd and wait action of the task; an action's env takes precedence"`
+	Dir            string            `json:"dir,omitempty" jsonschema:"description=Default working directory of the task's actions and files, relative paths are resolved against the tasks file's directory"`
+	Internal       bool              `json:"internal,omitempty" jsonschema:"description=Hide the task from uds run --list (it is still shown with --list-all)"`
+	Labels         []string          `json:"labels,omitempty" jsonschema:"description=Labels to select the task by with uds run --selector label=<label>"`
+}
+
+// File is a Zarf file that can be symlinked outside of the working directory or written from inline content
+type File struct {
+	zarfTypes.ZarfFile  `yaml:",inline"`
+	Content             string `json:"content,omitempty" jsonschema:"description=Content to write to the target instead of copying a source (variables in it are templated)"`
+	AllowEmpty          bool   `json:"allowEmpty,omitempty" jsonschema:"description=Allow a source glob (e.g. configs/*.yaml) to match no files"`
+	AllowOutsideWorkdir bool   `json:"allowOutsideWorkdir,omitempty" jsonschema:"description=Allow the file's symlinks (or the file they link to) to be outside of the working directory"`
+	Mode                string `json:"mode,omitempty" jsonschema:"description=Octal mode to set on the placed file (e.g. 0644) instead of 0700 for executables and directories and 0600 otherwise"`
+	Retries             int    `json:"retries,omitempty" jsonschema:"description=How many times to retry a failed download of a URL source (defaults to --download-retries)"`
+	ArchiveFormat       string `json:"archiveFormat,omitempty" jsonschema:"description=Format of the archive to extract the extractPath from (defaults to the format given by the archive's name or content),enum=tar,enum=tar.gz,enum=tar.zst,enum=tar.bz2,enum=tar.xz,enum=zip"`
 }
 
-// TODO make schema complain if an action has more than one of cmd, task or wait
+// TODO make schema complain if an action has more than one of cmd, task, wait or http
 
 // Action is a Zarf action inside a Task
 type Action struct {
 	*zarfTypes.ZarfComponentAction `yaml:",inline"`
-	TaskReference                  string `json:"task,omitempty" jsonschema:"description=The task to run, mutually exclusive with cmd and wait"`
+	TaskReference                  string        `json:"task,omitempty" jsonschema:"description=The task to run, mutually exclusive with cmd and wait"`
+	Isolate                        bool          `json:"isolate,omitempty" jsonschema:"description=(Task only) Run the referenced task with a copy of the variables so that the variables it sets aren't visible to the following actions"`
+	If                             string        `json:"if,omitempty" jsonschema:"description=Only run the action when this condition is true (supports ==, != and the truthiness of a value)"`
+	Unless                         string        `json:"unless,omitempty" jsonschema:"description=Skip the action when this condition is true (supports ==, != and the truthiness of a value)"`
+	ForEach                        string        `json:"forEach,omitempty" jsonschema:"description=A comma or newline separated list to run the action once per item of, exposing the item as ${ITEM} and its index as ${ITEM_INDEX}"`
+	RequireConfirmation            bool          `json:"requireConfirmation,omitempty" jsonschema:"description=Ask to confirm the action (described by its description) before it runs or fail without a prompt unless uds run is given --confirm"`
+	ContinueOnError                bool          `json:"continueOnError,omitempty" jsonschema:"description=Keep going when the action (or an iteration of its forEach) fails, the run still fails once every action has completed"`
+	AlwaysRun                      bool          `json:"alwaysRun,omitempty" jsonschema:"description=(Cmd wait and http only) Run the action again when a failed run is resumed with --resume even if it completed before the run failed"`
+	Timeout                        string        `json:"timeout,omitempty" jsonschema:"description=(Cmd only) How long a single attempt of the command can run (e.g. 30s or 5m) before it is killed and retried, while maxTotalSeconds bounds all attempts combined"`
+	RetryDelay                     string        `json:"retryDelay,omitempty" jsonschema:"description=(Cmd only) How long to wait before retrying a failed command (e.g. 500ms or 2s), defaults to no delay"`
+	RetryBackoff                   string        `json:"retryBackoff,omitempty" jsonschema:"description=(Cmd only) How the retry delay grows with each retry,enum=constant,enum=linear,enum=exponential"`
+	RetryJitter                    bool          `json:"retryJitter,omitempty" jsonschema:"description=(Cmd only) Randomize each retry delay to between half and all of its duration"`
+	Wait                           *Wait         `json:"wait,omitempty" jsonschema:"description=Wait for a condition to be met before continuing. Must specify either cmd or wait for the action."`
+	HTTP                           *HTTPRequest  `json:"http,omitempty" jsonschema:"description=Make an HTTP request natively instead of shelling out to curl or wget"`
+	OutputFile                     string        `json:"outputFile,omitempty" jsonschema:"description=(Cmd only) Stream the stdout and stderr of the command (and of each of its retries) to this file as it runs"`
+	MaxOutputBytes                 int           `json:"maxOutputBytes,omitempty" jsonschema:"description=(Cmd only) How many bytes of the command's stdout (and of its stderr) to keep in memory for setVariables and logs (defaults to 4194304 and -1 keeps all of it)"`
+	OnMaxOutput                    string        `json:"onMaxOutput,omitempty" jsonschema:"description=(Cmd only) Whether to truncate the kept output and let the command complete (the default) or kill the command and fail the action once its output exceeds maxOutputBytes,enum=truncate,enum=kill"`
+	SetVariables                   []SetVariable `json:"setVariables,omitempty" jsonschema:"description=(Cmd and http only) An array of variables to update with the output of the command (or the body of the HTTP response). These variables will be available to all remaining actions and components."`
+}
+
+// HTTPRequest is an HTTP request made by an action, all of its fields can reference variables
+type HTTPRequest struct {
+	Method     string            `json:"method,omitempty" jsonschema:"description=The method of the request (defaults to GET)"`
+	URL        string            `json:"url" jsonschema:"description=The URL to send the request to"`
+	Headers    map[string]string `json:"headers,omitempty" jsonschema:"description=Headers to send with the request"`
+	Body       string            `json:"body,omitempty" jsonschema:"description=The body of the request"`
+	StatusCode int               `json:"statusCode,omitempty" jsonschema:"description=The status code the response must have (defaults to any 2xx status code)"`
+}
+
+// Wait is a Zarf wait that can also wait for a local file or a command
+type Wait struct {
+	zarfTypes.ZarfComponentActionWait `yaml:",inline"`
+	File                              *WaitFile    `json:"file,omitempty" jsonschema:"description=Wait for a local file or directory to exist or be deleted"`
+	Command                           *WaitCommand `json:"command,omitempty" jsonschema:"description=Wait for a command to exit with a zero exit code"`
+}
+
+// WaitFile waits for a local file or directory
+type WaitFile struct {
+	Path      string `json:"path" jsonschema:"description=The path of the file or directory, relative to the action's dir"`
+	Condition string `json:"condition,omitempty" jsonschema:"description=Whether to wait for the path to exist (the default) or to be deleted,enum=exists,enum=deleted"`
+}
+
+// WaitCommand waits for a command to succeed
+type WaitCommand struct {
+	Cmd string `json:"cmd" jsonschema:"description=The command to run until it exits with a zero exit code"`
+}
+
+// Conditions of a file wait
+const (
+	WaitFileExists  = "exists"
+	WaitFileDeleted = "deleted"
+)
+
+// Retry backoff strategies of an action
+const (
+	RetryBackoffConstant    = "constant"
+	RetryBackoffLinear      = "linear"
+	RetryBackoffExponential = "exponential"
+)
+
+// Output streams of a command that a variable can be set from
+const (
+	StreamStdout   = "stdout"
+	StreamStderr   = "stderr"
+	StreamCombined = "combined"
+)
+
+// What an action does once its command's output exceeds maxOutputBytes
+const (
+	OnMaxOutputTruncate = "truncate"
+	OnMaxOutputKill     = "kill"
+)
+
+// SetVariable is a variable set from the output of a cmd, optionally selecting a field of JSON output
+type SetVariable struct {
+	zarfTypes.ZarfComponentActionSetVariable `yaml:",inline"`
+	JSON                                     string `json:"json,omitempty" jsonschema:"description=A dotted path (e.g. .metadata.name or .items[0].status) selecting the value of the variable from the JSON output of the command"`
+	Stream                                   string `json:"stream,omitempty" jsonschema:"description=(Cmd only) The output of the command to set the variable from (defaults to stdout),enum=stdout,enum=stderr,enum=combined"`
 }
 
 // TaskReference references the name of a task